@@ -0,0 +1,180 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+type exportFormat string
+
+const (
+	exportFormatHTML exportFormat = ""
+	exportFormatCSV  exportFormat = "csv"
+	exportFormatTSV  exportFormat = "tsv"
+	exportFormatJSON exportFormat = "json"
+	exportFormatAPKG exportFormat = "apkg"
+)
+
+// exportFlushSize is the number of words after which an exported word list is
+// flushed to the client while the rest is still being read.
+const exportFlushSize = 1000
+
+var exportContentTypes = map[exportFormat]string{
+	exportFormatCSV:  "text/csv",
+	exportFormatTSV:  "text/tab-separated-values",
+	exportFormatJSON: "application/json",
+	exportFormatAPKG: "application/apkg",
+}
+
+// parseExportFormat returns the format requested through the format parameter
+// or, failing that, the first supported type in the Accept header. Browsers
+// ask for HTML first, so they get the regular page.
+func parseExportFormat(r *http.Request) (exportFormat, error) {
+	if value := r.URL.Query().Get("format"); value != "" {
+		format := exportFormat(strings.ToLower(value))
+
+		if format != "html" && exportContentTypes[format] == "" {
+			return exportFormatHTML, fmt.Errorf("invalid format %q", value)
+		}
+
+		if format == "html" {
+			return exportFormatHTML, nil
+		}
+
+		return format, nil
+	}
+
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+
+		if mediaType == "text/html" {
+			return exportFormatHTML, nil
+		}
+
+		for format, contentType := range exportContentTypes {
+			if mediaType == contentType {
+				return format, nil
+			}
+		}
+	}
+
+	return exportFormatHTML, nil
+}
+
+type exportable interface {
+	record() []string
+}
+
+// recordWriter streams rows to the response as CSV (with a header), TSV
+// (without, so that Quizlet and Memrise import every line as a card) or a JSON
+// array.
+type recordWriter struct {
+	w      http.ResponseWriter
+	format exportFormat
+	csv    *csv.Writer
+	count  int
+}
+
+func newRecordWriter(w http.ResponseWriter, format exportFormat, name string, columns []string) (*recordWriter, error) {
+	w.Header().Set("Content-Type", exportContentTypes[format]+"; charset=utf-8")
+
+	if format != exportFormatJSON {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName(name)+"."+string(format)))
+	}
+
+	rw := &recordWriter{w: w, format: format}
+
+	switch format {
+	case exportFormatCSV:
+		rw.csv = csv.NewWriter(w)
+
+		err := rw.csv.Write(columns)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write header: %w", err)
+		}
+	case exportFormatJSON:
+		_, err := io.WriteString(w, "[")
+		if err != nil {
+			return nil, fmt.Errorf("failed to write header: %w", err)
+		}
+	}
+
+	return rw, nil
+}
+
+func (rw *recordWriter) write(row exportable) error {
+	var err error
+
+	switch rw.format {
+	case exportFormatCSV:
+		err = rw.csv.Write(row.record())
+	case exportFormatTSV:
+		fields := row.record()
+
+		for i, field := range fields {
+			fields[i] = strings.Join(strings.Fields(field), " ")
+		}
+
+		_, err = io.WriteString(rw.w, strings.Join(fields, "\t")+"\n")
+	case exportFormatJSON:
+		var b []byte
+
+		b, err = json.Marshal(row)
+		if err != nil {
+			return fmt.Errorf("failed to encode row: %w", err)
+		}
+
+		separator := ",\n"
+
+		if rw.count == 0 {
+			separator = "\n"
+		}
+
+		_, err = io.WriteString(rw.w, separator+string(b))
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to write row: %w", err)
+	}
+
+	rw.count++
+
+	return nil
+}
+
+// flush sends the rows written so far to the client.
+func (rw *recordWriter) flush() error {
+	if rw.csv != nil {
+		rw.csv.Flush()
+
+		err := rw.csv.Error()
+		if err != nil {
+			return fmt.Errorf("failed to write rows: %w", err)
+		}
+	}
+
+	if flusher, ok := rw.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	return nil
+}
+
+func (rw *recordWriter) close() error {
+	if rw.format == exportFormatJSON {
+		_, err := io.WriteString(rw.w, "\n]\n")
+		if err != nil {
+			return fmt.Errorf("failed to write footer: %w", err)
+		}
+	}
+
+	return rw.flush()
+}
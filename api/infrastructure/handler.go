@@ -2,197 +2,362 @@ package api
 
 import (
 	"bytes"
+	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 	t "text/template"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/nienkeboomsma/vocabularium/api/infrastructure/template"
 	"github.com/nienkeboomsma/vocabularium/database"
 	"github.com/nienkeboomsma/vocabularium/domain"
+	exporter "github.com/nienkeboomsma/vocabularium/exporter/ports/driven"
+	importer "github.com/nienkeboomsma/vocabularium/importer/ports/driving"
 	repositories "github.com/nienkeboomsma/vocabularium/repositories/ports/driving"
 	"github.com/nienkeboomsma/vocabularium/textprocessor/ports/driven"
 	"github.com/nienkeboomsma/vocabularium/workpersister/ports/driving"
 )
 
+// actionLimit is the number of recent actions shown on the actions page.
+const actionLimit = 50
+
+// defaultTargetCoverage is the coverage the progress page projects a date
+// for, unless another one is given.
+const defaultTargetCoverage = 95.0
+
+// sessionCookie is the name of the cookie that holds the session token.
+const sessionCookie = "session"
+
 type API struct {
-	textProcessor    driven.TextProcessor
-	workPersister    driving.WorkPersister
-	authorRepository repositories.AuthorRepository
-	wordRepository   repositories.WordRepository
-	workRepository   repositories.WorkRepository
+	textProcessor           driven.TextProcessor
+	workPersister           driving.WorkPersister
+	deckExporter            exporter.DeckExporter
+	editionExporters        map[domain.EditionFormat]exporter.EditionExporter
+	knownWordImporter       importer.KnownWordImporter
+	authorRepository        repositories.AuthorRepository
+	classRepository         repositories.ClassRepository
+	collectionRepository    repositories.CollectionRepository
+	learningStateRepository repositories.LearningStateRepository
+	sectionRepository       repositories.SectionRepository
+	userRepository          repositories.UserRepository
+	wordRepository          repositories.WordRepository
+	workRepository          repositories.WorkRepository
+	workWordRepository      repositories.WorkWordRepository
 }
 
 func NewAPI(
 	tp driven.TextProcessor,
 	wp driving.WorkPersister,
+	de exporter.DeckExporter,
+	ee map[domain.EditionFormat]exporter.EditionExporter,
+	ki importer.KnownWordImporter,
 	authorRepository repositories.AuthorRepository,
+	classRepository repositories.ClassRepository,
+	collectionRepository repositories.CollectionRepository,
+	learningStateRepository repositories.LearningStateRepository,
+	sectionRepository repositories.SectionRepository,
+	userRepository repositories.UserRepository,
 	wordRepository repositories.WordRepository,
 	workRepository repositories.WorkRepository,
+	workWordRepository repositories.WorkWordRepository,
 ) *API {
 	return &API{
-		textProcessor:    tp,
-		workPersister:    wp,
-		authorRepository: authorRepository,
-		wordRepository:   wordRepository,
-		workRepository:   workRepository,
+		textProcessor:           tp,
+		workPersister:           wp,
+		deckExporter:            de,
+		editionExporters:        ee,
+		knownWordImporter:       ki,
+		authorRepository:        authorRepository,
+		classRepository:         classRepository,
+		collectionRepository:    collectionRepository,
+		learningStateRepository: learningStateRepository,
+		sectionRepository:       sectionRepository,
+		userRepository:          userRepository,
+		wordRepository:          wordRepository,
+		workRepository:          workRepository,
+		workWordRepository:      workWordRepository,
 	}
 }
 
-func (a *API) DeleteWork() http.HandlerFunc {
+// AssignToClass assigns a work to the class or, if a citation is given, the
+// section of the work with that citation.
+func (a *API) AssignToClass() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		id, err := uuid.Parse(r.PathValue("id"))
+		class, err := a.getTaughtClass(r)
+		if err != nil {
+			http.Error(w, "Failed to retrieve class", http.StatusBadRequest)
+			return
+		}
+
+		workID, err := uuid.Parse(r.FormValue("work"))
 		if err != nil {
-			fmt.Println(id)
 			http.Error(w, "Invalid UUID", http.StatusBadRequest)
 			return
 		}
 
-		err = a.workRepository.Delete(r.Context(), id)
+		assignment := domain.Assignment{
+			ID:      uuid.New(),
+			ClassID: class.ID,
+			WorkID:  workID,
+		}
+
+		citation := strings.TrimSpace(r.FormValue("citation"))
+
+		if citation != "" {
+			sections, err := a.sectionRepository.GetByWorkID(r.Context(), workID)
+			if err != nil {
+				http.Error(w, "Failed to retrieve sections", http.StatusBadRequest)
+				return
+			}
+
+			i := slices.IndexFunc(sections, func(section domain.Section) bool {
+				return section.Citation == citation
+			})
+			if i < 0 {
+				http.Error(w, "Unknown passage", http.StatusBadRequest)
+				return
+			}
+
+			assignment.SectionID = sections[i].ID
+		}
+
+		_, err = a.classRepository.SaveAssignment(r.Context(), assignment)
 		if err != nil {
-			fmt.Println(err)
-			http.Error(w, "Failed to delete work", http.StatusBadRequest)
+			http.Error(w, "Failed to save assignment", http.StatusBadRequest)
 			return
 		}
 
-		w.WriteHeader(http.StatusOK)
+		http.Redirect(w, r, "/classes/"+class.ID.String(), http.StatusSeeOther)
 	}
 }
 
-func (a *API) GetFrequencyList() http.HandlerFunc {
-	return handleWordList(
-		template.GetWordListTemplate("Frequency list", "📈"),
-		func(r *http.Request) (uuid.UUID, error) { return uuid.UUID{}, nil },
-		func(ctx context.Context, id uuid.UUID) (domain.Work, error) { return domain.Work{}, nil },
-		func(ctx context.Context, id uuid.UUID) (*[]domain.WordInWork, error) {
-			return a.wordRepository.GetFrequencyList(ctx)
-		},
-	)
-}
+// Authenticate puts the user who is logged in into the context of the
+// request, so that the repositories read and write their learning state.
+// Anyone else is sent to the login page, or refused if the request is not for
+// a page.
+func (a *API) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" || r.URL.Path == "/register" {
+			next.ServeHTTP(w, r)
+			return
+		}
 
-func (a *API) GetFrequencyListByAuthor() http.HandlerFunc {
-	return handleWordList(
-		template.GetWordListTemplate("Frequency list", "📈"),
-		func(r *http.Request) (uuid.UUID, error) { return uuid.Parse(r.PathValue("id")) },
-		func(ctx context.Context, id uuid.UUID) (domain.Work, error) {
-			author, err := a.authorRepository.GetByID(ctx, id)
-			if err != nil {
-				return domain.Work{}, err
+		cookie, err := r.Cookie(sessionCookie)
+		if err == nil {
+			user, err := a.userRepository.GetBySession(r.Context(), domain.HashSessionToken(cookie.Value), time.Now())
+			if err == nil {
+				next.ServeHTTP(w, r.WithContext(domain.ContextWithUser(r.Context(), user)))
+				return
 			}
+		}
 
-			return domain.Work{Author: domain.Author{
-				Name: author.Name,
-			}}, nil
-		},
-		a.wordRepository.GetFrequencyListByAuthorID,
-	)
+		if r.Method != http.MethodGet || strings.HasPrefix(r.URL.Path, "/api/") {
+			http.Error(w, "Not logged in", http.StatusUnauthorized)
+			return
+		}
+
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+	})
 }
 
-func (a *API) GetFrequencyListByWork() http.HandlerFunc {
-	return handleWordList(
-		template.GetWordListTemplate("Frequency list", "📈"),
-		func(r *http.Request) (uuid.UUID, error) { return uuid.Parse(r.PathValue("id")) },
-		a.workRepository.GetByID,
-		a.wordRepository.GetFrequencyListByWorkID,
-	)
+func (a *API) DeleteClass() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		class, err := a.getTaughtClass(r)
+		if err != nil {
+			http.Error(w, "Failed to retrieve class", http.StatusBadRequest)
+			return
+		}
+
+		err = a.classRepository.Delete(r.Context(), class.ID)
+		if err != nil {
+			http.Error(w, "Failed to delete class", http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
 }
 
-func (a *API) GetGlossaryByWork() http.HandlerFunc {
-	return handleWordList(
-		template.GetWordListTemplate("Glossary", "📖"),
-		func(r *http.Request) (uuid.UUID, error) { return uuid.Parse(r.PathValue("id")) },
-		a.workRepository.GetByID,
-		a.wordRepository.GetGlossaryByWorkID,
-	)
+func (a *API) DeleteClassAssignment() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		class, err := a.getTaughtClass(r)
+		if err != nil {
+			http.Error(w, "Failed to retrieve class", http.StatusBadRequest)
+			return
+		}
+
+		assignmentID, err := uuid.Parse(r.PathValue("assignmentID"))
+		if err != nil {
+			http.Error(w, "Invalid UUID", http.StatusBadRequest)
+			return
+		}
+
+		err = a.classRepository.DeleteAssignment(r.Context(), class.ID, assignmentID)
+		if err != nil {
+			http.Error(w, "Failed to delete assignment", http.StatusBadRequest)
+			return
+		}
+
+		http.Redirect(w, r, "/classes/"+class.ID.String(), http.StatusSeeOther)
+	}
 }
 
-func (a *API) GetWorks() http.HandlerFunc {
+func (a *API) DeleteCollection() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		works, err := a.workRepository.Get(r.Context())
+		id, err := uuid.Parse(r.PathValue("id"))
 		if err != nil {
-			http.Error(w, "Failed to retrieve works", http.StatusBadRequest)
+			http.Error(w, "Invalid UUID", http.StatusBadRequest)
 			return
 		}
 
-		useTemplate(w, template.GetWorkListTemplate(), works)
+		err = a.collectionRepository.Delete(r.Context(), id)
+		if err != nil {
+			http.Error(w, "Failed to delete collection", http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
 	}
 }
 
-func (a *API) Lemmatise() http.HandlerFunc {
+func (a *API) DeleteWork() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		workID := database.StringToUUID(fmt.Sprintf("%s_%s", r.FormValue("author"), r.FormValue("title")))
-		work, err := a.workRepository.GetByID(r.Context(), workID)
-		if err == nil {
-			w.WriteHeader(http.StatusBadRequest)
-			useTemplate(w, template.GetFailedWorkUploadTemplate(), template.UploadFailedData{
-				Message: "A work with this author and title already exists. Please remove it and try again.",
-				Error:   "",
-			})
+		id, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			fmt.Println(id)
+			http.Error(w, "Invalid UUID", http.StatusBadRequest)
 			return
 		}
 
-		file, _, err := r.FormFile("file")
+		err = a.workRepository.Delete(r.Context(), id)
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			useTemplate(w, template.GetFailedWorkUploadTemplate(), template.UploadFailedData{
-				Message: "Failed to get uploaded file from the request",
-				Error:   err.Error(),
-			})
+			fmt.Println(err)
+			http.Error(w, "Failed to delete work", http.StatusBadRequest)
 			return
 		}
-		defer file.Close()
 
-		uploadedData, err := io.ReadAll(file)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (a *API) GetActions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actions, err := a.learningStateRepository.GetActions(r.Context(), actionLimit)
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			useTemplate(w, template.GetFailedWorkUploadTemplate(), template.UploadFailedData{
-				Message: "Failed to read the contents of the uploaded file",
-				Error:   err.Error(),
-			})
+			http.Error(w, "Failed to retrieve actions", http.StatusBadRequest)
 			return
 		}
 
-		author := domain.Author{
-			ID:   database.StringToUUID(r.FormValue("author")),
-			Name: r.FormValue("author"),
+		useTemplate(w, template.GetActionsTemplate(), actions)
+	}
+}
+
+func (a *API) GetClass() http.HandlerFunc {
+	return a.handleClass(false)
+}
+
+func (a *API) GetClassAsJSON() http.HandlerFunc {
+	return a.handleClass(true)
+}
+
+// GetClassGlossary lists the words of an assignment that fewer than the
+// threshold percentage of the class knows, in the order in which they first
+// occur, so that they can be handed out before reading.
+func (a *API) GetClassGlossary() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		class, err := a.getTaughtClass(r)
+		if err != nil {
+			http.Error(w, "Failed to retrieve class", http.StatusBadRequest)
+			return
 		}
-		work = domain.Work{
-			ID:    workID,
-			Title: r.FormValue("title"),
+
+		assignmentID, err := uuid.Parse(r.PathValue("assignmentID"))
+		if err != nil {
+			http.Error(w, "Invalid UUID", http.StatusBadRequest)
+			return
 		}
 
-		workWords, words, logs, err := a.textProcessor.Process(uploadedData)
+		i := slices.IndexFunc(class.Assignments, func(assignment domain.Assignment) bool {
+			return assignment.ID == assignmentID
+		})
+		if i < 0 {
+			http.Error(w, "Failed to retrieve assignment", http.StatusBadRequest)
+			return
+		}
+
+		assignment := class.Assignments[i]
+
+		threshold, err := parseGlossaryThreshold(r)
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			useTemplate(w, template.GetFailedWorkUploadTemplate(), template.UploadFailedData{
-				Message: "Failed to process the uploaded text",
-				Error:   err.Error(),
-			})
+			http.Error(w, "Invalid threshold", http.StatusBadRequest)
+			return
+		}
+
+		format, err := parseExportFormat(r)
+		if err != nil || format == exportFormatAPKG {
+			http.Error(w, "Invalid format", http.StatusBadRequest)
 			return
 		}
 
-		err = a.workPersister.Persist(r.Context(), author, work, words, workWords)
+		words, _, err := a.wordRepository.GetGlossary(r.Context(), assignment.Scope(), domain.GlossaryOptions{FirstOccurrenceOnly: true}, domain.WordListFilter{Page: 1})
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			useTemplate(w, template.GetFailedWorkUploadTemplate(), template.UploadFailedData{
-				Message: "Failed to save the work in the database",
-				Error:   err.Error(),
-			})
+			http.Error(w, "Failed to retrieve words", http.StatusBadRequest)
 			return
 		}
 
-		for i, log := range logs {
-			logs[i] = t.HTMLEscapeString(log)
+		knownBy, err := a.classRepository.GetKnownBy(r.Context(), class.ID, assignment.Scope())
+		if err != nil {
+			http.Error(w, "Failed to retrieve knowledge of the class", http.StatusBadRequest)
+			return
 		}
 
-		w.WriteHeader(http.StatusBadRequest)
-		useTemplate(w, template.GetSuccessfulWorkUploadTemplate(), template.UploadSuccessData{Logs: logs})
+		glossary := domain.NewClassGlossary(*words, knownBy, len(class.Students), threshold)
+
+		if format != exportFormatHTML {
+			writeClassGlossary(w, format, class.Name+" "+assignment.Name(), glossary)
+			return
+		}
+
+		useTemplate(w, template.GetClassGlossaryTemplate(), template.ClassGlossaryPageData{
+			Class:      class,
+			Assignment: assignment,
+			Words:      glossary,
+			Threshold:  threshold,
+			Exports: []template.ExportLink{
+				{Label: "CSV", URL: exportURL(r, exportFormatCSV)},
+				{Label: "TSV", URL: exportURL(r, exportFormatTSV)},
+				{Label: "JSON", URL: exportURL(r, exportFormatJSON)},
+			},
+		})
 	}
 }
 
-func (a *API) ToggleKnownStatus() http.HandlerFunc {
+func (a *API) GetClasses() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		classes, err := a.classRepository.Get(r.Context())
+		if err != nil {
+			http.Error(w, "Failed to retrieve classes", http.StatusBadRequest)
+			return
+		}
+
+		user, _ := domain.UserFromContext(r.Context())
+
+		useTemplate(w, template.GetClassListTemplate(), template.ClassListPageData{
+			Classes: classes,
+			UserID:  user.ID,
+		})
+	}
+}
+
+func (a *API) GetCollection() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id, err := uuid.Parse(r.PathValue("id"))
 		if err != nil {
@@ -200,69 +365,2697 @@ func (a *API) ToggleKnownStatus() http.HandlerFunc {
 			return
 		}
 
-		_, err = a.wordRepository.ToggleKnownStatus(r.Context(), id)
+		collection, err := a.collectionRepository.GetByID(r.Context(), id)
 		if err != nil {
-			http.Error(w, "Failed to save updated known status", http.StatusBadRequest)
+			http.Error(w, "Failed to retrieve collection", http.StatusBadRequest)
 			return
 		}
 
-		w.WriteHeader(http.StatusOK)
+		works, err := a.workRepository.Get(r.Context(), domain.WorkSortAuthor, false)
+		if err != nil {
+			http.Error(w, "Failed to retrieve works", http.StatusBadRequest)
+			return
+		}
+
+		selected := map[uuid.UUID]bool{}
+		for _, workID := range collection.WorkIDs {
+			selected[workID] = true
+		}
+
+		useTemplate(w, template.GetCollectionTemplate(), template.CollectionPageData{
+			Collection: collection,
+			Works:      works,
+			Selected:   selected,
+		})
 	}
 }
 
-func (a *API) Upload() http.HandlerFunc {
+func (a *API) GetCollections() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		html := template.GetUploadTemplate()
-		w.Header().Set("Content-Type", "text/html; charset=utf-8")
-		w.Write([]byte(html))
+		collections, err := a.collectionRepository.Get(r.Context())
+		if err != nil {
+			http.Error(w, "Failed to retrieve collections", http.StatusBadRequest)
+			return
+		}
+
+		works, err := a.workRepository.Get(r.Context(), domain.WorkSortAuthor, false)
+		if err != nil {
+			http.Error(w, "Failed to retrieve works", http.StatusBadRequest)
+			return
+		}
+
+		worksByID := map[uuid.UUID]domain.Work{}
+		for _, work := range works {
+			worksByID[work.ID] = work
+		}
+
+		data := template.CollectionListPageData{Works: works}
+
+		for _, collection := range collections {
+			row := template.CollectionRow{Collection: collection}
+
+			for _, workID := range collection.WorkIDs {
+				row.Works = append(row.Works, worksByID[workID])
+			}
+
+			data.Collections = append(data.Collections, row)
+		}
+
+		useTemplate(w, template.GetCollectionListTemplate(), data)
 	}
 }
 
-func handleWordList(
-	htmlTemplate string,
-	idCallback func(r *http.Request) (uuid.UUID, error),
-	workCallback func(ctx context.Context, id uuid.UUID) (domain.Work, error),
-	wordCallback func(ctx context.Context, id uuid.UUID) (*[]domain.WordInWork, error),
-) http.HandlerFunc {
+func (a *API) GetComparison() http.HandlerFunc {
+	return a.handleComparison(false)
+}
+
+func (a *API) GetComparisonAsJSON() http.HandlerFunc {
+	return a.handleComparison(true)
+}
+
+func (a *API) GetCoverageCurve() http.HandlerFunc {
+	return handleCoverageCurve(
+		scopeFromPath(domain.ScopeKindCorpus),
+		a.getScopeAsWork,
+		a.wordRepository.GetFrequencyList,
+	)
+}
+
+func (a *API) GetCoverageCurveByAuthor() http.HandlerFunc {
+	return handleCoverageCurve(
+		scopeFromPath(domain.ScopeKindAuthor),
+		a.getScopeAsWork,
+		a.wordRepository.GetFrequencyList,
+	)
+}
+
+func (a *API) GetCoverageCurveByCollection() http.HandlerFunc {
+	return handleCoverageCurve(
+		scopeFromPath(domain.ScopeKindCollection),
+		a.getScopeAsWork,
+		a.wordRepository.GetFrequencyList,
+	)
+}
+
+func (a *API) GetCoverageCurveBySection() http.HandlerFunc {
+	return handleCoverageCurve(
+		scopeFromPath(domain.ScopeKindSection),
+		a.getScopeAsWork,
+		a.wordRepository.GetFrequencyList,
+	)
+}
+
+func (a *API) GetCoverageCurveByWork() http.HandlerFunc {
+	return handleCoverageCurve(
+		scopeFromPath(domain.ScopeKindWork),
+		a.getScopeAsWork,
+		a.wordRepository.GetFrequencyList,
+	)
+}
+
+func (a *API) EditWork() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		id, err := idCallback(r)
+		id, err := uuid.Parse(r.PathValue("id"))
 		if err != nil {
 			http.Error(w, "Invalid UUID", http.StatusBadRequest)
 			return
 		}
 
-		work, err := workCallback(r.Context(), id)
+		work, err := a.workRepository.GetByID(r.Context(), id)
 		if err != nil {
 			http.Error(w, "Failed to retrieve work", http.StatusBadRequest)
 			return
 		}
 
-		words, err := wordCallback(r.Context(), id)
+		useTemplate(w, template.GetEditWorkTemplate(), template.EditWorkPageData{
+			Work:  work,
+			Forms: []domain.WorkForm{domain.WorkFormProse, domain.WorkFormVerse},
+			Tags:  strings.Join(work.Tags, ", "),
+		})
+	}
+}
+
+// GetEdition shows the options of a reader's edition of the work and, once a
+// format has been chosen, sends the edition as a zip archive with its source
+// or as an e-book.
+func (a *API) GetEdition() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.PathValue("id"))
 		if err != nil {
-			http.Error(w, "Failed to retrieve words", http.StatusBadRequest)
+			http.Error(w, "Invalid UUID", http.StatusBadRequest)
+			return
+		}
+
+		work, err := a.workRepository.GetByID(r.Context(), id)
+		if err != nil {
+			http.Error(w, "Failed to retrieve work", http.StatusBadRequest)
 			return
 		}
 
-		if r.PathValue("skipKnown") == "true" {
-			filteredWords := []domain.WordInWork{}
+		format := domain.EditionFormat(r.URL.Query().Get("format"))
 
-			for _, word := range *words {
-				if word.Known {
-					continue
-				}
+		if format == "" {
+			useTemplate(w, template.GetEditionTemplate(), template.EditionPageData{
+				Work:         work,
+				Formats:      []domain.EditionFormat{domain.EditionFormatLaTeX, domain.EditionFormatTypst, domain.EditionFormatEPUB},
+				Units:        []domain.EditionUnit{domain.EditionUnitPage, domain.EditionUnitSection},
+				WordsPerPage: domain.DefaultWordsPerPage,
+			})
+			return
+		}
 
-				filteredWords = append(filteredWords, word)
-			}
+		exporter, ok := a.editionExporters[format]
+		if !ok {
+			http.Error(w, "Invalid format", http.StatusBadRequest)
+			return
+		}
 
-			words = &filteredWords
+		options, err := parseEditionOptions(r)
+		if err != nil {
+			http.Error(w, "Invalid edition options", http.StatusBadRequest)
+			return
 		}
 
-		useTemplate(w, htmlTemplate, template.WordListPageData{
-			Title:  work.Title,
-			Author: work.Author.Name,
-			Words:  words,
-		})
-	}
+		// E-books are read on their own rather than handed out, so only the
+		// words that are unknown at the time of export are glossed.
+		if format == domain.EditionFormatEPUB {
+			options.ExcludeKnown = true
+		}
+
+		workWords, err := a.workWordRepository.GetByWorkID(r.Context(), id)
+		if err != nil {
+			http.Error(w, "Failed to retrieve text", http.StatusBadRequest)
+			return
+		}
+
+		words, _, err := a.wordRepository.GetFrequencyList(r.Context(), domain.Scope{Kind: domain.ScopeKindWork, ID: id}, domain.WordListFilter{Page: 1})
+		if err != nil {
+			http.Error(w, "Failed to retrieve words", http.StatusBadRequest)
+			return
+		}
+
+		sections, err := a.sectionRepository.GetByWorkID(r.Context(), id)
+		if err != nil {
+			http.Error(w, "Failed to retrieve sections", http.StatusBadRequest)
+			return
+		}
+
+		var buf bytes.Buffer
+
+		err = exporter.Export(&buf, domain.NewEdition(work, workWords, *words, sections, options))
+		if err != nil {
+			http.Error(w, "Failed to export edition", http.StatusInternalServerError)
+			return
+		}
+
+		contentType, name := "application/zip", fileName(work.Title)+"-"+string(format)+".zip"
+
+		if format == domain.EditionFormatEPUB {
+			contentType, name = "application/epub+zip", fileName(work.Title)+".epub"
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+		w.Write(buf.Bytes())
+	}
+}
+
+func (a *API) GetFrequencyList() http.HandlerFunc {
+	return a.handleWordList(
+		template.GetWordListTemplate("Frequency list", "📈"),
+		scopeFromPath(domain.ScopeKindCorpus),
+		a.getScopeAsWork,
+		a.wordRepository.GetFrequencyList,
+		a.wordRepository.StreamFrequencyList,
+		nil,
+	)
+}
+
+func (a *API) GetFrequencyListByAuthor() http.HandlerFunc {
+	return a.handleWordList(
+		template.GetWordListTemplate("Frequency list", "📈"),
+		scopeFromPath(domain.ScopeKindAuthor),
+		a.getScopeAsWork,
+		a.wordRepository.GetFrequencyList,
+		a.wordRepository.StreamFrequencyList,
+		nil,
+	)
+}
+
+func (a *API) GetFrequencyListByCollection() http.HandlerFunc {
+	return a.handleWordList(
+		template.GetWordListTemplate("Frequency list", "📈"),
+		scopeFromPath(domain.ScopeKindCollection),
+		a.getScopeAsWork,
+		a.wordRepository.GetFrequencyList,
+		a.wordRepository.StreamFrequencyList,
+		nil,
+	)
+}
+
+func (a *API) GetFrequencyListBySection() http.HandlerFunc {
+	return a.handleWordList(
+		template.GetWordListTemplate("Frequency list", "📈"),
+		scopeFromPath(domain.ScopeKindSection),
+		a.getScopeAsWork,
+		a.wordRepository.GetFrequencyList,
+		a.wordRepository.StreamFrequencyList,
+		nil,
+	)
+}
+
+func (a *API) GetFrequencyListByWork() http.HandlerFunc {
+	return a.handleWordList(
+		template.GetWordListTemplate("Frequency list", "📈"),
+		scopeFromPath(domain.ScopeKindWork),
+		a.getScopeAsWork,
+		a.wordRepository.GetFrequencyList,
+		a.wordRepository.StreamFrequencyList,
+		nil,
+	)
+}
+
+func (a *API) GetGlossaryByCollection() http.HandlerFunc {
+	return a.handleGlossary(scopeFromPath(domain.ScopeKindCollection))
+}
+
+func (a *API) GetGlossaryBySection() http.HandlerFunc {
+	return a.handleGlossary(scopeFromPath(domain.ScopeKindSection))
+}
+
+func (a *API) GetGlossaryByWork() http.HandlerFunc {
+	return a.handleGlossary(scopeFromPath(domain.ScopeKindWork))
+}
+
+func (a *API) GetKeynessByAuthor() http.HandlerFunc {
+	return handleKeyness(
+		scopeFromPath(domain.ScopeKindAuthor),
+		a.getScopeAsWork,
+		a.wordRepository.GetKeywords,
+		false,
+	)
+}
+
+func (a *API) GetKeynessByAuthorAsJSON() http.HandlerFunc {
+	return handleKeyness(
+		scopeFromPath(domain.ScopeKindAuthor),
+		a.getScopeAsWork,
+		a.wordRepository.GetKeywords,
+		true,
+	)
+}
+
+func (a *API) GetKeynessByCollection() http.HandlerFunc {
+	return handleKeyness(
+		scopeFromPath(domain.ScopeKindCollection),
+		a.getScopeAsWork,
+		a.wordRepository.GetKeywords,
+		false,
+	)
+}
+
+func (a *API) GetKeynessByCollectionAsJSON() http.HandlerFunc {
+	return handleKeyness(
+		scopeFromPath(domain.ScopeKindCollection),
+		a.getScopeAsWork,
+		a.wordRepository.GetKeywords,
+		true,
+	)
+}
+
+func (a *API) GetKeynessBySection() http.HandlerFunc {
+	return handleKeyness(
+		scopeFromPath(domain.ScopeKindSection),
+		a.getScopeAsWork,
+		a.wordRepository.GetKeywords,
+		false,
+	)
+}
+
+func (a *API) GetKeynessBySectionAsJSON() http.HandlerFunc {
+	return handleKeyness(
+		scopeFromPath(domain.ScopeKindSection),
+		a.getScopeAsWork,
+		a.wordRepository.GetKeywords,
+		true,
+	)
+}
+
+func (a *API) GetKeynessByWork() http.HandlerFunc {
+	return handleKeyness(
+		scopeFromPath(domain.ScopeKindWork),
+		a.getScopeAsWork,
+		a.wordRepository.GetKeywords,
+		false,
+	)
+}
+
+func (a *API) GetKeynessByWorkAsJSON() http.HandlerFunc {
+	return handleKeyness(
+		scopeFromPath(domain.ScopeKindWork),
+		a.getScopeAsWork,
+		a.wordRepository.GetKeywords,
+		true,
+	)
+}
+
+// GetLearningStateAsJSON returns the learning state of the word in the path
+// together with its review history and the history of its status.
+func (a *API) GetLearningStateAsJSON() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid UUID", http.StatusBadRequest)
+			return
+		}
+
+		state, err := a.learningStateRepository.GetByWordID(r.Context(), id)
+		if err != nil {
+			http.Error(w, "Failed to retrieve learning state", http.StatusBadRequest)
+			return
+		}
+
+		reviews, err := a.learningStateRepository.GetReviews(r.Context(), id)
+		if err != nil {
+			http.Error(w, "Failed to retrieve reviews", http.StatusBadRequest)
+			return
+		}
+
+		history, err := a.learningStateRepository.GetHistory(r.Context(), id)
+		if err != nil {
+			http.Error(w, "Failed to retrieve history", http.StatusBadRequest)
+			return
+		}
+
+		writeJSON(w, toLearningStateResponse(state, reviews, history))
+	}
+}
+
+func (a *API) GetLogin() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		useTemplate(w, template.GetLoginTemplate(), template.LoginPageData{})
+	}
+}
+
+func (a *API) GetProgress() http.HandlerFunc {
+	return a.handleProgress(false)
+}
+
+func (a *API) GetProgressAsJSON() http.HandlerFunc {
+	return a.handleProgress(true)
+}
+
+func (a *API) GetReader() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid UUID", http.StatusBadRequest)
+			return
+		}
+
+		query := r.URL.Query()
+
+		unit := domain.ReaderUnit(cmp.Or(query.Get("unit"), string(domain.ReaderUnitSentence)))
+		if unit != domain.ReaderUnitSentence && unit != domain.ReaderUnitSection {
+			http.Error(w, "Invalid unit", http.StatusBadRequest)
+			return
+		}
+
+		sentencesPerPage, level, pageNumber := domain.DefaultSentencesPerPage, 0, 0
+
+		err = parseIntParams(query, map[string]*int{
+			"sentences": &sentencesPerPage,
+			"level":     &level,
+			"page":      &pageNumber,
+		})
+		if err != nil {
+			http.Error(w, "Invalid reader options", http.StatusBadRequest)
+			return
+		}
+
+		work, err := a.workRepository.GetByID(r.Context(), id)
+		if err != nil {
+			http.Error(w, "Failed to retrieve work", http.StatusBadRequest)
+			return
+		}
+
+		workWords, err := a.workWordRepository.GetByWorkID(r.Context(), id)
+		if err != nil {
+			http.Error(w, "Failed to retrieve text", http.StatusBadRequest)
+			return
+		}
+
+		words, _, err := a.wordRepository.GetFrequencyList(r.Context(), domain.Scope{Kind: domain.ScopeKindWork, ID: id}, domain.WordListFilter{Page: 1})
+		if err != nil {
+			http.Error(w, "Failed to retrieve words", http.StatusBadRequest)
+			return
+		}
+
+		sections, err := a.sectionRepository.GetByWorkID(r.Context(), id)
+		if err != nil {
+			http.Error(w, "Failed to retrieve sections", http.StatusBadRequest)
+			return
+		}
+
+		pages := domain.Paginate(workWords, sections, unit, sentencesPerPage, level)
+
+		// Without a page, the reader opens where it was left.
+		if pageNumber == 0 {
+			pageNumber = domain.PageOfWord(pages, work.ReadingPosition)
+		}
+
+		pageNumber = min(max(pageNumber, 1), max(len(pages), 1))
+
+		data := template.ReaderPageData{
+			Work:      work,
+			PageCount: len(pages),
+			Words:     make(map[uuid.UUID]domain.WordInWork, len(*words)),
+			Units:     []domain.ReaderUnit{domain.ReaderUnitSentence, domain.ReaderUnitSection},
+			Query:     query,
+		}
+
+		for _, word := range *words {
+			data.Words[word.ID] = word
+		}
+
+		if len(pages) > 0 {
+			data.Page = &pages[pageNumber-1]
+		}
+
+		if pageNumber > 1 {
+			data.PreviousURL = pageURL(r, pageNumber-1)
+		}
+
+		if pageNumber < len(pages) {
+			data.NextURL = pageURL(r, pageNumber+1)
+		}
+
+		useTemplate(w, template.GetReaderTemplate(), data)
+	}
+}
+
+func (a *API) GetRecommendations() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		maxNewLemmas := domain.DefaultMaxNewLemmas
+
+		if r.URL.Query().Has("maxNew") {
+			var err error
+
+			maxNewLemmas, err = strconv.Atoi(r.URL.Query().Get("maxNew"))
+			if err != nil || maxNewLemmas < 0 {
+				http.Error(w, "Invalid maximum number of new lemmas", http.StatusBadRequest)
+				return
+			}
+		}
+
+		works, err := a.workRepository.Get(r.Context(), domain.WorkSortAuthor, false)
+		if err != nil {
+			http.Error(w, "Failed to retrieve works", http.StatusBadRequest)
+			return
+		}
+
+		lemmaCounts, err := a.wordRepository.GetLemmaCountsByWork(r.Context())
+		if err != nil {
+			http.Error(w, "Failed to retrieve words", http.StatusBadRequest)
+			return
+		}
+
+		frequencyList, _, err := a.wordRepository.GetFrequencyList(r.Context(), domain.Scope{Kind: domain.ScopeKindCorpus}, domain.WordListFilter{})
+		if err != nil {
+			http.Error(w, "Failed to retrieve words", http.StatusBadRequest)
+			return
+		}
+
+		words := map[uuid.UUID]domain.WordInWork{}
+		for _, word := range *frequencyList {
+			words[word.ID] = word
+		}
+
+		useTemplate(w, template.GetRecommendationsTemplate(), template.RecommendationsPageData{
+			MaxNewLemmas:    maxNewLemmas,
+			Recommendations: domain.RankWorks(works, lemmaCounts, words),
+			ReadingPath:     domain.GetReadingPath(works, lemmaCounts, words, maxNewLemmas),
+		})
+	}
+}
+
+func (a *API) GetRegistration() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		useTemplate(w, template.GetLoginTemplate(), template.LoginPageData{Register: true})
+	}
+}
+
+// GetReview shows the first due flashcard, optionally limited to the words in
+// one work, with the front chosen in the query.
+func (a *API) GetReview() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		now := time.Now()
+
+		workID := uuid.Nil
+
+		if query.Get("work") != "" {
+			id, err := uuid.Parse(query.Get("work"))
+			if err != nil {
+				http.Error(w, "Invalid UUID", http.StatusBadRequest)
+				return
+			}
+
+			workID = id
+		}
+
+		front := domain.FlashcardFront(cmp.Or(query.Get("front"), string(domain.FlashcardFrontLemma)))
+
+		if front != domain.FlashcardFrontLemma && front != domain.FlashcardFrontContext {
+			http.Error(w, "Invalid front", http.StatusBadRequest)
+			return
+		}
+
+		cards, dueCount, err := a.learningStateRepository.GetDue(r.Context(), workID, 1, now)
+		if err != nil {
+			http.Error(w, "Failed to retrieve due cards", http.StatusBadRequest)
+			return
+		}
+
+		works, err := a.workRepository.Get(r.Context(), domain.WorkSortAuthor, false)
+		if err != nil {
+			http.Error(w, "Failed to retrieve works", http.StatusBadRequest)
+			return
+		}
+
+		data := template.ReviewPageData{
+			DueCount: dueCount,
+			Front:    front,
+			Fronts:   []domain.FlashcardFront{domain.FlashcardFrontLemma, domain.FlashcardFrontContext},
+			Orders:   []domain.QueueOrder{domain.QueueOrderFrequency, domain.QueueOrderFirstOccurrence},
+			Works:    works,
+			Query:    query,
+		}
+
+		if len(cards) > 0 {
+			card := cards[0]
+
+			card.Sentence, err = a.workWordRepository.GetSentence(r.Context(), card.Example.WorkID, card.Example.SentenceIndex)
+			if err != nil {
+				http.Error(w, "Failed to retrieve sentence", http.StatusBadRequest)
+				return
+			}
+
+			labels := map[domain.Grade]string{
+				domain.GradeAgain: "Again",
+				domain.GradeHard:  "Hard",
+				domain.GradeGood:  "Good",
+				domain.GradeEasy:  "Easy",
+			}
+
+			for _, grade := range []domain.Grade{domain.GradeAgain, domain.GradeHard, domain.GradeGood, domain.GradeEasy} {
+				next, _ := card.State.Review(grade, now)
+
+				data.Grades = append(data.Grades, template.GradeOption{
+					Grade:    grade,
+					Label:    labels[grade],
+					Interval: formatInterval(next.Due.Sub(now)),
+				})
+			}
+
+			data.Card = &card
+		}
+
+		useTemplate(w, template.GetReviewTemplate(), data)
+	}
+}
+
+func (a *API) GetSections() http.HandlerFunc {
+	return a.handleSections(false)
+}
+
+func (a *API) GetSectionsAsJSON() http.HandlerFunc {
+	return a.handleSections(true)
+}
+
+func (a *API) GetStatistics() http.HandlerFunc {
+	return a.handleStatistics(false)
+}
+
+func (a *API) GetStatisticsAsJSON() http.HandlerFunc {
+	return a.handleStatistics(true)
+}
+
+// GetWordHistory shows every change of the learning status of the word in the
+// path, oldest first.
+func (a *API) GetWordHistory() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid UUID", http.StatusBadRequest)
+			return
+		}
+
+		word, err := a.wordRepository.GetByID(r.Context(), id)
+		if err != nil {
+			http.Error(w, "Failed to retrieve word", http.StatusBadRequest)
+			return
+		}
+
+		state, err := a.learningStateRepository.GetByWordID(r.Context(), id)
+		if err != nil {
+			http.Error(w, "Failed to retrieve learning state", http.StatusBadRequest)
+			return
+		}
+
+		history, err := a.learningStateRepository.GetHistory(r.Context(), id)
+		if err != nil {
+			http.Error(w, "Failed to retrieve history", http.StatusBadRequest)
+			return
+		}
+
+		useTemplate(w, template.GetWordHistoryTemplate(), template.WordHistoryData{
+			Word:    word,
+			State:   state,
+			History: history,
+		})
+	}
+}
+
+func (a *API) GetWorks() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sort := domain.WorkSort(r.URL.Query().Get("sort"))
+		descending := r.URL.Query().Get("order") == "desc"
+
+		format, err := parseExportFormat(r)
+		if err != nil || format == exportFormatAPKG {
+			http.Error(w, "Invalid format", http.StatusBadRequest)
+			return
+		}
+
+		works, err := a.workRepository.Get(r.Context(), sort, descending)
+		if err != nil {
+			http.Error(w, "Failed to retrieve works", http.StatusBadRequest)
+			return
+		}
+
+		if format != exportFormatHTML {
+			writeWorks(w, format, works)
+			return
+		}
+
+		useTemplate(w, template.GetWorkListTemplate(), works)
+	}
+}
+
+// GradeFlashcard reschedules the word in the path according to the grade and
+// returns to the review page.
+func (a *API) GradeFlashcard() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid UUID", http.StatusBadRequest)
+			return
+		}
+
+		grade, err := domain.ParseGrade(r.FormValue("grade"))
+		if err != nil {
+			http.Error(w, "Invalid grade", http.StatusBadRequest)
+			return
+		}
+
+		_, err = a.learningStateRepository.Review(r.Context(), id, grade, time.Now())
+		if err != nil {
+			http.Error(w, "Failed to save review", http.StatusBadRequest)
+			return
+		}
+
+		query := url.Values{}
+
+		for _, key := range []string{"work", "front"} {
+			if r.FormValue(key) != "" {
+				query.Set(key, r.FormValue(key))
+			}
+		}
+
+		http.Redirect(w, r, "/review?"+query.Encode(), http.StatusSeeOther)
+	}
+}
+
+func (a *API) ImportKnownWords() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		responseFormat, err := parseExportFormat(r)
+		if err != nil || (responseFormat != exportFormatHTML && responseFormat != exportFormatJSON) {
+			http.Error(w, "Invalid format", http.StatusBadRequest)
+			return
+		}
+
+		format, err := domain.ParseKnownWordFormat(cmp.Or(r.FormValue("format"), string(domain.KnownWordFormatText)))
+		if err != nil {
+			http.Error(w, "Invalid list format", http.StatusBadRequest)
+			return
+		}
+
+		var list io.Reader = strings.NewReader(r.FormValue("list"))
+
+		file, _, err := r.FormFile("file")
+		if err == nil {
+			defer file.Close()
+			list = file
+		}
+
+		dryRun := r.FormValue("dryRun") == "true"
+
+		report, err := a.knownWordImporter.Import(r.Context(), list, format, dryRun, time.Now())
+		if err != nil {
+			http.Error(w, "Failed to import known words", http.StatusBadRequest)
+			return
+		}
+
+		if responseFormat == exportFormatJSON {
+			writeJSON(w, toKnownWordReportResponse(report))
+			return
+		}
+
+		useTemplate(w, template.GetKnownWordReportTemplate(), template.KnownWordReportData{
+			Report: report,
+			DryRun: dryRun,
+		})
+	}
+}
+
+// JoinClass makes the user a student of the class with the submitted join
+// code.
+func (a *API) JoinClass() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		code := strings.ToUpper(strings.TrimSpace(r.FormValue("code")))
+		if code == "" {
+			http.Error(w, "Join code is required", http.StatusBadRequest)
+			return
+		}
+
+		id, err := a.classRepository.Join(r.Context(), code)
+		if err != nil {
+			http.Error(w, "Failed to join class", http.StatusBadRequest)
+			return
+		}
+
+		http.Redirect(w, r, "/classes/"+id.String(), http.StatusSeeOther)
+	}
+}
+
+// LeaveClass removes the user from the students of the class in the path.
+func (a *API) LeaveClass() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid UUID", http.StatusBadRequest)
+			return
+		}
+
+		err = a.classRepository.Leave(r.Context(), id)
+		if err != nil {
+			http.Error(w, "Failed to leave class", http.StatusBadRequest)
+			return
+		}
+
+		http.Redirect(w, r, "/classes", http.StatusSeeOther)
+	}
+}
+
+func (a *API) Lemmatise() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		workID := database.StringToUUID(fmt.Sprintf("%s_%s", r.FormValue("author"), r.FormValue("title")))
+		work, err := a.workRepository.GetByID(r.Context(), workID)
+		if err == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			useTemplate(w, template.GetFailedWorkUploadTemplate(), template.UploadFailedData{
+				Message: "A work with this author and title already exists. Please remove it and try again.",
+				Error:   "",
+			})
+			return
+		}
+
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			useTemplate(w, template.GetFailedWorkUploadTemplate(), template.UploadFailedData{
+				Message: "Failed to get uploaded file from the request",
+				Error:   err.Error(),
+			})
+			return
+		}
+		defer file.Close()
+
+		uploadedData, err := io.ReadAll(file)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			useTemplate(w, template.GetFailedWorkUploadTemplate(), template.UploadFailedData{
+				Message: "Failed to read the contents of the uploaded file",
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		author := domain.Author{
+			ID:   database.StringToUUID(r.FormValue("author")),
+			Name: r.FormValue("author"),
+		}
+		work = domain.Work{
+			ID:    workID,
+			Title: r.FormValue("title"),
+		}
+
+		workWords, words, sections, logs, err := a.textProcessor.Process(uploadedData)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			useTemplate(w, template.GetFailedWorkUploadTemplate(), template.UploadFailedData{
+				Message: "Failed to process the uploaded text",
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		err = a.workPersister.Persist(r.Context(), author, work, words, workWords, sections)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			useTemplate(w, template.GetFailedWorkUploadTemplate(), template.UploadFailedData{
+				Message: "Failed to save the work in the database",
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		for i, log := range logs {
+			logs[i] = t.HTMLEscapeString(log)
+		}
+
+		w.WriteHeader(http.StatusBadRequest)
+		useTemplate(w, template.GetSuccessfulWorkUploadTemplate(), template.UploadSuccessData{Logs: logs})
+	}
+}
+
+// LogIn starts a session for the user with the submitted name and password.
+func (a *API) LogIn() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimSpace(r.FormValue("name"))
+
+		user, err := a.userRepository.GetByName(r.Context(), name)
+		if err != nil || !user.CheckPassword(r.FormValue("password")) {
+			w.WriteHeader(http.StatusUnauthorized)
+			useTemplate(w, template.GetLoginTemplate(), template.LoginPageData{
+				Name:  name,
+				Error: "Unknown name or wrong password",
+			})
+			return
+		}
+
+		err = a.startSession(w, r, user)
+		if err != nil {
+			http.Error(w, "Failed to log in", http.StatusInternalServerError)
+			return
+		}
+
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+	}
+}
+
+// LogOut ends the current session and clears its cookie.
+func (a *API) LogOut() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie(sessionCookie)
+		if err == nil {
+			err = a.userRepository.DeleteSession(r.Context(), domain.HashSessionToken(cookie.Value))
+			if err != nil {
+				http.Error(w, "Failed to log out", http.StatusInternalServerError)
+				return
+			}
+		}
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     sessionCookie,
+			Path:     "/",
+			MaxAge:   -1,
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+	}
+}
+
+func (a *API) MarkKnown() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		scope, err := domain.ParseScope(query.Get("scope"))
+		if err != nil {
+			http.Error(w, "Invalid scope", http.StatusBadRequest)
+			return
+		}
+
+		scope.Works, err = parseWorkFilter(r)
+		if err != nil {
+			http.Error(w, "Invalid scope", http.StatusBadRequest)
+			return
+		}
+
+		filter, err := parseWordListFilter(r)
+		if err != nil {
+			http.Error(w, "Invalid filter", http.StatusBadRequest)
+			return
+		}
+
+		top := 0
+
+		if r.FormValue("top") != "" {
+			top, err = strconv.Atoi(r.FormValue("top"))
+			if err != nil || top < 0 {
+				http.Error(w, "Invalid number of words", http.StatusBadRequest)
+				return
+			}
+		}
+
+		// Without a number of words, the whole list is marked.
+		filter.Page = 1
+		filter.PageSize = top
+
+		list := "frequency list"
+		wordCallback := a.wordRepository.GetFrequencyList
+
+		if query.Get("list") == "glossary" {
+			options, err := parseGlossaryOptions(r)
+			if err != nil {
+				http.Error(w, "Invalid glossary options", http.StatusBadRequest)
+				return
+			}
+
+			list = "glossary"
+			wordCallback = func(ctx context.Context, scope domain.Scope, filter domain.WordListFilter) (*[]domain.WordInWork, int, error) {
+				return a.wordRepository.GetGlossary(ctx, scope, options, filter)
+			}
+		}
+
+		work, err := a.getScopeAsWork(r.Context(), scope)
+		if err != nil {
+			http.Error(w, "Failed to retrieve work", http.StatusBadRequest)
+			return
+		}
+
+		words, _, err := wordCallback(r.Context(), scope, filter)
+		if err != nil {
+			http.Error(w, "Failed to retrieve words", http.StatusBadRequest)
+			return
+		}
+
+		ids := make([]uuid.UUID, 0, len(*words))
+
+		for _, word := range *words {
+			ids = append(ids, word.ID)
+		}
+
+		_, err = a.learningStateRepository.MarkKnown(r.Context(), ids, domain.StatusSourceBulk, markKnownDescription(query, list, work, top), time.Now())
+		if err != nil {
+			http.Error(w, "Failed to mark words as known", http.StatusBadRequest)
+			return
+		}
+
+		http.Redirect(w, r, "/actions", http.StatusSeeOther)
+	}
+}
+
+// Register creates an account and logs the new user in. The first account
+// takes over the learning state recorded before there were accounts.
+func (a *API) Register() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.FormValue("name")
+
+		user, err := domain.NewUser(name, r.FormValue("password"), time.Now())
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			useTemplate(w, template.GetLoginTemplate(), template.LoginPageData{
+				Register: true,
+				Name:     name,
+				Error:    "Failed to register: " + err.Error(),
+			})
+			return
+		}
+
+		user, err = a.userRepository.Register(r.Context(), user)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			useTemplate(w, template.GetLoginTemplate(), template.LoginPageData{
+				Register: true,
+				Name:     name,
+				Error:    "Failed to register; the name may already be taken",
+			})
+			return
+		}
+
+		err = a.startSession(w, r, user)
+		if err != nil {
+			http.Error(w, "Failed to log in", http.StatusInternalServerError)
+			return
+		}
+
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+	}
+}
+
+func (a *API) RemoveClassStudent() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		class, err := a.getTaughtClass(r)
+		if err != nil {
+			http.Error(w, "Failed to retrieve class", http.StatusBadRequest)
+			return
+		}
+
+		studentID, err := uuid.Parse(r.PathValue("studentID"))
+		if err != nil {
+			http.Error(w, "Invalid UUID", http.StatusBadRequest)
+			return
+		}
+
+		err = a.classRepository.RemoveStudent(r.Context(), class.ID, studentID)
+		if err != nil {
+			http.Error(w, "Failed to remove student", http.StatusBadRequest)
+			return
+		}
+
+		http.Redirect(w, r, "/classes/"+class.ID.String(), http.StatusSeeOther)
+	}
+}
+
+// SaveClass creates a class taught by the user, with a new join code, or
+// renames one.
+func (a *API) SaveClass() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		code, err := domain.NewJoinCode()
+		if err != nil {
+			http.Error(w, "Failed to generate join code", http.StatusInternalServerError)
+			return
+		}
+
+		class := domain.Class{
+			ID:       uuid.New(),
+			Name:     strings.TrimSpace(r.FormValue("name")),
+			JoinCode: code,
+		}
+
+		if r.PathValue("id") != "" {
+			id, err := uuid.Parse(r.PathValue("id"))
+			if err != nil {
+				http.Error(w, "Invalid UUID", http.StatusBadRequest)
+				return
+			}
+
+			class.ID = id
+		}
+
+		if class.Name == "" {
+			http.Error(w, "Class name is required", http.StatusBadRequest)
+			return
+		}
+
+		_, err = a.classRepository.Save(r.Context(), class)
+		if err != nil {
+			http.Error(w, "Failed to save class", http.StatusBadRequest)
+			return
+		}
+
+		http.Redirect(w, r, "/classes/"+class.ID.String(), http.StatusSeeOther)
+	}
+}
+
+// SaveCollection creates a collection, or updates the one in the path, from
+// the submitted name and work IDs.
+func (a *API) SaveCollection() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		collection := domain.Collection{
+			ID:      uuid.New(),
+			Name:    strings.TrimSpace(r.FormValue("name")),
+			WorkIDs: []uuid.UUID{},
+		}
+
+		if r.PathValue("id") != "" {
+			id, err := uuid.Parse(r.PathValue("id"))
+			if err != nil {
+				http.Error(w, "Invalid UUID", http.StatusBadRequest)
+				return
+			}
+
+			collection.ID = id
+		}
+
+		if collection.Name == "" {
+			http.Error(w, "Collection name is required", http.StatusBadRequest)
+			return
+		}
+
+		for _, value := range r.Form["work"] {
+			workID, err := uuid.Parse(value)
+			if err != nil {
+				http.Error(w, "Invalid UUID", http.StatusBadRequest)
+				return
+			}
+
+			collection.WorkIDs = append(collection.WorkIDs, workID)
+		}
+
+		_, err := a.collectionRepository.Save(r.Context(), collection)
+		if err != nil {
+			http.Error(w, "Failed to save collection", http.StatusBadRequest)
+			return
+		}
+
+		http.Redirect(w, r, "/collections", http.StatusSeeOther)
+	}
+}
+
+// SaveReadingPosition remembers where the user stopped reading the work in the
+// path, so that the reader reopens it there.
+func (a *API) SaveReadingPosition() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid UUID", http.StatusBadRequest)
+			return
+		}
+
+		position, err := strconv.Atoi(r.FormValue("position"))
+		if err != nil || position < 0 {
+			http.Error(w, "Invalid reading position", http.StatusBadRequest)
+			return
+		}
+
+		err = a.workRepository.SaveReadingPosition(r.Context(), id, position)
+		if err != nil {
+			http.Error(w, "Failed to save reading position", http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// SeedReviewQueue adds the unknown words of a work to the review queue, the
+// most frequent or the first to occur first, so that the work can be prepared
+// before reading it.
+func (a *API) SeedReviewQueue() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		workID, err := uuid.Parse(r.FormValue("work"))
+		if err != nil {
+			http.Error(w, "Invalid UUID", http.StatusBadRequest)
+			return
+		}
+
+		order := domain.QueueOrder(cmp.Or(r.FormValue("order"), string(domain.QueueOrderFrequency)))
+		known := false
+		filter := domain.WordListFilter{Known: &known}
+
+		switch order {
+		case domain.QueueOrderFrequency:
+		case domain.QueueOrderFirstOccurrence:
+			filter.Sort = domain.WordSortFirstOccurrence
+		default:
+			http.Error(w, "Invalid order", http.StatusBadRequest)
+			return
+		}
+
+		count := 20
+
+		if r.FormValue("count") != "" {
+			count, err = strconv.Atoi(r.FormValue("count"))
+			if err != nil || count < 1 {
+				http.Error(w, "Invalid count", http.StatusBadRequest)
+				return
+			}
+		}
+
+		words, _, err := a.wordRepository.GetFrequencyList(r.Context(), domain.Scope{Kind: domain.ScopeKindWork, ID: workID}, filter)
+		if err != nil {
+			http.Error(w, "Failed to retrieve words", http.StatusBadRequest)
+			return
+		}
+
+		wordIDs := make([]uuid.UUID, 0, len(*words))
+		for _, word := range *words {
+			wordIDs = append(wordIDs, word.ID)
+		}
+
+		added, err := a.learningStateRepository.Enqueue(r.Context(), wordIDs, count, time.Now())
+		if err != nil {
+			http.Error(w, "Failed to add words to the queue", http.StatusBadRequest)
+			return
+		}
+
+		query := url.Values{}
+		query.Set("work", workID.String())
+		query.Set("added", strconv.Itoa(added))
+
+		http.Redirect(w, r, "/review?"+query.Encode(), http.StatusSeeOther)
+	}
+}
+
+// SetLearningStatus sets the learning status of the word in the path by hand,
+// e.g. to ignore a proper name.
+func (a *API) SetLearningStatus() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid UUID", http.StatusBadRequest)
+			return
+		}
+
+		status, err := domain.ParseLearningStatus(r.FormValue("status"))
+		if err != nil {
+			http.Error(w, "Invalid learning status", http.StatusBadRequest)
+			return
+		}
+
+		_, err = a.learningStateRepository.SetStatus(r.Context(), id, status, time.Now())
+		if err != nil {
+			http.Error(w, "Failed to save learning status", http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (a *API) ToggleKnownStatus() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid UUID", http.StatusBadRequest)
+			return
+		}
+
+		_, err = a.learningStateRepository.ToggleKnown(r.Context(), id, time.Now())
+		if err != nil {
+			http.Error(w, "Failed to save updated known status", http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (a *API) ToggleReadStatus() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid UUID", http.StatusBadRequest)
+			return
+		}
+
+		_, err = a.workRepository.ToggleReadStatus(r.Context(), id)
+		if err != nil {
+			http.Error(w, "Failed to save updated read status", http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (a *API) UndoAction() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid UUID", http.StatusBadRequest)
+			return
+		}
+
+		_, err = a.learningStateRepository.Undo(r.Context(), id, time.Now())
+		if err != nil {
+			http.Error(w, "Failed to undo action", http.StatusBadRequest)
+			return
+		}
+
+		http.Redirect(w, r, "/actions", http.StatusSeeOther)
+	}
+}
+
+// UndoLatestActions undoes the given number of most recent actions that have
+// not been undone yet.
+func (a *API) UndoLatestActions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		count, err := strconv.Atoi(r.FormValue("count"))
+		if err != nil || count < 1 {
+			http.Error(w, "Invalid count", http.StatusBadRequest)
+			return
+		}
+
+		_, err = a.learningStateRepository.UndoLatest(r.Context(), count, time.Now())
+		if err != nil {
+			http.Error(w, "Failed to undo actions", http.StatusBadRequest)
+			return
+		}
+
+		http.Redirect(w, r, "/actions", http.StatusSeeOther)
+	}
+}
+
+func (a *API) UpdateWorkMetadata() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid UUID", http.StatusBadRequest)
+			return
+		}
+
+		metadata, err := parseWorkMetadata(r)
+		if err != nil {
+			http.Error(w, "Invalid metadata", http.StatusBadRequest)
+			return
+		}
+
+		_, err = a.workRepository.UpdateMetadata(r.Context(), id, metadata)
+		if err != nil {
+			http.Error(w, "Failed to save metadata", http.StatusBadRequest)
+			return
+		}
+
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+	}
+}
+
+func (a *API) Upload() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		html := template.GetUploadTemplate()
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(html))
+	}
+}
+
+func (a *API) UploadKnownWords() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		html := template.GetKnownWordImportTemplate()
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(html))
+	}
+}
+
+// startSession logs the user in by saving a new session and handing its token
+// to the browser.
+func (a *API) startSession(w http.ResponseWriter, r *http.Request, user domain.User) error {
+	session, token, err := domain.NewSession(user.ID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	err = a.userRepository.SaveSession(r.Context(), session)
+	if err != nil {
+		return err
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    token,
+		Path:     "/",
+		Expires:  session.Expires,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return nil
+}
+
+// getTaughtClass returns the class in the path, provided the user who makes
+// the request is its instructor.
+func (a *API) getTaughtClass(r *http.Request) (domain.Class, error) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return domain.Class{}, err
+	}
+
+	class, err := a.classRepository.GetByID(r.Context(), id)
+	if err != nil {
+		return domain.Class{}, err
+	}
+
+	user, _ := domain.UserFromContext(r.Context())
+
+	if !class.IsTaughtBy(user.ID) {
+		return domain.Class{}, fmt.Errorf("class %s is not taught by %s", id, user.Name)
+	}
+
+	return class, nil
+}
+
+func (a *API) getAuthorAsWork(ctx context.Context, id uuid.UUID) (domain.Work, error) {
+	author, err := a.authorRepository.GetByID(ctx, id)
+	if err != nil {
+		return domain.Work{}, err
+	}
+
+	return domain.Work{Author: domain.Author{
+		Name: author.Name,
+	}}, nil
+}
+
+func (a *API) getCollectionAsWork(ctx context.Context, id uuid.UUID) (domain.Work, error) {
+	collection, err := a.collectionRepository.GetByID(ctx, id)
+	if err != nil {
+		return domain.Work{}, err
+	}
+
+	return domain.Work{Author: domain.Author{
+		Name: collection.Name,
+	}}, nil
+}
+
+// getSectionAsWork returns the work the section belongs to, with the citation
+// of the section added to its title.
+func (a *API) getSectionAsWork(ctx context.Context, id uuid.UUID) (domain.Work, error) {
+	section, err := a.sectionRepository.GetByID(ctx, id)
+	if err != nil {
+		return domain.Work{}, err
+	}
+
+	work, err := a.workRepository.GetByID(ctx, section.WorkID)
+	if err != nil {
+		return domain.Work{}, err
+	}
+
+	work.Title = fmt.Sprintf("%s %s", work.Title, section.Citation)
+
+	return work, nil
+}
+
+// getScopeAsWork returns a work whose title and author name describe the scope
+// in page headings.
+func (a *API) getScopeAsWork(ctx context.Context, scope domain.Scope) (domain.Work, error) {
+	switch scope.Kind {
+	case domain.ScopeKindWork:
+		return a.workRepository.GetByID(ctx, scope.ID)
+	case domain.ScopeKindAuthor:
+		return a.getAuthorAsWork(ctx, scope.ID)
+	case domain.ScopeKindCollection:
+		return a.getCollectionAsWork(ctx, scope.ID)
+	case domain.ScopeKindSection:
+		return a.getSectionAsWork(ctx, scope.ID)
+	default:
+		return domain.Work{}, nil
+	}
+}
+
+func (a *API) handleGlossary(scopeCallback func(r *http.Request) (domain.Scope, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		options, err := parseGlossaryOptions(r)
+		if err != nil {
+			http.Error(w, "Invalid glossary options", http.StatusBadRequest)
+			return
+		}
+
+		a.handleWordList(
+			template.GetWordListTemplate("Glossary", "📖"),
+			scopeCallback,
+			a.getScopeAsWork,
+			func(ctx context.Context, scope domain.Scope, filter domain.WordListFilter) (*[]domain.WordInWork, int, error) {
+				return a.wordRepository.GetGlossary(ctx, scope, options, filter)
+			},
+			func(ctx context.Context, scope domain.Scope, filter domain.WordListFilter, fn func(domain.WordInWork) error) error {
+				return a.wordRepository.StreamGlossary(ctx, scope, options, filter, fn)
+			},
+			&options,
+		)(w, r)
+	}
+}
+
+func handleCoverageCurve(
+	scopeCallback func(r *http.Request) (domain.Scope, error),
+	workCallback func(ctx context.Context, scope domain.Scope) (domain.Work, error),
+	wordCallback func(ctx context.Context, scope domain.Scope, filter domain.WordListFilter) (*[]domain.WordInWork, int, error),
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		scope, err := scopeCallback(r)
+		if err != nil {
+			http.Error(w, "Invalid scope", http.StatusBadRequest)
+			return
+		}
+
+		thresholds := domain.DefaultCoverageThresholds
+
+		if r.URL.Query().Has("thresholds") {
+			thresholds = []float64{}
+
+			for _, value := range strings.Split(r.URL.Query().Get("thresholds"), ",") {
+				threshold, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+				if err != nil || threshold <= 0 || threshold > 100 {
+					http.Error(w, "Invalid thresholds", http.StatusBadRequest)
+					return
+				}
+
+				thresholds = append(thresholds, threshold)
+			}
+		}
+
+		work, err := workCallback(r.Context(), scope)
+		if err != nil {
+			http.Error(w, "Failed to retrieve work", http.StatusBadRequest)
+			return
+		}
+
+		words, _, err := wordCallback(r.Context(), scope, domain.WordListFilter{})
+		if err != nil {
+			http.Error(w, "Failed to retrieve words", http.StatusBadRequest)
+			return
+		}
+
+		curve := domain.NewCoverageCurve(*words, thresholds)
+
+		useTemplate(w, template.GetCoverageTemplate(), template.CoveragePageData{
+			Title:  work.Title,
+			Author: work.Author.Name,
+			Curve:  curve,
+			Chart:  template.GetCoverageChart(curve),
+		})
+	}
+}
+
+func (a *API) handleComparison(asJSON bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		options, err := a.getScopeOptions(r.Context())
+		if err != nil {
+			http.Error(w, "Failed to retrieve works", http.StatusBadRequest)
+			return
+		}
+
+		data := template.ComparisonPageData{
+			A:       query.Get("a"),
+			B:       query.Get("b"),
+			Known:   query.Get("known"),
+			Options: options,
+			JSONURL: "/api" + r.URL.Path + "?" + r.URL.RawQuery,
+		}
+
+		if !asJSON && data.A == "" && data.B == "" {
+			useTemplate(w, template.GetComparisonTemplate(), data)
+			return
+		}
+
+		scopeA, err := domain.ParseScope(data.A)
+		if err != nil {
+			http.Error(w, "Invalid scope A", http.StatusBadRequest)
+			return
+		}
+
+		scopeB, err := domain.ParseScope(data.B)
+		if err != nil {
+			http.Error(w, "Invalid scope B", http.StatusBadRequest)
+			return
+		}
+
+		filter, err := parseWordListFilter(r)
+		if err != nil {
+			http.Error(w, "Invalid filter", http.StatusBadRequest)
+			return
+		}
+
+		for _, option := range options {
+			if option.Value == scopeA.String() {
+				data.NameA = option.Label
+			}
+
+			if option.Value == scopeB.String() {
+				data.NameB = option.Label
+			}
+		}
+
+		if data.NameA == "" || data.NameB == "" {
+			http.Error(w, "Unknown work, author or collection", http.StatusBadRequest)
+			return
+		}
+
+		words, err := a.wordRepository.GetComparison(r.Context(), scopeA, scopeB, filter)
+		if err != nil {
+			http.Error(w, "Failed to retrieve words", http.StatusBadRequest)
+			return
+		}
+
+		comparison := domain.NewComparison(*words)
+		data.Comparison = &comparison
+
+		if asJSON {
+			writeJSON(w, comparisonResponse{
+				A:     scopeResponse{Scope: scopeA.String(), Name: data.NameA},
+				B:     scopeResponse{Scope: scopeB.String(), Name: data.NameB},
+				OnlyA: toComparedWordResponses(comparison.OnlyA),
+				OnlyB: toComparedWordResponses(comparison.OnlyB),
+				Both:  toComparedWordResponses(comparison.Both),
+			})
+			return
+		}
+
+		useTemplate(w, template.GetComparisonTemplate(), data)
+	}
+}
+
+// handleSections lists the section tree of the work in the path, with the
+// lexical richness of every section.
+func (a *API) handleSections(asJSON bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid UUID", http.StatusBadRequest)
+			return
+		}
+
+		work, err := a.workRepository.GetByID(r.Context(), id)
+		if err != nil {
+			http.Error(w, "Failed to retrieve work", http.StatusBadRequest)
+			return
+		}
+
+		sections, err := a.sectionRepository.GetByWorkID(r.Context(), id)
+		if err != nil {
+			http.Error(w, "Failed to retrieve sections", http.StatusBadRequest)
+			return
+		}
+
+		streams, err := a.wordRepository.GetLemmaStreamsBySection(r.Context(), id)
+		if err != nil {
+			http.Error(w, "Failed to retrieve words", http.StatusBadRequest)
+			return
+		}
+
+		frequencyList, _, err := a.wordRepository.GetFrequencyList(r.Context(), domain.Scope{Kind: domain.ScopeKindWork, ID: id}, domain.WordListFilter{})
+		if err != nil {
+			http.Error(w, "Failed to retrieve words", http.StatusBadRequest)
+			return
+		}
+
+		frequencies := laslaFrequencies(*frequencyList)
+
+		data := template.SectionsPageData{Work: work}
+
+		for _, section := range sections {
+			data.Sections = append(data.Sections, template.SectionRow{
+				Section:  section,
+				Richness: domain.NewLexicalRichness(streams[section.ID], frequencies),
+			})
+		}
+
+		if asJSON {
+			responses := []sectionResponse{}
+
+			for _, row := range data.Sections {
+				responses = append(responses, toSectionResponse(row))
+			}
+
+			writeJSON(w, responses)
+			return
+		}
+
+		useTemplate(w, template.GetSectionsTemplate(), data)
+	}
+}
+
+// getScopeOptions lists the corpus, every author, every collection and every
+// work as scopes that can be selected.
+func (a *API) getScopeOptions(ctx context.Context) ([]template.ScopeOption, error) {
+	works, err := a.workRepository.Get(ctx, domain.WorkSortAuthor, false)
+	if err != nil {
+		return []template.ScopeOption{}, err
+	}
+
+	options := []template.ScopeOption{{
+		Value: domain.Scope{Kind: domain.ScopeKindCorpus}.String(),
+		Label: "Corpus",
+	}}
+
+	for i, work := range works {
+		if i == 0 || works[i-1].Author.ID != work.Author.ID {
+			options = append(options, template.ScopeOption{
+				Value: domain.Scope{Kind: domain.ScopeKindAuthor, ID: work.Author.ID}.String(),
+				Label: work.Author.Name,
+			})
+		}
+	}
+
+	collections, err := a.collectionRepository.Get(ctx)
+	if err != nil {
+		return []template.ScopeOption{}, err
+	}
+
+	for _, collection := range collections {
+		options = append(options, template.ScopeOption{
+			Value: domain.Scope{Kind: domain.ScopeKindCollection, ID: collection.ID}.String(),
+			Label: collection.Name,
+		})
+	}
+
+	for _, work := range works {
+		options = append(options, template.ScopeOption{
+			Value: domain.Scope{Kind: domain.ScopeKindWork, ID: work.ID}.String(),
+			Label: fmt.Sprintf("%s (%s)", work.Title, work.Author.Name),
+		})
+	}
+
+	return options, nil
+}
+
+// handleClass shows the instructor of a class how well every student knows
+// the vocabulary of every assignment. Students see the assignments only.
+func (a *API) handleClass(asJSON bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid UUID", http.StatusBadRequest)
+			return
+		}
+
+		class, err := a.classRepository.GetByID(r.Context(), id)
+		if err != nil {
+			http.Error(w, "Failed to retrieve class", http.StatusBadRequest)
+			return
+		}
+
+		user, _ := domain.UserFromContext(r.Context())
+
+		data := template.ClassPageData{
+			Class:      class,
+			Instructor: class.IsTaughtBy(user.ID),
+			JSONURL:    "/api" + r.URL.Path,
+		}
+
+		if !data.Instructor {
+			if asJSON {
+				http.Error(w, "Only the instructor can see what the class knows", http.StatusForbidden)
+				return
+			}
+
+			useTemplate(w, template.GetClassTemplate(), data)
+			return
+		}
+
+		data.Threshold, err = parseGlossaryThreshold(r)
+		if err != nil {
+			http.Error(w, "Invalid threshold", http.StatusBadRequest)
+			return
+		}
+
+		coverage, err := a.classRepository.GetCoverage(r.Context(), class.ID)
+		if err != nil {
+			http.Error(w, "Failed to retrieve knowledge of the class", http.StatusBadRequest)
+			return
+		}
+
+		data.Knowledge = domain.NewClassKnowledge(class, coverage)
+
+		if asJSON {
+			writeJSON(w, toClassResponse(class, data.Knowledge))
+			return
+		}
+
+		data.Works, err = a.workRepository.Get(r.Context(), domain.WorkSortAuthor, false)
+		if err != nil {
+			http.Error(w, "Failed to retrieve works", http.StatusBadRequest)
+			return
+		}
+
+		useTemplate(w, template.GetClassTemplate(), data)
+	}
+}
+
+// handleProgress shows the number of known words over time, the words learned
+// per day or week, the coverage of every work and, for the text in the scope
+// parameter, when the target coverage will be reached at the current pace.
+func (a *API) handleProgress(asJSON bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		now := time.Now()
+
+		data := template.ProgressPageData{
+			Per:     query.Get("per"),
+			Window:  domain.DefaultPaceWindow,
+			Scope:   query.Get("scope"),
+			Target:  defaultTargetCoverage,
+			JSONURL: "/api" + r.URL.Path + "?" + r.URL.RawQuery,
+		}
+
+		if data.Per == "" {
+			data.Per = "week"
+		}
+
+		if data.Per != "day" && data.Per != "week" {
+			http.Error(w, "Invalid period", http.StatusBadRequest)
+			return
+		}
+
+		err := parseIntParams(query, map[string]*int{"days": &data.Window})
+		if err != nil || data.Window == 0 {
+			http.Error(w, "Invalid number of days", http.StatusBadRequest)
+			return
+		}
+
+		if query.Get("target") != "" {
+			data.Target, err = strconv.ParseFloat(query.Get("target"), 64)
+			if err != nil || data.Target <= 0 || data.Target > 100 {
+				http.Error(w, "Invalid target coverage", http.StatusBadRequest)
+				return
+			}
+		}
+
+		known, err := a.learningStateRepository.CountKnown(r.Context())
+		if err != nil {
+			http.Error(w, "Failed to retrieve known words", http.StatusBadRequest)
+			return
+		}
+
+		days, err := a.learningStateRepository.GetDailyProgress(r.Context())
+		if err != nil {
+			http.Error(w, "Failed to retrieve progress", http.StatusBadRequest)
+			return
+		}
+
+		data.Progress = domain.NewProgress(known, days, now, data.Window)
+
+		data.Works, err = a.workRepository.Get(r.Context(), domain.WorkSortCoverage, true)
+		if err != nil {
+			http.Error(w, "Failed to retrieve works", http.StatusBadRequest)
+			return
+		}
+
+		data.Options, err = a.getScopeOptions(r.Context())
+		if err != nil {
+			http.Error(w, "Failed to retrieve works", http.StatusBadRequest)
+			return
+		}
+
+		if data.Scope != "" {
+			scope, err := domain.ParseScope(data.Scope)
+			if err != nil {
+				http.Error(w, "Invalid scope", http.StatusBadRequest)
+				return
+			}
+
+			for _, option := range data.Options {
+				if option.Value == scope.String() {
+					data.Name = option.Label
+				}
+			}
+
+			if data.Name == "" {
+				http.Error(w, "Unknown work, author or collection", http.StatusBadRequest)
+				return
+			}
+
+			words, _, err := a.wordRepository.GetFrequencyList(r.Context(), scope, domain.WordListFilter{})
+			if err != nil {
+				http.Error(w, "Failed to retrieve words", http.StatusBadRequest)
+				return
+			}
+
+			projection := data.Progress.Project(domain.NewCoverageCurve(*words, nil), data.Target, now)
+			data.Projection = &projection
+		}
+
+		if asJSON {
+			writeJSON(w, toProgressResponse(data))
+			return
+		}
+
+		points := data.Progress.Weeks
+		if data.Per == "day" {
+			points = data.Progress.Days
+		}
+
+		data.KnownChart = template.GetKnownChart(data.Progress.Days)
+		data.LearnedChart = template.GetLearnedChart(points)
+
+		useTemplate(w, template.GetProgressTemplate(), data)
+	}
+}
+
+func (a *API) handleStatistics(asJSON bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		filter, err := parseWorkFilter(r)
+		if err != nil {
+			http.Error(w, "Invalid work filter", http.StatusBadRequest)
+			return
+		}
+
+		works, err := a.workRepository.GetByFilter(r.Context(), filter)
+		if err != nil {
+			http.Error(w, "Failed to retrieve works", http.StatusBadRequest)
+			return
+		}
+
+		collections, err := a.collectionRepository.Get(r.Context())
+		if err != nil {
+			http.Error(w, "Failed to retrieve collections", http.StatusBadRequest)
+			return
+		}
+
+		streams, err := a.wordRepository.GetLemmaStreamsByWork(r.Context())
+		if err != nil {
+			http.Error(w, "Failed to retrieve words", http.StatusBadRequest)
+			return
+		}
+
+		frequencyList, _, err := a.wordRepository.GetFrequencyList(r.Context(), domain.Scope{Kind: domain.ScopeKindCorpus}, domain.WordListFilter{})
+		if err != nil {
+			http.Error(w, "Failed to retrieve words", http.StatusBadRequest)
+			return
+		}
+
+		frequencies := laslaFrequencies(*frequencyList)
+
+		data := template.StatisticsPageData{Query: r.URL.Query()}
+		corpusStream := []uuid.UUID{}
+
+		// Works are sorted by author, so all works by one author are adjacent
+		var authorStream []uuid.UUID
+
+		for i, work := range works {
+			data.Works = append(data.Works, template.StatisticsRow{
+				ID:       work.ID,
+				Author:   work.Author.Name,
+				Title:    work.Title,
+				Richness: domain.NewLexicalRichness(streams[work.ID], frequencies),
+			})
+
+			authorStream = append(authorStream, streams[work.ID]...)
+			corpusStream = append(corpusStream, streams[work.ID]...)
+
+			if i == len(works)-1 || works[i+1].Author.ID != work.Author.ID {
+				data.Authors = append(data.Authors, template.StatisticsRow{
+					ID:       work.Author.ID,
+					Author:   work.Author.Name,
+					Richness: domain.NewLexicalRichness(authorStream, frequencies),
+				})
+
+				authorStream = nil
+			}
+		}
+
+		for _, collection := range collections {
+			inCollection := map[uuid.UUID]bool{}
+			for _, workID := range collection.WorkIDs {
+				inCollection[workID] = true
+			}
+
+			var collectionStream []uuid.UUID
+
+			for _, work := range works {
+				if inCollection[work.ID] {
+					collectionStream = append(collectionStream, streams[work.ID]...)
+				}
+			}
+
+			data.Collections = append(data.Collections, template.StatisticsRow{
+				ID:       collection.ID,
+				Title:    collection.Name,
+				Richness: domain.NewLexicalRichness(collectionStream, frequencies),
+			})
+		}
+
+		data.Corpus = template.StatisticsRow{
+			Richness: domain.NewLexicalRichness(corpusStream, frequencies),
+		}
+
+		if asJSON {
+			responses := []lexicalRichnessResponse{}
+
+			for _, row := range data.Works {
+				responses = append(responses, toLexicalRichnessResponse("work", row))
+			}
+
+			for _, row := range data.Authors {
+				responses = append(responses, toLexicalRichnessResponse("author", row))
+			}
+
+			for _, row := range data.Collections {
+				responses = append(responses, toLexicalRichnessResponse("collection", row))
+			}
+
+			responses = append(responses, toLexicalRichnessResponse("corpus", data.Corpus))
+
+			writeJSON(w, responses)
+			return
+		}
+
+		useTemplate(w, template.GetStatisticsTemplate(), data)
+	}
+}
+
+func handleKeyness(
+	scopeCallback func(r *http.Request) (domain.Scope, error),
+	workCallback func(ctx context.Context, scope domain.Scope) (domain.Work, error),
+	keywordCallback func(ctx context.Context, scope domain.Scope, reference domain.KeynessReference) (*[]domain.Keyword, error),
+	asJSON bool,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		scope, err := scopeCallback(r)
+		if err != nil {
+			http.Error(w, "Invalid scope", http.StatusBadRequest)
+			return
+		}
+
+		query := r.URL.Query()
+		reference := domain.KeynessReference(cmp.Or(query.Get("reference"), string(domain.KeynessReferenceCorpus)))
+		sort := domain.KeywordSort(query.Get("sort"))
+
+		if reference != domain.KeynessReferenceCorpus && reference != domain.KeynessReferenceLASLA {
+			http.Error(w, "Invalid reference", http.StatusBadRequest)
+			return
+		}
+
+		work, err := workCallback(r.Context(), scope)
+		if err != nil {
+			http.Error(w, "Failed to retrieve work", http.StatusBadRequest)
+			return
+		}
+
+		keywords, err := keywordCallback(r.Context(), scope, reference)
+		if err != nil {
+			http.Error(w, "Failed to retrieve words", http.StatusBadRequest)
+			return
+		}
+
+		// The statistics are calculated over all lemmas, so that filtering by
+		// known status does not change the corpus sizes
+		domain.ScoreKeywords(*keywords)
+		domain.SortKeywords(*keywords, sort)
+
+		if query.Get("known") != "" {
+			known := query.Get("known") == "true"
+
+			*keywords = slices.DeleteFunc(*keywords, func(keyword domain.Keyword) bool {
+				return keyword.Known != known
+			})
+		}
+
+		if asJSON {
+			writeJSON(w, toKeywordResponses(*keywords))
+			return
+		}
+
+		useTemplate(w, template.GetKeynessTemplate(), template.KeynessPageData{
+			Title:     work.Title,
+			Author:    work.Author.Name,
+			Reference: reference,
+			Known:     query.Get("known"),
+			Keywords:  *keywords,
+			JSONURL:   "/api" + r.URL.Path + "?" + r.URL.RawQuery,
+		})
+	}
+}
+
+func (a *API) handleWordList(
+	htmlTemplate string,
+	scopeCallback func(r *http.Request) (domain.Scope, error),
+	workCallback func(ctx context.Context, scope domain.Scope) (domain.Work, error),
+	wordCallback func(ctx context.Context, scope domain.Scope, filter domain.WordListFilter) (*[]domain.WordInWork, int, error),
+	streamCallback func(ctx context.Context, scope domain.Scope, filter domain.WordListFilter, fn func(domain.WordInWork) error) error,
+	glossaryOptions *domain.GlossaryOptions,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		scope, err := scopeCallback(r)
+		if err != nil {
+			http.Error(w, "Invalid scope", http.StatusBadRequest)
+			return
+		}
+
+		filter, err := parseWordListFilter(r)
+		if err != nil {
+			http.Error(w, "Invalid filter", http.StatusBadRequest)
+			return
+		}
+
+		if filter.Sort == domain.WordSortFirstOccurrence && scope.SpansWorks() {
+			http.Error(w, "Sorting by first occurrence requires a single work", http.StatusBadRequest)
+			return
+		}
+
+		format, err := parseExportFormat(r)
+		if err != nil {
+			http.Error(w, "Invalid format", http.StatusBadRequest)
+			return
+		}
+
+		if format == exportFormatAPKG {
+			filter.Page = 1
+			filter.PageSize = 0
+		}
+
+		work, err := workCallback(r.Context(), scope)
+		if err != nil {
+			http.Error(w, "Failed to retrieve work", http.StatusBadRequest)
+			return
+		}
+
+		switch format {
+		case exportFormatCSV, exportFormatTSV, exportFormatJSON:
+			writeWordList(w, r, format, cmp.Or(work.Title, "Corpus"), scope, filter, streamCallback)
+			return
+		}
+
+		words, total, err := wordCallback(r.Context(), scope, filter)
+		if err != nil {
+			http.Error(w, "Failed to retrieve words", http.StatusBadRequest)
+			return
+		}
+
+		if format == exportFormatAPKG {
+			a.writeDeck(w, r, scope, cmp.Or(work.Title, "Corpus"), *words)
+			return
+		}
+
+		data := template.WordListPageData{
+			Title:         work.Title,
+			Author:        work.Author.Name,
+			Words:         words,
+			Glossary:      glossaryOptions,
+			Dispersion:    scope.SpansWorks() && glossaryOptions == nil,
+			WorkFilter:    scope.SpansWorks(),
+			Query:         r.URL.Query(),
+			PartsOfSpeech: domain.PartsOfSpeech,
+			Total:         total,
+			Page:          filter.Page,
+			PageCount:     filter.PageCount(total),
+			Exports: []template.ExportLink{
+				{Label: "📇 Anki deck", URL: exportURL(r, exportFormatAPKG)},
+				{Label: "CSV", URL: exportURL(r, exportFormatCSV)},
+				{Label: "TSV", URL: exportURL(r, exportFormatTSV)},
+				{Label: "JSON", URL: exportURL(r, exportFormatJSON)},
+			},
+			MarkKnownURL: markKnownURL(r, scope, glossaryOptions != nil),
+		}
+
+		if filter.Page > 1 {
+			data.PreviousURL = pageURL(r, filter.Page-1)
+		}
+
+		if filter.Page < data.PageCount {
+			data.NextURL = pageURL(r, filter.Page+1)
+		}
+
+		useTemplate(w, htmlTemplate, data)
+	}
+}
+
+// writeWordList streams all words in the list, regardless of the requested
+// page, as they are read from the database. The response only starts with the
+// first word, so that a failing query can still be reported as such; once it
+// has started, an error aborts it, so that the client does not mistake the
+// partial list for a complete one.
+func writeWordList(
+	w http.ResponseWriter,
+	r *http.Request,
+	format exportFormat,
+	name string,
+	scope domain.Scope,
+	filter domain.WordListFilter,
+	streamCallback func(ctx context.Context, scope domain.Scope, filter domain.WordListFilter, fn func(domain.WordInWork) error) error,
+) {
+	var rw *recordWriter
+
+	started := false
+
+	start := func() error {
+		started = true
+
+		var err error
+
+		rw, err = newRecordWriter(w, format, name, wordColumns)
+
+		return err
+	}
+
+	err := streamCallback(r.Context(), scope, filter, func(word domain.WordInWork) error {
+		if !started {
+			err := start()
+			if err != nil {
+				return err
+			}
+		}
+
+		err := rw.write(toWordResponse(word))
+		if err != nil {
+			return err
+		}
+
+		if rw.count%exportFlushSize == 0 {
+			return rw.flush()
+		}
+
+		return nil
+	})
+	if err != nil {
+		if !started {
+			http.Error(w, "Failed to retrieve words", http.StatusBadRequest)
+			return
+		}
+
+		panic(http.ErrAbortHandler)
+	}
+
+	if !started {
+		err = start()
+		if err != nil {
+			panic(http.ErrAbortHandler)
+		}
+	}
+
+	err = rw.close()
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// writeClassGlossary writes the glossary of an assignment to the response,
+// aborting it if that fails halfway.
+func writeClassGlossary(w http.ResponseWriter, format exportFormat, name string, words []domain.ClassWord) {
+	rw, err := newRecordWriter(w, format, name, classWordColumns)
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+
+	for _, word := range words {
+		err = rw.write(toClassWordResponse(word))
+		if err != nil {
+			panic(http.ErrAbortHandler)
+		}
+	}
+
+	err = rw.close()
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// writeWorks writes the works to the response, aborting it if that fails
+// halfway.
+func writeWorks(w http.ResponseWriter, format exportFormat, works []domain.Work) {
+	rw, err := newRecordWriter(w, format, "works", workColumns)
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+
+	for _, work := range works {
+		err = rw.write(toWorkResponse(work))
+		if err != nil {
+			panic(http.ErrAbortHandler)
+		}
+	}
+
+	err = rw.close()
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// writeDeck sends the words as an Anki package, with the first occurrence of
+// every word in the scope as example.
+func (a *API) writeDeck(w http.ResponseWriter, r *http.Request, scope domain.Scope, name string, words []domain.WordInWork) {
+	examples, err := a.wordRepository.GetExamples(r.Context(), scope)
+	if err != nil {
+		http.Error(w, "Failed to retrieve examples", http.StatusBadRequest)
+		return
+	}
+
+	var buf bytes.Buffer
+
+	err = a.deckExporter.Export(&buf, domain.NewDeck(name, words, examples))
+	if err != nil {
+		http.Error(w, "Failed to export deck", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/apkg")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName(name)+".apkg"))
+	w.Write(buf.Bytes())
+}
+
+// fileName turns a title into a file name without spaces or characters that
+// are not allowed in file names.
+func fileName(title string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) {
+			return -1
+		}
+
+		return r
+	}, title)
+
+	return cmp.Or(strings.Join(strings.Fields(name), "_"), "vocabularium")
+}
+
+// scopeFromPath returns a callback that reads the scope of the given kind
+// from the request path, and the work filter from the query; the corpus scope
+// needs no ID.
+func scopeFromPath(kind domain.ScopeKind) func(r *http.Request) (domain.Scope, error) {
+	return func(r *http.Request) (domain.Scope, error) {
+		works, err := parseWorkFilter(r)
+		if err != nil {
+			return domain.Scope{}, err
+		}
+
+		if kind == domain.ScopeKindCorpus {
+			return domain.Scope{Kind: kind, Works: works}, nil
+		}
+
+		id, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			return domain.Scope{}, err
+		}
+
+		return domain.Scope{Kind: kind, ID: id, Works: works}, nil
+	}
+}
+
+// formatInterval describes the time until the next review in minutes, hours
+// or days.
+func formatInterval(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%d min", int(d.Round(time.Minute).Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%d h", int(d.Round(time.Hour).Hours()))
+	default:
+		return fmt.Sprintf("%d d", int(d.Round(24*time.Hour).Hours()/24))
+	}
+}
+
+// exportURL returns the URL of the current list, with all its words, in the
+// given format.
+func exportURL(r *http.Request, format exportFormat) string {
+	query := r.URL.Query()
+	query.Del("page")
+	query.Set("format", string(format))
+
+	return r.URL.Path + "?" + query.Encode()
+}
+
+// laslaFrequencies maps the words that occur in LASLA to their frequency
+// there, for domain.NewLexicalRichness.
+func laslaFrequencies(words []domain.WordInWork) map[uuid.UUID]int {
+	frequencies := map[uuid.UUID]int{}
+
+	for _, word := range words {
+		if word.FrequencyInLASLA > 0 {
+			frequencies[word.ID] = word.FrequencyInLASLA
+		}
+	}
+
+	return frequencies
+}
+
+// markKnownDescription describes the words marked as known by a bulk action,
+// for instance "Top 500 words of the frequency list of the corpus (minFrequency=1000)".
+func markKnownDescription(query url.Values, list string, work domain.Work, top int) string {
+	description := "All words"
+
+	if top > 0 {
+		description = fmt.Sprintf("Top %d words", top)
+	}
+
+	description += fmt.Sprintf(" of the %s of %s", list, cmp.Or(work.Title, "the corpus"))
+
+	filters := url.Values{}
+
+	for key, values := range query {
+		switch key {
+		case "scope", "list", "page", "pageSize", "format":
+		default:
+			filters[key] = values
+		}
+	}
+
+	if len(filters) > 0 {
+		description += " (" + filters.Encode() + ")"
+	}
+
+	return description
+}
+
+// markKnownURL returns the URL that marks the words in the list as known, with
+// the same scope and filters.
+func markKnownURL(r *http.Request, scope domain.Scope, glossary bool) string {
+	query := r.URL.Query()
+	query.Del("page")
+	query.Del("format")
+	query.Set("scope", scope.String())
+
+	if glossary {
+		query.Set("list", "glossary")
+	}
+
+	return "/mark-known?" + query.Encode()
+}
+
+func pageURL(r *http.Request, page int) string {
+	query := r.URL.Query()
+	query.Set("page", strconv.Itoa(page))
+
+	return r.URL.Path + "?" + query.Encode()
+}
+
+func parseEditionOptions(r *http.Request) (domain.EditionOptions, error) {
+	query := r.URL.Query()
+
+	options := domain.EditionOptions{
+		Unit:         domain.EditionUnit(cmp.Or(query.Get("unit"), string(domain.EditionUnitPage))),
+		WordsPerPage: domain.DefaultWordsPerPage,
+		ExcludeKnown: query.Get("known") == "false",
+	}
+
+	if options.Unit != domain.EditionUnitPage && options.Unit != domain.EditionUnitSection {
+		return domain.EditionOptions{}, fmt.Errorf("invalid unit %q", options.Unit)
+	}
+
+	err := parseIntParams(query, map[string]*int{
+		"wordsPerPage": &options.WordsPerPage,
+		"level":        &options.Level,
+		"maxFrequency": &options.MaxFrequencyInLASLA,
+	})
+	if err != nil {
+		return domain.EditionOptions{}, err
+	}
+
+	return options, nil
+}
+
+// parseGlossaryThreshold returns the percentage of a class below which a word
+// goes into its pre-reading glossary.
+func parseGlossaryThreshold(r *http.Request) (float64, error) {
+	value := r.URL.Query().Get("threshold")
+
+	if value == "" {
+		return domain.DefaultGlossaryThreshold, nil
+	}
+
+	threshold, err := strconv.ParseFloat(value, 64)
+	if err != nil || threshold <= 0 || threshold > 100 {
+		return 0, fmt.Errorf("invalid threshold %q", value)
+	}
+
+	return threshold, nil
+}
+
+func parseGlossaryOptions(r *http.Request) (domain.GlossaryOptions, error) {
+	query := r.URL.Query()
+
+	options := domain.GlossaryOptions{
+		Unit:                domain.RangeUnit(cmp.Or(query.Get("unit"), string(domain.RangeUnitWord))),
+		FirstOccurrenceOnly: query.Get("first") == "true",
+	}
+
+	if options.Unit != domain.RangeUnitWord && options.Unit != domain.RangeUnitSentence {
+		return domain.GlossaryOptions{}, fmt.Errorf("invalid unit %q", options.Unit)
+	}
+
+	err := parseOptionalIntParams(query, map[string]**int{
+		"from": &options.From,
+		"to":   &options.To,
+	})
+	if err != nil {
+		return domain.GlossaryOptions{}, err
+	}
+
+	return options, nil
+}
+
+func parseWordListFilter(r *http.Request) (domain.WordListFilter, error) {
+	query := r.URL.Query()
+
+	filter := domain.WordListFilter{
+		Sort:         domain.WordSort(query.Get("sort")),
+		Order:        domain.SortOrder(query.Get("order")),
+		Page:         1,
+		PageSize:     domain.DefaultPageSize,
+		PartOfSpeech: query.Get("pos"),
+		Search:       strings.TrimSpace(query.Get("q")),
+	}
+
+	switch filter.Sort {
+	case "", domain.WordSortCount, domain.WordSortAlphabetical, domain.WordSortLASLA, domain.WordSortFirstOccurrence,
+		domain.WordSortRange, domain.WordSortDP, domain.WordSortJuillandD, domain.WordSortAdjustedFrequency:
+	default:
+		return domain.WordListFilter{}, fmt.Errorf("invalid sort %q", filter.Sort)
+	}
+
+	switch filter.Order {
+	case "", domain.SortOrderAscending, domain.SortOrderDescending:
+	default:
+		return domain.WordListFilter{}, fmt.Errorf("invalid order %q", filter.Order)
+	}
+
+	switch query.Get("known") {
+	case "":
+	case "true", "false":
+		known := query.Get("known") == "true"
+		filter.Known = &known
+	default:
+		return domain.WordListFilter{}, fmt.Errorf("invalid known status %q", query.Get("known"))
+	}
+
+	err := parseIntParams(query, map[string]*int{
+		"page":     &filter.Page,
+		"pageSize": &filter.PageSize,
+		"minCount": &filter.MinCount,
+		"maxCount": &filter.MaxCount,
+	})
+	if err != nil {
+		return domain.WordListFilter{}, err
+	}
+
+	err = parseOptionalIntParams(query, map[string]**int{
+		"minFrequency": &filter.MinFrequencyInLASLA,
+		"maxFrequency": &filter.MaxFrequencyInLASLA,
+	})
+	if err != nil {
+		return domain.WordListFilter{}, err
+	}
+
+	filter.Page = max(filter.Page, 1)
+
+	return filter, nil
+}
+
+func parseWorkFilter(r *http.Request) (domain.WorkFilter, error) {
+	query := r.URL.Query()
+
+	filter := domain.WorkFilter{
+		Period:  strings.TrimSpace(query.Get("period")),
+		Genre:   strings.TrimSpace(query.Get("genre")),
+		Form:    domain.WorkForm(query.Get("form")),
+		Tag:     strings.TrimSpace(query.Get("tag")),
+		Edition: strings.TrimSpace(query.Get("edition")),
+		Notes:   strings.TrimSpace(query.Get("notes")),
+	}
+
+	switch filter.Form {
+	case "", domain.WorkFormProse, domain.WorkFormVerse:
+	default:
+		return domain.WorkFilter{}, fmt.Errorf("invalid form %q", filter.Form)
+	}
+
+	var err error
+
+	filter.FromYear, err = parseYear(query.Get("fromYear"))
+	if err != nil {
+		return domain.WorkFilter{}, err
+	}
+
+	filter.ToYear, err = parseYear(query.Get("toYear"))
+	if err != nil {
+		return domain.WorkFilter{}, err
+	}
+
+	return filter, nil
+}
+
+func parseWorkMetadata(r *http.Request) (domain.WorkMetadata, error) {
+	metadata := domain.WorkMetadata{
+		Period:  strings.TrimSpace(r.FormValue("period")),
+		Genre:   strings.TrimSpace(r.FormValue("genre")),
+		Form:    domain.WorkForm(r.FormValue("form")),
+		Edition: strings.TrimSpace(r.FormValue("edition")),
+		Notes:   strings.TrimSpace(r.FormValue("notes")),
+		Tags:    domain.ParseTags(r.FormValue("tags")),
+	}
+
+	switch metadata.Form {
+	case "", domain.WorkFormProse, domain.WorkFormVerse:
+	default:
+		return domain.WorkMetadata{}, fmt.Errorf("invalid form %q", metadata.Form)
+	}
+
+	var err error
+
+	metadata.Year, err = parseYear(r.FormValue("year"))
+	if err != nil {
+		return domain.WorkMetadata{}, err
+	}
+
+	return metadata, nil
+}
+
+// parseYear parses an optional year, which is negative for BC.
+func parseYear(value string) (*int, error) {
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		return nil, nil
+	}
+
+	year, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid year %q", value)
+	}
+
+	return &year, nil
+}
+
+func parseIntParams(query url.Values, targets map[string]*int) error {
+	for param, target := range targets {
+		if query.Get(param) == "" {
+			continue
+		}
+
+		value, err := strconv.Atoi(query.Get(param))
+		if err != nil || value < 0 {
+			return fmt.Errorf("invalid %s %q", param, query.Get(param))
+		}
+
+		*target = value
+	}
+
+	return nil
+}
+
+// parseOptionalIntParams is like parseIntParams, but leaves the targets of
+// missing parameters nil rather than zero.
+func parseOptionalIntParams(query url.Values, targets map[string]**int) error {
+	for param, target := range targets {
+		if query.Get(param) == "" {
+			continue
+		}
+
+		value, err := strconv.Atoi(query.Get(param))
+		if err != nil || value < 0 {
+			return fmt.Errorf("invalid %s %q", param, query.Get(param))
+		}
+
+		*target = &value
+	}
+
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, data any) {
+	var buf bytes.Buffer
+
+	err := json.NewEncoder(&buf).Encode(data)
+	if err != nil {
+		http.Error(w, "Failed to encode JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	buf.WriteTo(w)
 }
 
 func useTemplate(w http.ResponseWriter, template string, data any) {
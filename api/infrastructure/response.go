@@ -0,0 +1,581 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nienkeboomsma/vocabularium/api/infrastructure/template"
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type classResponse struct {
+	ID          uuid.UUID            `json:"id"`
+	Name        string               `json:"name"`
+	Students    []studentResponse    `json:"students"`
+	Assignments []assignmentResponse `json:"assignments"`
+}
+
+type studentResponse struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+type assignmentResponse struct {
+	ID           uuid.UUID                 `json:"id"`
+	WorkID       uuid.UUID                 `json:"workId"`
+	SectionID    *uuid.UUID                `json:"sectionId,omitempty"`
+	Name         string                    `json:"name"`
+	Author       string                    `json:"author"`
+	MeanCoverage float64                   `json:"meanCoverage"`
+	MinCoverage  float64                   `json:"minCoverage"`
+	Students     []studentCoverageResponse `json:"students"`
+}
+
+type studentCoverageResponse struct {
+	StudentID       uuid.UUID `json:"studentId"`
+	TokenCount      int       `json:"tokenCount"`
+	KnownTokenCount int       `json:"knownTokenCount"`
+	Coverage        float64   `json:"coverage"`
+}
+
+func toClassResponse(class domain.Class, knowledge []domain.AssignmentKnowledge) classResponse {
+	response := classResponse{
+		ID:          class.ID,
+		Name:        class.Name,
+		Students:    make([]studentResponse, 0, len(class.Students)),
+		Assignments: make([]assignmentResponse, 0, len(knowledge)),
+	}
+
+	for _, student := range class.Students {
+		response.Students = append(response.Students, studentResponse{ID: student.ID, Name: student.Name})
+	}
+
+	for _, row := range knowledge {
+		assignment := assignmentResponse{
+			ID:           row.Assignment.ID,
+			WorkID:       row.Assignment.WorkID,
+			Name:         row.Assignment.Name(),
+			Author:       row.Assignment.Author,
+			MeanCoverage: row.MeanCoverage,
+			MinCoverage:  row.MinCoverage,
+			Students:     make([]studentCoverageResponse, 0, len(row.Students)),
+		}
+
+		if row.Assignment.SectionID != uuid.Nil {
+			assignment.SectionID = &row.Assignment.SectionID
+		}
+
+		for _, c := range row.Students {
+			assignment.Students = append(assignment.Students, studentCoverageResponse{
+				StudentID:       c.StudentID,
+				TokenCount:      c.TokenCount,
+				KnownTokenCount: c.KnownTokenCount,
+				Coverage:        c.Coverage(),
+			})
+		}
+
+		response.Assignments = append(response.Assignments, assignment)
+	}
+
+	return response
+}
+
+type classWordResponse struct {
+	ID               uuid.UUID `json:"id"`
+	Lemma            string    `json:"lemma"`
+	LemmaRich        string    `json:"lemmaRich"`
+	Translation      string    `json:"translation"`
+	Count            int       `json:"count"`
+	FrequencyInLASLA int       `json:"frequencyInLasla"`
+	KnownBy          int       `json:"knownBy"`
+	Students         int       `json:"students"`
+}
+
+// classWordColumns are the column headings of classWordResponse.record; see
+// wordColumns.
+var classWordColumns = []string{"lemma", "translation", "lemmaRich", "count", "frequencyInLasla", "knownBy", "students"}
+
+func toClassWordResponse(word domain.ClassWord) classWordResponse {
+	return classWordResponse{
+		ID:               word.ID,
+		Lemma:            word.LemmaRaw,
+		LemmaRich:        word.LemmaRich,
+		Translation:      word.Translation,
+		Count:            word.Count,
+		FrequencyInLASLA: word.FrequencyInLASLA,
+		KnownBy:          word.KnownBy,
+		Students:         word.Students,
+	}
+}
+
+func (cr classWordResponse) record() []string {
+	return []string{
+		cr.Lemma,
+		cr.Translation,
+		cr.LemmaRich,
+		strconv.Itoa(cr.Count),
+		strconv.Itoa(cr.FrequencyInLASLA),
+		strconv.Itoa(cr.KnownBy),
+		strconv.Itoa(cr.Students),
+	}
+}
+
+type keywordResponse struct {
+	ID               uuid.UUID `json:"id"`
+	Lemma            string    `json:"lemma"`
+	LemmaRich        string    `json:"lemmaRich"`
+	Translation      string    `json:"translation"`
+	FrequencyInLASLA int       `json:"frequencyInLasla"`
+	Known            bool      `json:"known"`
+	TargetCount      int       `json:"targetCount"`
+	ReferenceCount   int       `json:"referenceCount"`
+	LogLikelihood    float64   `json:"logLikelihood"`
+	PercentDiff      float64   `json:"percentDiff"`
+	LogRatio         float64   `json:"logRatio"`
+}
+
+func toKeywordResponses(keywords []domain.Keyword) []keywordResponse {
+	responses := make([]keywordResponse, 0, len(keywords))
+
+	for _, keyword := range keywords {
+		responses = append(responses, keywordResponse{
+			ID:               keyword.ID,
+			Lemma:            keyword.LemmaRaw,
+			LemmaRich:        keyword.LemmaRich,
+			Translation:      keyword.Translation,
+			FrequencyInLASLA: keyword.FrequencyInLASLA,
+			Known:            keyword.Known,
+			TargetCount:      keyword.TargetCount,
+			ReferenceCount:   keyword.ReferenceCount,
+			LogLikelihood:    keyword.LogLikelihood,
+			PercentDiff:      keyword.PercentDiff,
+			LogRatio:         keyword.LogRatio,
+		})
+	}
+
+	return responses
+}
+
+type knownWordEntryResponse struct {
+	Line       int            `json:"line"`
+	Lemma      string         `json:"lemma"`
+	Candidates []wordResponse `json:"candidates,omitempty"`
+}
+
+type knownWordReportResponse struct {
+	Entries   int                      `json:"entries"`
+	Matched   []wordResponse           `json:"matched"`
+	Marked    int                      `json:"marked"`
+	Unmatched []knownWordEntryResponse `json:"unmatched"`
+	Ambiguous []knownWordEntryResponse `json:"ambiguous"`
+}
+
+func toKnownWordReportResponse(report domain.KnownWordReport) knownWordReportResponse {
+	response := knownWordReportResponse{
+		Entries:   report.Entries,
+		Matched:   make([]wordResponse, 0, len(report.Matched)),
+		Marked:    report.Marked,
+		Unmatched: make([]knownWordEntryResponse, 0, len(report.Unmatched)),
+		Ambiguous: make([]knownWordEntryResponse, 0, len(report.Ambiguous)),
+	}
+
+	for _, word := range report.Matched {
+		response.Matched = append(response.Matched, toWordResponse(word))
+	}
+
+	for _, entry := range report.Unmatched {
+		response.Unmatched = append(response.Unmatched, knownWordEntryResponse{Line: entry.Line, Lemma: entry.Lemma})
+	}
+
+	for _, entry := range report.Ambiguous {
+		candidates := make([]wordResponse, 0, len(entry.Candidates))
+
+		for _, word := range entry.Candidates {
+			candidates = append(candidates, toWordResponse(word))
+		}
+
+		response.Ambiguous = append(response.Ambiguous, knownWordEntryResponse{Line: entry.Line, Lemma: entry.Lemma, Candidates: candidates})
+	}
+
+	return response
+}
+
+type learningStateResponse struct {
+	WordID       uuid.UUID        `json:"wordId"`
+	Status       string           `json:"status"`
+	Known        bool             `json:"known"`
+	Due          *time.Time       `json:"due,omitempty"`
+	Stability    float64          `json:"stability"`
+	Difficulty   float64          `json:"difficulty"`
+	Repetitions  int              `json:"repetitions"`
+	Lapses       int              `json:"lapses"`
+	LastReviewed *time.Time       `json:"lastReviewed,omitempty"`
+	Reviews      []reviewResponse `json:"reviews"`
+	History      []changeResponse `json:"history"`
+}
+
+type reviewResponse struct {
+	Grade          int        `json:"grade"`
+	PreviousStatus string     `json:"previousStatus"`
+	Status         string     `json:"status"`
+	Stability      float64    `json:"stability"`
+	Difficulty     float64    `json:"difficulty"`
+	Due            *time.Time `json:"due,omitempty"`
+	Reviewed       time.Time  `json:"reviewed"`
+}
+
+type changeResponse struct {
+	ActionID       uuid.UUID  `json:"actionId"`
+	Source         string     `json:"source"`
+	Description    string     `json:"description,omitempty"`
+	PreviousStatus string     `json:"previousStatus"`
+	Status         string     `json:"status"`
+	Changed        time.Time  `json:"changed"`
+	Undone         *time.Time `json:"undone,omitempty"`
+}
+
+func toLearningStateResponse(state domain.LearningState, reviews []domain.Review, history []domain.StatusChange) learningStateResponse {
+	response := learningStateResponse{
+		WordID:       state.WordID,
+		Status:       string(state.Status),
+		Known:        state.Status.IsKnown(),
+		Due:          optionalTime(state.Due),
+		Stability:    state.Stability,
+		Difficulty:   state.Difficulty,
+		Repetitions:  state.Repetitions,
+		Lapses:       state.Lapses,
+		LastReviewed: optionalTime(state.LastReviewed),
+		Reviews:      make([]reviewResponse, 0, len(reviews)),
+		History:      make([]changeResponse, 0, len(history)),
+	}
+
+	for _, review := range reviews {
+		response.Reviews = append(response.Reviews, reviewResponse{
+			Grade:          int(review.Grade),
+			PreviousStatus: string(review.PreviousStatus),
+			Status:         string(review.Status),
+			Stability:      review.Stability,
+			Difficulty:     review.Difficulty,
+			Due:            optionalTime(review.Due),
+			Reviewed:       review.Reviewed,
+		})
+	}
+
+	for _, change := range history {
+		response.History = append(response.History, changeResponse{
+			ActionID:       change.ActionID,
+			Source:         string(change.Source),
+			Description:    change.Description,
+			PreviousStatus: string(change.PreviousStatus),
+			Status:         string(change.Status),
+			Changed:        change.Changed,
+			Undone:         optionalTime(change.Undone),
+		})
+	}
+
+	return response
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+type lexicalRichnessResponse struct {
+	Scope                string    `json:"scope"`
+	ID                   uuid.UUID `json:"id"`
+	Author               string    `json:"author,omitempty"`
+	Title                string    `json:"title,omitempty"`
+	TokenCount           int       `json:"tokenCount"`
+	TypeCount            int       `json:"typeCount"`
+	TypeTokenRatio       float64   `json:"typeTokenRatio"`
+	HapaxLegomena        int       `json:"hapaxLegomena"`
+	DisLegomena          int       `json:"disLegomena"`
+	MTLD                 float64   `json:"mtld"`
+	YulesK               float64   `json:"yulesK"`
+	MeanFrequencyInLASLA float64   `json:"meanFrequencyInLasla"`
+}
+
+func toLexicalRichnessResponse(scope string, row template.StatisticsRow) lexicalRichnessResponse {
+	return lexicalRichnessResponse{
+		Scope:                scope,
+		ID:                   row.ID,
+		Author:               row.Author,
+		Title:                row.Title,
+		TokenCount:           row.Richness.TokenCount,
+		TypeCount:            row.Richness.TypeCount,
+		TypeTokenRatio:       row.Richness.TypeTokenRatio,
+		HapaxLegomena:        row.Richness.HapaxLegomena,
+		DisLegomena:          row.Richness.DisLegomena,
+		MTLD:                 row.Richness.MTLD,
+		YulesK:               row.Richness.YulesK,
+		MeanFrequencyInLASLA: row.Richness.MeanFrequencyInLASLA,
+	}
+}
+
+type sectionResponse struct {
+	ID             uuid.UUID               `json:"id"`
+	ParentID       *uuid.UUID              `json:"parentId,omitempty"`
+	Kind           string                  `json:"kind"`
+	Label          string                  `json:"label"`
+	Citation       string                  `json:"citation"`
+	Depth          int                     `json:"depth"`
+	FirstWordIndex int                     `json:"firstWordIndex"`
+	LastWordIndex  int                     `json:"lastWordIndex"`
+	Richness       lexicalRichnessResponse `json:"richness"`
+}
+
+func toSectionResponse(row template.SectionRow) sectionResponse {
+	response := sectionResponse{
+		ID:             row.ID,
+		Kind:           row.Kind,
+		Label:          row.Label,
+		Citation:       row.Citation,
+		Depth:          row.Depth,
+		FirstWordIndex: row.FirstWordIndex,
+		LastWordIndex:  row.LastWordIndex,
+		Richness: toLexicalRichnessResponse("section", template.StatisticsRow{
+			ID:       row.ID,
+			Title:    row.Citation,
+			Richness: row.Richness,
+		}),
+	}
+
+	if row.ParentID != uuid.Nil {
+		response.ParentID = &row.ParentID
+	}
+
+	return response
+}
+
+type scopeResponse struct {
+	Scope string `json:"scope"`
+	Name  string `json:"name"`
+}
+
+type comparedWordResponse struct {
+	ID               uuid.UUID `json:"id"`
+	Lemma            string    `json:"lemma"`
+	LemmaRich        string    `json:"lemmaRich"`
+	Translation      string    `json:"translation"`
+	FrequencyInLASLA int       `json:"frequencyInLasla"`
+	Known            bool      `json:"known"`
+	CountA           int       `json:"countA"`
+	CountB           int       `json:"countB"`
+}
+
+type comparisonResponse struct {
+	A     scopeResponse          `json:"a"`
+	B     scopeResponse          `json:"b"`
+	OnlyA []comparedWordResponse `json:"onlyA"`
+	OnlyB []comparedWordResponse `json:"onlyB"`
+	Both  []comparedWordResponse `json:"both"`
+}
+
+func toComparedWordResponses(words []domain.ComparedWord) []comparedWordResponse {
+	responses := make([]comparedWordResponse, 0, len(words))
+
+	for _, word := range words {
+		responses = append(responses, comparedWordResponse{
+			ID:               word.ID,
+			Lemma:            word.LemmaRaw,
+			LemmaRich:        word.LemmaRich,
+			Translation:      word.Translation,
+			FrequencyInLASLA: word.FrequencyInLASLA,
+			Known:            word.Known,
+			CountA:           word.CountA,
+			CountB:           word.CountB,
+		})
+	}
+
+	return responses
+}
+
+type wordResponse struct {
+	ID               uuid.UUID `json:"id"`
+	Lemma            string    `json:"lemma"`
+	LemmaRich        string    `json:"lemmaRich"`
+	Translation      string    `json:"translation"`
+	Count            int       `json:"count"`
+	FrequencyInLASLA int       `json:"frequencyInLasla"`
+	Known            bool      `json:"known"`
+}
+
+// wordColumns are the column headings of wordResponse.record. The lemma and
+// its translation come first, because Quizlet and Memrise only import the
+// first two columns as term and definition.
+var wordColumns = []string{"lemma", "translation", "lemmaRich", "count", "frequencyInLasla", "known"}
+
+func toWordResponse(word domain.WordInWork) wordResponse {
+	return wordResponse{
+		ID:               word.ID,
+		Lemma:            word.LemmaRaw,
+		LemmaRich:        word.LemmaRich,
+		Translation:      word.Translation,
+		Count:            word.Count,
+		FrequencyInLASLA: word.FrequencyInLASLA,
+		Known:            word.Known,
+	}
+}
+
+func (wr wordResponse) record() []string {
+	return []string{
+		wr.Lemma,
+		wr.Translation,
+		wr.LemmaRich,
+		strconv.Itoa(wr.Count),
+		strconv.Itoa(wr.FrequencyInLASLA),
+		strconv.FormatBool(wr.Known),
+	}
+}
+
+type workResponse struct {
+	ID              uuid.UUID `json:"id"`
+	Title           string    `json:"title"`
+	Author          string    `json:"author"`
+	Period          string    `json:"period"`
+	Year            *int      `json:"year"`
+	Genre           string    `json:"genre"`
+	Form            string    `json:"form"`
+	Tags            []string  `json:"tags"`
+	TokenCount      int       `json:"tokenCount"`
+	KnownTokenCount int       `json:"knownTokenCount"`
+	Coverage        float64   `json:"coverage"`
+	Read            bool      `json:"read"`
+}
+
+var workColumns = []string{"title", "author", "period", "year", "genre", "form", "tags", "tokenCount", "knownTokenCount", "coverage", "read"}
+
+func toWorkResponse(work domain.Work) workResponse {
+	return workResponse{
+		ID:              work.ID,
+		Title:           work.Title,
+		Author:          work.Author.Name,
+		Period:          work.Period,
+		Year:            work.Year,
+		Genre:           work.Genre,
+		Form:            string(work.Form),
+		Tags:            work.Tags,
+		TokenCount:      work.TokenCount,
+		KnownTokenCount: work.KnownTokenCount,
+		Coverage:        work.Coverage(),
+		Read:            work.Read,
+	}
+}
+
+func (wr workResponse) record() []string {
+	year := ""
+
+	if wr.Year != nil {
+		year = strconv.Itoa(*wr.Year)
+	}
+
+	return []string{
+		wr.Title,
+		wr.Author,
+		wr.Period,
+		year,
+		wr.Genre,
+		wr.Form,
+		strings.Join(wr.Tags, ", "),
+		strconv.Itoa(wr.TokenCount),
+		strconv.Itoa(wr.KnownTokenCount),
+		strconv.FormatFloat(wr.Coverage, 'f', 2, 64),
+		strconv.FormatBool(wr.Read),
+	}
+}
+
+type progressResponse struct {
+	Known      int                     `json:"known"`
+	Pace       float64                 `json:"pace"`
+	PaceWindow int                     `json:"paceWindow"`
+	Days       []progressPointResponse `json:"days"`
+	Weeks      []progressPointResponse `json:"weeks"`
+	Works      []workCoverageResponse  `json:"works"`
+	Projection *projectionResponse     `json:"projection,omitempty"`
+}
+
+type progressPointResponse struct {
+	Start     string `json:"start"`
+	Learned   int    `json:"learned"`
+	Forgotten int    `json:"forgotten"`
+	Known     int    `json:"known"`
+}
+
+type workCoverageResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Author   string    `json:"author"`
+	Title    string    `json:"title"`
+	Coverage float64   `json:"coverage"`
+}
+
+type projectionResponse struct {
+	Scope         string  `json:"scope"`
+	Name          string  `json:"name"`
+	Target        float64 `json:"target"`
+	Coverage      float64 `json:"coverage"`
+	LemmasToLearn int     `json:"lemmasToLearn"`
+	Reached       bool    `json:"reached"`
+	Reachable     bool    `json:"reachable"`
+	Days          int     `json:"days,omitempty"`
+	Date          string  `json:"date,omitempty"`
+}
+
+func toProgressResponse(data template.ProgressPageData) progressResponse {
+	response := progressResponse{
+		Known:      data.Progress.Known,
+		Pace:       data.Progress.Pace,
+		PaceWindow: data.Progress.PaceWindow,
+		Days:       toProgressPointResponses(data.Progress.Days),
+		Weeks:      toProgressPointResponses(data.Progress.Weeks),
+		Works:      make([]workCoverageResponse, 0, len(data.Works)),
+	}
+
+	for _, work := range data.Works {
+		response.Works = append(response.Works, workCoverageResponse{
+			ID:       work.ID,
+			Author:   work.Author.Name,
+			Title:    work.Title,
+			Coverage: work.Coverage(),
+		})
+	}
+
+	if data.Projection != nil {
+		response.Projection = &projectionResponse{
+			Scope:         data.Scope,
+			Name:          data.Name,
+			Target:        data.Projection.Target,
+			Coverage:      data.Projection.Coverage,
+			LemmasToLearn: data.Projection.LemmasToLearn,
+			Reached:       data.Projection.Reached,
+			Reachable:     data.Projection.Reachable,
+			Days:          data.Projection.Days,
+		}
+
+		if !data.Projection.Date.IsZero() {
+			response.Projection.Date = data.Projection.Date.Format("2006-01-02")
+		}
+	}
+
+	return response
+}
+
+func toProgressPointResponses(points []domain.ProgressPoint) []progressPointResponse {
+	responses := make([]progressPointResponse, 0, len(points))
+
+	for _, point := range points {
+		responses = append(responses, progressPointResponse{
+			Start:     point.Start.Format("2006-01-02"),
+			Learned:   point.Learned,
+			Forgotten: point.Forgotten,
+			Known:     point.Known,
+		})
+	}
+
+	return responses
+}
@@ -0,0 +1,74 @@
+package template
+
+import (
+	"fmt"
+)
+
+func GetActionsTemplate() string {
+	template := `
+<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="UTF-8" />
+		<title>Actions</title>
+		<link rel="icon" href="https://fav.farm/↩️" />
+		<style>
+			%s
+			%s
+			%s
+		</style>
+	</head>
+	<body>
+		<nav>
+			<a href="http://localhost:4321">👈🏻 Back to works</a>
+		</nav>
+		<h1>Actions</h1>
+		{{if .}}
+			<form method="POST" action="http://localhost:4321/undo" onsubmit="return confirm('Undo the last ' + this.count.value + ' actions?')">
+				<label>
+					<span>Undo the last</span>
+					<input type="number" name="count" min="1" max="{{len .}}" value="1">
+					<span>actions</span>
+				</label>
+				<button type="submit">↩️ Undo</button>
+			</form>
+			<div class="table">
+				<table>
+					<thead>
+						<tr>
+							<th>When</th>
+							<th>What</th>
+							<th>Source</th>
+							<th>Words</th>
+							<th></th>
+						</tr>
+					</thead>
+					<tbody>
+						{{range .}}
+							<tr>
+								<td>{{.Created.Format "2006-01-02 15:04"}}</td>
+								<td>{{.Description}} <span class="subtle-inline">marked as {{.Status}}</span></td>
+								<td>{{.Source}}</td>
+								<td>{{.WordCount}}</td>
+								<td>
+									{{if .IsUndone}}
+										<span class="subtle-inline">undone {{.Undone.Format "2006-01-02 15:04"}}</span>
+									{{else}}
+										<form method="POST" action="http://localhost:4321/undo/{{.ID}}" onsubmit="return confirm('Restore the {{.WordCount}} words to their status before this action?')">
+											<button type="submit" title="Undo">↩️</button>
+										</form>
+									{{end}}
+								</td>
+							</tr>
+						{{end}}
+					</tbody>
+				</table>
+			</div>
+		{{else}}
+			<p>No actions to display</p>
+		{{end}}
+	</body>
+</html>
+`
+	return fmt.Sprintf(template, baseStyles, tableStyles, statisticsStyles)
+}
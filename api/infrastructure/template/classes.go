@@ -0,0 +1,329 @@
+package template
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type ClassListPageData struct {
+	Classes []domain.Class
+	UserID  uuid.UUID
+}
+
+type ClassPageData struct {
+	Class      domain.Class
+	Instructor bool
+	Works      []domain.Work
+	Knowledge  []domain.AssignmentKnowledge
+	Threshold  float64
+	JSONURL    string
+}
+
+type ClassGlossaryPageData struct {
+	Class      domain.Class
+	Assignment domain.Assignment
+	Words      []domain.ClassWord
+	Threshold  float64
+	Exports    []ExportLink
+}
+
+func GetClassListTemplate() string {
+	template := `
+<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="UTF-8" />
+		<title>Classes</title>
+		<link rel="icon" href="https://fav.farm/🏫" />
+		<style>
+			%s
+			%s
+			%s
+			%s
+		</style>
+	</head>
+	<body>
+		<nav>
+			<a href="http://localhost:4321">👈🏻 Back to works</a>
+		</nav>
+		<h1>Classes</h1>
+		{{if .Classes}}
+			<div class="table">
+				<table>
+					<thead>
+						<tr>
+							<th>Name</th>
+							<th></th>
+							<th></th>
+						</tr>
+					</thead>
+					<tbody>
+						{{range .Classes}}
+							<tr>
+								<td><a href="http://localhost:4321/classes/{{.ID}}">{{html .Name}}</a></td>
+								{{if .IsTaughtBy $.UserID}}
+									<td><span class="subtle-inline">instructor</span></td>
+									<td>
+										<button title="Delete {{html .Name}}" onclick="confirmAndDelete(this)" data-id="{{.ID}}">❌</button>
+									</td>
+								{{else}}
+									<td><span class="subtle-inline">student</span></td>
+									<td></td>
+								{{end}}
+							</tr>
+						{{end}}
+					</tbody>
+				</table>
+			</div>
+		{{else}}
+			<p>No classes to display</p>
+		{{end}}
+		<h2>Join a class</h2>
+		<form class="collection" method="POST" action="http://localhost:4321/join-class">
+			<label>
+				Join code
+				<input type="text" name="code" required>
+			</label>
+			<button type="submit">Join class</button>
+		</form>
+		<h2>New class</h2>
+		<form class="collection" method="POST" action="http://localhost:4321/classes">
+			<label>
+				Name
+				<input type="text" name="name" required>
+			</label>
+			<button type="submit">Create class</button>
+		</form>
+	</body>
+	<script>
+		async function confirmAndDelete(button) {
+			const id = button.getAttribute("data-id");
+			const confirmed = confirm("Are you sure you want to delete this class? The learning state of its students will not be deleted.");
+
+			if (!confirmed) return;
+
+			const url = "http://localhost:4321/delete-class/" + id;
+
+			try {
+				const response = await fetch(url, { method: "POST" });
+
+				if (!response.ok) {
+					button.textContent = "👎🏻";
+					button.title = "Failed to delete class; click to try again";
+					return;
+				}
+
+				const row = button.closest("tr");
+				if (row) row.remove();
+			} catch (error) {
+				button.textContent = "👎🏻";
+				button.title = "Failed to delete class; click to try again";
+			}
+		}
+	</script>
+</html>
+`
+
+	return fmt.Sprintf(template, baseStyles, tableStyles, statisticsStyles, collectionStyles)
+}
+
+func GetClassTemplate() string {
+	template := `
+<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="UTF-8" />
+		<title>{{html .Class.Name}}</title>
+		<link rel="icon" href="https://fav.farm/🏫" />
+		<style>
+			%s
+			%s
+			%s
+			%s
+			%s
+		</style>
+	</head>
+	<body>
+		<nav>
+			<a href="http://localhost:4321/classes">👈🏻 Back to classes</a>
+			{{if .Instructor}}
+				<a href="{{.JSONURL}}">📄 JSON</a>
+			{{end}}
+		</nav>
+		<h1>{{html .Class.Name}}</h1>
+		{{if .Instructor}}
+			<h2>Knowledge per assignment</h2>
+			{{if and .Knowledge .Class.Students}}
+				<div class="table">
+					<table>
+						<thead>
+							<tr>
+								<th>Assignment</th>
+								<th>Mean</th>
+								<th>Lowest</th>
+								{{range .Class.Students}}
+									<th>{{html .Name}}</th>
+								{{end}}
+								<th></th>
+								<th></th>
+							</tr>
+						</thead>
+						<tbody>
+							{{range .Knowledge}}
+								<tr>
+									<td>{{html .Assignment.Name}} <span class="subtle-inline">by {{html .Assignment.Author}}</span></td>
+									<td>{{printf "%%.1f" .MeanCoverage}}%%</td>
+									<td>{{printf "%%.1f" .MinCoverage}}%%</td>
+									{{range .Students}}
+										<td title="{{.KnownTokenCount}} of {{.TokenCount}} words known">{{printf "%%.1f" .Coverage}}%%</td>
+									{{end}}
+									<td>
+										<a title="Pre-reading glossary of the words fewer than {{$.Threshold}}%% of the class knows" href="http://localhost:4321/class-glossary/{{$.Class.ID}}/{{.Assignment.ID}}?threshold={{$.Threshold}}">📖</a>
+									</td>
+									<td>
+										<form method="POST" action="http://localhost:4321/delete-class-assignment/{{$.Class.ID}}/{{.Assignment.ID}}">
+											<button type="submit" title="Remove {{html .Assignment.Name}} from the assignments">❌</button>
+										</form>
+									</td>
+								</tr>
+							{{end}}
+						</tbody>
+					</table>
+				</div>
+			{{else}}
+				<p>Add students and assignments to see what the class knows</p>
+			{{end}}
+			<form class="filters" method="GET">
+				<label>
+					<span>Glossary of words known by fewer than</span>
+					<input type="number" name="threshold" min="1" max="100" step="any" value="{{.Threshold}}">
+					<span>%% of the class</span>
+				</label>
+				<button type="submit">Apply</button>
+			</form>
+			<h2>Assign a work or passage</h2>
+			<form class="collection" method="POST" action="http://localhost:4321/class-assignments/{{.Class.ID}}">
+				<label>
+					Work
+					<select name="work" required>
+						{{range .Works}}
+							<option value="{{.ID}}">{{html .Title}} by {{html .Author.Name}}</option>
+						{{end}}
+					</select>
+				</label>
+				<label>
+					Passage
+					<input type="text" name="citation" placeholder="64">
+					<span class="subtle-inline">citation of a section; leave empty for the whole work</span>
+				</label>
+				<button type="submit">Assign</button>
+			</form>
+			<h2>Students</h2>
+			{{range .Class.Students}}
+				<form class="student" method="POST" action="http://localhost:4321/delete-class-student/{{$.Class.ID}}/{{.ID}}">
+					{{html .Name}}
+					<button type="submit" title="Remove {{html .Name}} from the class">❌</button>
+				</form>
+			{{else}}
+				<p>No students to display</p>
+			{{end}}
+			<p>Students join the class with the code <strong>{{.Class.JoinCode}}</strong> on the classes page.</p>
+			<h2>Rename</h2>
+			<form class="collection" method="POST" action="http://localhost:4321/classes/{{.Class.ID}}">
+				<label>
+					Name
+					<input type="text" name="name" value="{{html .Class.Name}}" required>
+				</label>
+				<button type="submit">Save class</button>
+			</form>
+		{{else}}
+			<h2>Assignments</h2>
+			<div class="table">
+				<table>
+					<tbody>
+						{{range .Class.Assignments}}
+							<tr>
+								<td>{{html .Name}} <span class="subtle-inline">by {{html .Author}}</span></td>
+								<td>
+									<a title="Read {{html .Title}}" href="http://localhost:4321/read/{{.WorkID}}">👓</a>
+								</td>
+								<td>
+									{{if .Citation}}
+										<a title="{{html .Name}} glossary" href="http://localhost:4321/glossary-section/{{.SectionID}}?known=false">📖</a>
+									{{else}}
+										<a title="{{html .Name}} glossary" href="http://localhost:4321/glossary/{{.WorkID}}?known=false">📖</a>
+									{{end}}
+								</td>
+							</tr>
+						{{else}}
+							<tr><td>No assignments to display</td></tr>
+						{{end}}
+					</tbody>
+				</table>
+			</div>
+			<form method="POST" action="http://localhost:4321/leave-class/{{.Class.ID}}" onsubmit="return confirm('Are you sure you want to leave this class?')">
+				<button type="submit">Leave class</button>
+			</form>
+		{{end}}
+	</body>
+</html>
+`
+
+	return fmt.Sprintf(template, baseStyles, tableStyles, wordListStyles, statisticsStyles, collectionStyles)
+}
+
+func GetClassGlossaryTemplate() string {
+	template := `
+<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="UTF-8" />
+		<title>{{html .Assignment.Name}} for {{html .Class.Name}}</title>
+		<link rel="icon" href="https://fav.farm/📖" />
+		<style>
+			%s
+			%s
+			%s
+		</style>
+	</head>
+	<body>
+		<nav>
+			<a href="http://localhost:4321/classes/{{.Class.ID}}">👈🏻 Back to {{html .Class.Name}}</a>
+			{{range .Exports}}
+				<a href="{{.URL}}">{{.Label}}</a>
+			{{end}}
+		</nav>
+		<h1>{{html .Assignment.Name}} <span class="subtle">by</span> {{html .Assignment.Author}}</h1>
+		<p>Words known by fewer than {{.Threshold}}%% of {{html .Class.Name}}, in order of their first occurrence</p>
+		<div class="table">
+			<table>
+				<thead>
+					<tr>
+						<th>Lemma</th>
+						<th>Translation</th>
+						<th>Count</th>
+						<th>Known by</th>
+					</tr>
+				</thead>
+				<tbody>
+				{{range .Words}}
+					<tr>
+						<td>{{.LemmaRich}}</td>
+						<td>{{.Translation}}</td>
+						<td>{{.Count}}</td>
+						<td>{{.KnownBy}} of {{.Students}}</td>
+					</tr>
+				{{else}}
+					<tr><td colspan="4">No words to display</td></tr>
+				{{end}}
+				</tbody>
+			</table>
+		</div>
+	</body>
+</html>
+`
+
+	return fmt.Sprintf(template, baseStyles, tableStyles, wordListStyles)
+}
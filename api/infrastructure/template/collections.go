@@ -0,0 +1,207 @@
+package template
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type CollectionRow struct {
+	domain.Collection
+	Works []domain.Work
+}
+
+type CollectionListPageData struct {
+	Collections []CollectionRow
+	Works       []domain.Work
+	Selected    map[uuid.UUID]bool
+}
+
+type CollectionPageData struct {
+	Collection domain.Collection
+	Works      []domain.Work
+	Selected   map[uuid.UUID]bool
+}
+
+var collectionStyles = `
+h2 {
+	padding-left: 0.5rem;
+}
+
+form.collection {
+	display: flex;
+	flex-direction: column;
+	gap: 0.5rem;
+	padding-left: 0.5rem;
+}
+
+form.collection fieldset {
+	border: none;
+	display: flex;
+	flex-direction: column;
+	padding: 0;
+}
+
+form.collection button,
+form.collection input[type="text"] {
+	all: revert;
+	align-self: flex-start;
+}
+`
+
+// collectionWorksFieldset lists all works as checkboxes; works in the
+// Selected map are checked.
+var collectionWorksFieldset = `
+			<fieldset>
+				<legend>Works</legend>
+				{{range .Works}}
+					<label>
+						<input type="checkbox" name="work" value="{{.ID}}" {{if index $.Selected .ID}}checked{{end}}>
+						{{html .Title}} <span class="subtle-inline">by {{html .Author.Name}}</span>
+					</label>
+				{{else}}
+					<p>No works to display</p>
+				{{end}}
+			</fieldset>
+`
+
+func GetCollectionListTemplate() string {
+	template := `
+<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="UTF-8" />
+		<title>Collections</title>
+		<link rel="icon" href="https://fav.farm/🗂️" />
+		<style>
+			%s
+			%s
+			%s
+			%s
+		</style>
+	</head>
+	<body>
+		<nav>
+			<a href="http://localhost:4321">👈🏻 Back to works</a>
+		</nav>
+		<h1>Collections</h1>
+		{{if .Collections}}
+			<div class="table">
+				<table>
+					<thead>
+						<tr>
+							<th colspan="5">Name</th>
+							<th>Works</th>
+							<th></th>
+							<th></th>
+						</tr>
+					</thead>
+					<tbody>
+						{{range .Collections}}
+							<tr>
+								<td>{{html .Name}}</td>
+								<td>
+									<a title="{{html .Name}} frequency list" href="http://localhost:4321/frequency-list-collection/{{.ID}}?known=false">📈</a>
+								</td>
+								<td>
+									<a title="{{html .Name}} coverage curve" href="http://localhost:4321/coverage-collection/{{.ID}}">🎯</a>
+								</td>
+								<td>
+									<a title="{{html .Name}} glossary" href="http://localhost:4321/glossary-collection/{{.ID}}?known=false">📖</a>
+								</td>
+								<td>
+									<a title="{{html .Name}} keyness" href="http://localhost:4321/keyness-collection/{{.ID}}">🔑</a>
+								</td>
+								<td>
+									{{range $i, $work := .Works}}{{if $i}}; {{end}}{{html $work.Title}} <span class="subtle-inline">by {{html $work.Author.Name}}</span>{{else}}<span class="subtle-inline">none</span>{{end}}
+								</td>
+								<td>
+									<a title="Edit {{html .Name}}" href="http://localhost:4321/collections/{{.ID}}">✏️</a>
+								</td>
+								<td>
+									<button title="Delete {{html .Name}}" onclick="confirmAndDelete(this)" data-id="{{.ID}}">❌</button>
+								</td>
+							</tr>
+						{{end}}
+					</tbody>
+				</table>
+			</div>
+		{{else}}
+			<p>No collections to display</p>
+		{{end}}
+		<h2>New collection</h2>
+		<form class="collection" method="POST" action="http://localhost:4321/collections">
+			<label>
+				Name
+				<input type="text" name="name" required>
+			</label>
+			%s
+			<button type="submit">Create collection</button>
+		</form>
+	</body>
+	<script>
+		async function confirmAndDelete(button) {
+			const id = button.getAttribute("data-id");
+			const confirmed = confirm("Are you sure you want to delete this collection? Its works will not be deleted.");
+
+			if (!confirmed) return;
+
+			const url = "http://localhost:4321/delete-collection/" + id;
+
+			try {
+				const response = await fetch(url, { method: "POST" });
+
+				if (!response.ok) {
+					button.textContent = "👎🏻";
+					button.title = "Failed to delete collection; click to try again";
+					return;
+				}
+
+				const row = button.closest("tr");
+				if (row) row.remove();
+			} catch (error) {
+				button.textContent = "👎🏻";
+				button.title = "Failed to delete collection; click to try again";
+			}
+		}
+	</script>
+</html>
+`
+
+	return fmt.Sprintf(template, baseStyles, tableStyles, statisticsStyles, collectionStyles, collectionWorksFieldset)
+}
+
+func GetCollectionTemplate() string {
+	template := `
+<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="UTF-8" />
+		<title>{{html .Collection.Name}}</title>
+		<link rel="icon" href="https://fav.farm/🗂️" />
+		<style>
+			%s
+			%s
+			%s
+		</style>
+	</head>
+	<body>
+		<nav>
+			<a href="http://localhost:4321/collections">👈🏻 Back to collections</a>
+		</nav>
+		<h1>{{html .Collection.Name}}</h1>
+		<form class="collection" method="POST" action="http://localhost:4321/collections/{{.Collection.ID}}">
+			<label>
+				Name
+				<input type="text" name="name" value="{{html .Collection.Name}}" required>
+			</label>
+			%s
+			<button type="submit">Save collection</button>
+		</form>
+	</body>
+</html>
+`
+
+	return fmt.Sprintf(template, baseStyles, statisticsStyles, collectionStyles, collectionWorksFieldset)
+}
@@ -0,0 +1,163 @@
+package template
+
+import (
+	"fmt"
+
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type ScopeOption struct {
+	Value string
+	Label string
+}
+
+type ComparisonPageData struct {
+	A          string
+	B          string
+	NameA      string
+	NameB      string
+	Known      string
+	Options    []ScopeOption
+	Comparison *domain.Comparison
+	JSONURL    string
+}
+
+func GetComparisonTemplate() string {
+	scopeSelect := `
+			<label>
+				<span>%[1]s</span>
+				<select name="%[2]s">
+					{{$selected := .%[1]s}}
+					{{range .Options}}
+						<option value="{{.Value}}" {{if eq .Value $selected}}selected{{end}}>{{html .Label}}</option>
+					{{end}}
+				</select>
+			</label>
+	`
+
+	onlyTable := `
+		<h2>Only in {{html $.Name%[1]s}} ({{len .Only%[1]s}})</h2>
+		<div class="table">
+			<table>
+				<thead>
+					<tr>
+						<th>Lemma</th>
+						<th>Translation</th>
+						<th>Count</th>
+						<th>LASLA</th>
+					</tr>
+				</thead>
+				<tbody>
+				{{range .Only%[1]s}}
+					<tr>
+						<td>{{.LemmaRich}}</td>
+						<td>{{.Translation}}</td>
+						<td>{{.Count%[1]s}}</td>
+						<td>{{.FrequencyInLASLA}}</td>
+					</tr>
+				{{else}}
+					<tr><td colspan="4">No words to display</td></tr>
+				{{end}}
+				</tbody>
+			</table>
+		</div>
+	`
+
+	template := `
+<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="UTF-8" />
+		<title>Comparison</title>
+		<link rel="icon" href="https://fav.farm/⚖️" />
+		<style>
+			%s
+			%s
+			%s
+			%s
+		</style>
+	</head>
+	<body>
+		<nav>
+			<a href="http://localhost:4321">👈🏻 Back to works</a>
+			{{if .Comparison}}
+				<a href="{{.JSONURL}}">📄 JSON</a>
+			{{end}}
+		</nav>
+		<h1>
+			{{if .Comparison}}
+				{{html .NameA}} <span class="subtle">vs</span> {{html .NameB}}
+			{{else}}
+				Comparison
+			{{end}}
+		</h1>
+		<form class="filters" method="GET">
+			%s
+			%s
+			<label>
+				<span>Known status</span>
+				<select name="known">
+					<option value="" {{if eq .Known ""}}selected{{end}}>all words</option>
+					<option value="false" {{if eq .Known "false"}}selected{{end}}>unknown words</option>
+					<option value="true" {{if eq .Known "true"}}selected{{end}}>known words</option>
+				</select>
+			</label>
+			<button type="submit">Compare</button>
+		</form>
+		{{with .Comparison}}
+			%s
+			%s
+			<h2>In both ({{len .Both}})</h2>
+			<div class="table">
+				<table>
+					<thead>
+						<tr>
+							<th>Lemma</th>
+							<th>Translation</th>
+							<th>{{html $.NameA}}</th>
+							<th>{{html $.NameB}}</th>
+							<th>LASLA</th>
+						</tr>
+					</thead>
+					<tbody>
+					{{range .Both}}
+						<tr>
+							<td>{{.LemmaRich}}</td>
+							<td>{{.Translation}}</td>
+							<td>{{.CountA}}</td>
+							<td>{{.CountB}}</td>
+							<td>{{.FrequencyInLASLA}}</td>
+						</tr>
+					{{else}}
+						<tr><td colspan="5">No words to display</td></tr>
+					{{end}}
+					</tbody>
+				</table>
+			</div>
+		{{end}}
+	</body>
+</html>
+`
+
+	return fmt.Sprintf(
+		template,
+		baseStyles,
+		tableStyles,
+		wordListStyles,
+		comparisonStyles,
+		fmt.Sprintf(scopeSelect, "A", "a"),
+		fmt.Sprintf(scopeSelect, "B", "b"),
+		fmt.Sprintf(onlyTable, "A"),
+		fmt.Sprintf(onlyTable, "B"),
+	)
+}
+
+var comparisonStyles = `
+h2 {
+	padding-left: 0.5rem;
+}
+
+.table {
+	max-height: 60vh;
+}
+`
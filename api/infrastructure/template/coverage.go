@@ -0,0 +1,185 @@
+package template
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type CoveragePageData struct {
+	Title  string
+	Author string
+	Curve  domain.CoverageCurve
+	Chart  string
+}
+
+var chartStyles = `
+svg {
+	display: block;
+	margin: 1rem 0;
+	max-width: 100%;
+}
+
+svg text {
+	font-family: system-ui;
+	font-size: 0.75rem;
+}
+`
+
+func GetCoverageTemplate() string {
+	template := `
+<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="UTF-8" />
+		<title>
+			Coverage curve for
+			{{if .Title}}
+				{{html .Title}} by
+			{{end}}
+			{{if .Author}}
+				{{html .Author}}
+			{{end}}
+			{{if not .Title}}
+				{{if not .Author}}
+					corpus
+				{{end}}
+			{{end}}
+		</title>
+		<link rel="icon" href="https://fav.farm/🎯" />
+		<style>
+			%s
+			%s
+			%s
+			%s
+		</style>
+	</head>
+	<body>
+		<nav>
+			<a href="http://localhost:4321">👈🏻 Back to works</a>
+		</nav>
+		<h1>
+			Coverage curve <span class="subtle">for</span>
+			{{if .Title}}
+				{{html .Title}} <span class="subtle">by</span>
+			{{end}}
+			{{if .Author}}
+				{{html .Author}}
+			{{end}}
+			{{if not .Title}}
+				{{if not .Author}}
+					corpus
+				{{end}}
+			{{end}}
+		</h1>
+		<p>You currently know {{.Curve.KnownTokenCount}} of {{.Curve.TokenCount}} running words ({{printf "%%.1f" .Curve.Coverage}}%%).</p>
+		<table>
+			<thead>
+				<tr>
+					<th>Target coverage</th>
+					<th>Lemmas to learn</th>
+				</tr>
+			</thead>
+			<tbody>
+			{{range .Curve.Thresholds}}
+				<tr>
+					<td>{{.Target}}%%</td>
+					<td>{{if .Reached}}{{.LemmaCount}}{{else}}Not reachable{{end}}</td>
+				</tr>
+			{{end}}
+			</tbody>
+		</table>
+		{{.Chart}}
+		<div class="table">
+			<table>
+				<thead>
+					<tr>
+						<th>#</th>
+						<th>Lemma</th>
+						<th>Translation</th>
+						<th>Count</th>
+						<th>Coverage</th>
+					</tr>
+				</thead>
+				<tbody>
+				{{range .Curve.StepsToLearn}}
+					<tr>
+						<td>{{.LemmaCount}}</td>
+						<td>{{.Word.LemmaRich}}</td>
+						<td>{{.Word.Translation}}</td>
+						<td>{{.Word.Count}}</td>
+						<td>{{printf "%%.1f" .Coverage}}%%</td>
+					</tr>
+				{{else}}
+					<tr><td colspan="5">No words to learn</td></tr>
+				{{end}}
+				</tbody>
+			</table>
+		</div>
+	</body>
+</html>
+`
+
+	return fmt.Sprintf(template, baseStyles, tableStyles, wordListStyles, chartStyles)
+}
+
+// GetCoverageChart renders the cumulative coverage curve as an SVG line chart,
+// with a dashed line for every threshold.
+func GetCoverageChart(curve domain.CoverageCurve) string {
+	const width, height, padding = 640.0, 320.0, 40.0
+
+	lemmaCount := len(curve.StepsToLearn())
+	if lemmaCount == 0 {
+		lemmaCount = len(curve.Steps)
+	}
+
+	minCoverage := curve.Coverage()
+	for _, threshold := range curve.Thresholds {
+		minCoverage = min(minCoverage, threshold.Target-5)
+	}
+	minCoverage = max(0, math.Floor(minCoverage/10)*10)
+
+	x := func(lemmas int) float64 {
+		return padding + float64(lemmas)/float64(max(lemmaCount, 1))*(width-2*padding)
+	}
+	y := func(coverage float64) float64 {
+		return height - padding - (coverage-minCoverage)/(100-minCoverage)*(height-2*padding)
+	}
+
+	var svg strings.Builder
+
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f">`, width, height, width, height)
+	fmt.Fprintf(&svg, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="black" />`, padding, height-padding, width-padding, height-padding)
+	fmt.Fprintf(&svg, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="black" />`, padding, padding, padding, height-padding)
+	fmt.Fprintf(&svg, `<text x="%.1f" y="%.1f" text-anchor="end">%.0f%%</text>`, padding-4, y(minCoverage)+4, minCoverage)
+	fmt.Fprintf(&svg, `<text x="%.1f" y="%.1f" text-anchor="end">100%%</text>`, padding-4, y(100)+4)
+	fmt.Fprintf(&svg, `<text x="%.1f" y="%.1f">0</text>`, padding, height-padding+16)
+	fmt.Fprintf(&svg, `<text x="%.1f" y="%.1f" text-anchor="end">%d lemmas</text>`, width-padding, height-padding+16, lemmaCount)
+
+	for _, threshold := range curve.Thresholds {
+		fmt.Fprintf(&svg, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="gray" stroke-dasharray="4 4" />`, padding, y(threshold.Target), width-padding, y(threshold.Target))
+
+		label := fmt.Sprintf("%.0f%%: not reachable", threshold.Target)
+		if threshold.Reached {
+			label = fmt.Sprintf("%.0f%%: %d lemmas", threshold.Target, threshold.LemmaCount)
+		}
+
+		fmt.Fprintf(&svg, `<text x="%.1f" y="%.1f" text-anchor="end" fill="gray">%s</text>`, width-padding, y(threshold.Target)-4, label)
+	}
+
+	// Large corpora can have tens of thousands of unknown lemmas, so the curve
+	// is sampled to keep the number of points manageable.
+	stride := max(1, lemmaCount/500)
+
+	points := []string{fmt.Sprintf("%.1f,%.1f", x(0), y(curve.Coverage()))}
+	for i := stride - 1; i < lemmaCount; i += stride {
+		points = append(points, fmt.Sprintf("%.1f,%.1f", x(i+1), y(curve.Steps[i].Coverage)))
+	}
+
+	fmt.Fprintf(&svg, `<polyline fill="none" stroke="steelblue" stroke-width="2" points="%s" />`, strings.Join(points, " "))
+	svg.WriteString(`</svg>`)
+
+	return svg.String()
+}
@@ -0,0 +1,78 @@
+package template
+
+import (
+	"fmt"
+
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type EditionPageData struct {
+	Work         domain.Work
+	Formats      []domain.EditionFormat
+	Units        []domain.EditionUnit
+	WordsPerPage int
+}
+
+func GetEditionTemplate() string {
+	template := `
+<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="UTF-8" />
+		<title>Reader's edition of {{html .Work.Title}}</title>
+		<link rel="icon" href="https://fav.farm/🖨️" />
+		<style>
+			%s
+			%s
+		</style>
+	</head>
+	<body>
+		<nav>
+			<a href="http://localhost:4321">👈🏻 Back to works</a>
+		</nav>
+		<h1>Reader's edition <span class="subtle">of</span> {{html .Work.Title}} <span class="subtle">by</span> {{html .Work.Author.Name}}</h1>
+		<p>The text with a glossary beneath every page, as LaTeX or Typst source to compile offline, or as an EPUB in which every unknown word links to a pop-up gloss.</p>
+		<form class="filters" method="GET">
+			<label>
+				<span>Format</span>
+				<select name="format">
+					{{range .Formats}}
+						<option value="{{.}}">{{if eq . "latex"}}LaTeX{{else if eq . "typst"}}Typst{{else}}EPUB{{end}}</option>
+					{{end}}
+				</select>
+			</label>
+			<label>
+				<span>Pages</span>
+				<select name="unit">
+					{{range .Units}}
+						<option value="{{.}}">{{if eq . "page"}}by number of words{{else}}by section{{end}}</option>
+					{{end}}
+				</select>
+			</label>
+			<label>
+				<span>Words per page</span>
+				<input type="number" name="wordsPerPage" min="1" value="{{.WordsPerPage}}">
+			</label>
+			<label>
+				<span>Section level</span>
+				<input type="number" name="level" min="0" value="0" title="1 for the top level, 0 for the innermost sections">
+			</label>
+			<label>
+				<span>Max. LASLA frequency</span>
+				<input type="number" name="maxFrequency" min="0" title="Words that are more frequent are not glossed">
+			</label>
+			<label>
+				<span>Known words</span>
+				<select name="known">
+					<option value="false">not glossed</option>
+					<option value="">glossed</option>
+				</select>
+			</label>
+			<button type="submit">Download</button>
+		</form>
+	</body>
+</html>
+`
+
+	return fmt.Sprintf(template, baseStyles, wordListStyles)
+}
@@ -0,0 +1,148 @@
+package template
+
+import (
+	"fmt"
+
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type EditWorkPageData struct {
+	Work  domain.Work
+	Forms []domain.WorkForm
+	Tags  string
+}
+
+// workFilterInputs are the filter form fields that select works by their
+// metadata; they are read from the Query of the page data.
+var workFilterInputs = `
+				<label>
+					<span>Period</span>
+					<input type="text" name="period" value="{{.Query.Get "period"}}">
+				</label>
+				<label>
+					<span>Genre</span>
+					<input type="text" name="genre" value="{{.Query.Get "genre"}}">
+				</label>
+				<label>
+					<span>Prose or verse</span>
+					<select name="form">
+						<option value="" {{if eq (.Query.Get "form") ""}}selected{{end}}>both</option>
+						<option value="prose" {{if eq (.Query.Get "form") "prose"}}selected{{end}}>prose</option>
+						<option value="verse" {{if eq (.Query.Get "form") "verse"}}selected{{end}}>verse</option>
+					</select>
+				</label>
+				<label>
+					<span>From year</span>
+					<input type="number" name="fromYear" value="{{.Query.Get "fromYear"}}">
+				</label>
+				<label>
+					<span>To year</span>
+					<input type="number" name="toYear" value="{{.Query.Get "toYear"}}">
+				</label>
+				<label>
+					<span>Tag</span>
+					<input type="text" name="tag" value="{{.Query.Get "tag"}}">
+				</label>
+				<label>
+					<span>Edition</span>
+					<input type="text" name="edition" value="{{.Query.Get "edition"}}">
+				</label>
+				<label>
+					<span>Notes</span>
+					<input type="text" name="notes" value="{{.Query.Get "notes"}}">
+				</label>
+`
+
+var editWorkStyles = `
+form.metadata {
+	display: flex;
+	flex-direction: column;
+	gap: 0.75rem;
+	max-width: 40rem;
+	padding-left: 0.5rem;
+}
+
+form.metadata label span {
+	display: block;
+	font-size: 0.8rem;
+}
+
+form.metadata input,
+form.metadata select,
+form.metadata textarea,
+form.metadata button {
+	all: revert;
+}
+
+form.metadata input[type="text"],
+form.metadata textarea {
+	width: 100%;
+}
+
+form.metadata button {
+	align-self: flex-start;
+}
+`
+
+func GetEditWorkTemplate() string {
+	template := `
+<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="UTF-8" />
+		<title>Edit {{html .Work.Title}} by {{html .Work.Author.Name}}</title>
+		<link rel="icon" href="https://fav.farm/✏️" />
+		<style>
+			%s
+			%s
+			%s
+		</style>
+	</head>
+	<body>
+		<nav>
+			<a href="http://localhost:4321">👈🏻 Back to works</a>
+		</nav>
+		<h1>{{html .Work.Title}} <span class="subtle">by</span> {{html .Work.Author.Name}}</h1>
+		<form class="metadata" method="POST" action="http://localhost:4321/edit/{{.Work.ID}}">
+			<label>
+				<span>Period</span>
+				<input type="text" name="period" value="{{html .Work.Period}}" placeholder="e.g. Republican">
+			</label>
+			<label>
+				<span>Year (negative for BC)</span>
+				<input type="number" name="year" value="{{with .Work.Year}}{{.}}{{end}}">
+			</label>
+			<label>
+				<span>Genre</span>
+				<input type="text" name="genre" value="{{html .Work.Genre}}" placeholder="e.g. historiography">
+			</label>
+			<label>
+				<span>Prose or verse</span>
+				<select name="form">
+					<option value="" {{if eq .Work.Form ""}}selected{{end}}>unknown</option>
+					{{$form := .Work.Form}}
+					{{range .Forms}}
+						<option value="{{.}}" {{if eq . $form}}selected{{end}}>{{.}}</option>
+					{{end}}
+				</select>
+			</label>
+			<label>
+				<span>Edition</span>
+				<input type="text" name="edition" value="{{html .Work.Edition}}">
+			</label>
+			<label>
+				<span>Tags (comma-separated)</span>
+				<input type="text" name="tags" value="{{html .Tags}}">
+			</label>
+			<label>
+				<span>Notes</span>
+				<textarea name="notes" rows="5">{{html .Work.Notes}}</textarea>
+			</label>
+			<button type="submit">Save</button>
+		</form>
+	</body>
+</html>
+`
+
+	return fmt.Sprintf(template, baseStyles, wordListStyles, editWorkStyles)
+}
@@ -0,0 +1,71 @@
+package template
+
+import (
+	"fmt"
+
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type WordHistoryData struct {
+	Word    domain.Word
+	State   domain.LearningState
+	History []domain.StatusChange
+}
+
+func GetWordHistoryTemplate() string {
+	template := `
+<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="UTF-8" />
+		<title>History of {{.Word.LemmaRaw}}</title>
+		<link rel="icon" href="https://fav.farm/🕘" />
+		<style>
+			%s
+			%s
+			%s
+		</style>
+	</head>
+	<body>
+		<nav>
+			<a href="http://localhost:4321">👈🏻 Back to works</a>
+			<a href="http://localhost:4321/actions">↩️ Undo</a>
+		</nav>
+		<h1>{{.Word.LemmaRich}}</h1>
+		<p>{{.Word.Translation}} <span class="subtle-inline">currently {{.State.Status}}</span></p>
+		{{if .History}}
+			<div class="table">
+				<table>
+					<thead>
+						<tr>
+							<th>When</th>
+							<th>Source</th>
+							<th>Change</th>
+							<th>Action</th>
+						</tr>
+					</thead>
+					<tbody>
+						{{range .History}}
+							<tr>
+								<td>{{.Changed.Format "2006-01-02 15:04"}}</td>
+								<td>{{.Source}}</td>
+								<td>{{.PreviousStatus}} → {{.Status}}</td>
+								<td>
+									{{.Description}}
+									{{if .IsUndone}}
+										<span class="subtle-inline">undone {{.Undone.Format "2006-01-02 15:04"}}</span>
+									{{end}}
+								</td>
+							</tr>
+						{{end}}
+					</tbody>
+				</table>
+			</div>
+		{{else}}
+			<p>The learning status of this word has not changed yet</p>
+		{{end}}
+	</body>
+</html>
+`
+	return fmt.Sprintf(template, baseStyles, tableStyles, statisticsStyles)
+}
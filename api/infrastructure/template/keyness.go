@@ -0,0 +1,103 @@
+package template
+
+import (
+	"fmt"
+
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type KeynessPageData struct {
+	Title     string
+	Author    string
+	Reference domain.KeynessReference
+	Known     string
+	Keywords  []domain.Keyword
+	JSONURL   string
+}
+
+func GetKeynessTemplate() string {
+	template := `
+<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="UTF-8" />
+		<title>
+			Keyness for
+			{{if .Title}}
+				{{html .Title}} by
+			{{end}}
+			{{html .Author}}
+		</title>
+		<link rel="icon" href="https://fav.farm/🔑" />
+		<style>
+			%s
+			%s
+			%s
+		</style>
+	</head>
+	<body>
+		<nav>
+			<a href="http://localhost:4321">👈🏻 Back to works</a>
+			<a href="{{.JSONURL}}">📄 JSON</a>
+		</nav>
+		<h1>
+			Keyness <span class="subtle">for</span>
+			{{if .Title}}
+				{{html .Title}} <span class="subtle">by</span>
+			{{end}}
+			{{html .Author}}
+		</h1>
+		<form class="filters" method="GET">
+			<label>
+				<span>Compared with</span>
+				<select name="reference">
+					<option value="corpus" {{if eq .Reference "corpus"}}selected{{end}}>rest of corpus</option>
+					<option value="lasla" {{if eq .Reference "lasla"}}selected{{end}}>LASLA frequencies</option>
+				</select>
+			</label>
+			<label>
+				<span>Known status</span>
+				<select name="known">
+					<option value="" {{if eq .Known ""}}selected{{end}}>all words</option>
+					<option value="false" {{if eq .Known "false"}}selected{{end}}>unknown words</option>
+					<option value="true" {{if eq .Known "true"}}selected{{end}}>known words</option>
+				</select>
+			</label>
+			<button type="submit">Apply</button>
+		</form>
+		<div class="table">
+			<table>
+				<thead>
+					<tr>
+						<th><a href="?reference={{.Reference}}&known={{.Known}}&sort=alphabetical">Lemma</a></th>
+						<th>Translation</th>
+						<th><a title="Occurrences in target" href="?reference={{.Reference}}&known={{.Known}}&sort=target">Target</a></th>
+						<th><a title="Occurrences in reference" href="?reference={{.Reference}}&known={{.Known}}&sort=reference">Reference</a></th>
+						<th><a title="Log-likelihood; negative if underused" href="?reference={{.Reference}}&known={{.Known}}&sort=ll">LL</a></th>
+						<th><a title="Difference in normalised frequency" href="?reference={{.Reference}}&known={{.Known}}&sort=diff">%%DIFF</a></th>
+						<th><a title="Binary logarithm of the ratio of relative frequencies" href="?reference={{.Reference}}&known={{.Known}}&sort=ratio">Log ratio</a></th>
+					</tr>
+				</thead>
+				<tbody>
+				{{range .Keywords}}
+					<tr>
+						<td>{{.LemmaRich}}</td>
+						<td>{{.Translation}}</td>
+						<td>{{.TargetCount}}</td>
+						<td>{{.ReferenceCount}}</td>
+						<td>{{printf "%%.2f" .LogLikelihood}}</td>
+						<td>{{printf "%%.1f" .PercentDiff}}</td>
+						<td>{{printf "%%.2f" .LogRatio}}</td>
+					</tr>
+				{{else}}
+					<tr><td colspan="7">No words to display</td></tr>
+				{{end}}
+				</tbody>
+			</table>
+		</div>
+	</body>
+</html>
+`
+
+	return fmt.Sprintf(template, baseStyles, tableStyles, wordListStyles)
+}
@@ -0,0 +1,180 @@
+package template
+
+import (
+	"fmt"
+
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type KnownWordReportData struct {
+	Report domain.KnownWordReport
+	DryRun bool
+}
+
+var knownWordStyles = `
+textarea {
+	font-family: inherit;
+	height: 12rem;
+	width: 22rem;
+}
+
+.report {
+	margin: 1rem 0;
+	padding-left: 0.5rem;
+}
+
+.report td {
+	padding-right: 1rem;
+	vertical-align: top;
+}
+
+.report button {
+	all: revert;
+	margin: 0 0.25rem 0.25rem 0;
+}
+`
+
+func GetKnownWordImportTemplate() string {
+	template := `
+<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="UTF-8" />
+		<title>Import known words</title>
+		<link rel="icon" href="https://fav.farm/📋" />
+		<style>
+			%s
+			%s
+			%s
+		</style>
+	</head>
+	<body>
+		<nav>
+			<a href="http://localhost:4321">👈🏻 Back to works</a>
+		</nav>
+		<h1>Import known words</h1>
+		<form action="http://localhost:4321/import-known" method="POST" enctype="multipart/form-data">
+			<label>
+				<span>Format</span>
+				<select name="format">
+					<option value="text">Plain text, a lemma per line</option>
+					<option value="csv">CSV or TSV</option>
+					<option value="anki">Anki notes in plain text</option>
+					<option value="dcc">DCC core vocabulary</option>
+				</select>
+			</label>
+
+			<label>
+				<span>File</span>
+				<input type="file" id="file" name="file" accept=".txt,.csv,.tsv">
+			</label>
+
+			<label>
+				<span>Or paste</span>
+				<textarea name="list" placeholder="amo&#10;moneo&#10;rego"></textarea>
+			</label>
+
+			<label>
+				<span>Dry run</span>
+				<input type="checkbox" name="dryRun" value="true" title="Only report what would be marked">
+			</label>
+
+			<button type="submit">Import</button>
+		</form>
+	</body>
+</html>
+`
+	return fmt.Sprintf(template, baseStyles, uploadStyles, knownWordStyles)
+}
+
+func GetKnownWordReportTemplate() string {
+	template := `
+<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="UTF-8" />
+		<title>Import known words</title>
+		<link rel="icon" href="https://fav.farm/📋" />
+		<style>
+			%s
+			%s
+		</style>
+	</head>
+	<body>
+		<nav>
+			<a href="http://localhost:4321">👈🏻 Back to works</a>
+			<a href="http://localhost:4321/import-known">📋 Import another list</a>
+			{{if not .DryRun}}
+				<a href="http://localhost:4321/actions">↩️ Undo</a>
+			{{end}}
+		</nav>
+		<h1>Import known words</h1>
+		{{with .Report}}
+			<p class="report">
+				{{.Entries}} entries, {{len .Matched}} words matched;
+				{{if $.DryRun}}{{.Marked}} would be marked as known{{else}}{{.Marked}} marked as known{{end}}.
+				{{len .Ambiguous}} ambiguous and {{len .Unmatched}} unmatched entries are left to resolve by hand.
+			</p>
+			{{if .Ambiguous}}
+				<h2>Ambiguous</h2>
+				<table class="report">
+					{{range .Ambiguous}}
+						<tr>
+							<td>Line {{.Line}}</td>
+							<td>{{.Lemma}}</td>
+							<td>
+								{{range .Candidates}}
+									{{if .Known}}
+										<button disabled>{{.LemmaRich}} <em>{{.Translation}}</em> ✅</button>
+									{{else}}
+										<button title="Mark as known" onclick="markKnown(this)" data-id="{{.ID}}">{{.LemmaRich}} <em>{{.Translation}}</em></button>
+									{{end}}
+								{{end}}
+							</td>
+						</tr>
+					{{end}}
+				</table>
+			{{end}}
+			{{if .Unmatched}}
+				<h2>Unmatched</h2>
+				<table class="report">
+					{{range .Unmatched}}
+						<tr>
+							<td>Line {{.Line}}</td>
+							<td>{{.Lemma}}</td>
+						</tr>
+					{{end}}
+				</table>
+			{{end}}
+			{{if .Matched}}
+				<h2>Matched</h2>
+				<p class="report">{{range $i, $word := .Matched}}{{if $i}}; {{end}}{{$word.LemmaRich}}{{end}}</p>
+			{{end}}
+		{{end}}
+	</body>
+	<script>
+		async function markKnown(button) {
+			const id = button.getAttribute("data-id");
+
+			try {
+				const response = await fetch("http://localhost:4321/learning-status/" + id, {
+					method: "POST",
+					body: new URLSearchParams({ status: "known" }),
+				});
+
+				if (!response.ok) {
+					button.title = "Failed to mark as known; click to try again";
+					return;
+				}
+
+				button.textContent += " ✅";
+				button.disabled = true;
+			} catch (error) {
+				button.title = "Failed to mark as known; click to try again";
+			}
+		}
+	</script>
+</html>
+`
+	return fmt.Sprintf(template, baseStyles, knownWordStyles)
+}
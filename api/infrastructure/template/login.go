@@ -0,0 +1,56 @@
+package template
+
+import (
+	"fmt"
+)
+
+type LoginPageData struct {
+	Register bool
+	Name     string
+	Error    string
+}
+
+func GetLoginTemplate() string {
+	template := `
+<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="UTF-8" />
+		<title>{{if .Register}}Register{{else}}Log in{{end}}</title>
+		<link rel="icon" href="https://fav.farm/🔐" />
+		<style>
+			%s
+			%s
+		</style>
+	</head>
+	<body>
+		<nav>
+			{{if .Register}}
+				<a href="http://localhost:4321/login">🔐 Log in</a>
+			{{else}}
+				<a href="http://localhost:4321/register">👤 Register</a>
+			{{end}}
+		</nav>
+		<h1>{{if .Register}}Register{{else}}Log in{{end}}</h1>
+		{{if .Error}}
+			<p>{{html .Error}}</p>
+		{{end}}
+		<form action="http://localhost:4321/{{if .Register}}register{{else}}login{{end}}" method="POST">
+			<label>
+				<span>Name</span>
+				<input type="text" name="name" value="{{html .Name}}" autocomplete="username" required>
+			</label>
+
+			<label>
+				<span>Password</span>
+				<input type="password" name="password" autocomplete="{{if .Register}}new-password{{else}}current-password{{end}}" required>
+			</label>
+
+			<button type="submit">{{if .Register}}Register{{else}}Log in{{end}}</button>
+		</form>
+	</body>
+</html>
+`
+
+	return fmt.Sprintf(template, baseStyles, uploadStyles)
+}
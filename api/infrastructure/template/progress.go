@@ -0,0 +1,237 @@
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type ProgressPageData struct {
+	Progress     domain.Progress
+	Per          string
+	Window       int
+	Works        []domain.Work
+	Options      []ScopeOption
+	Scope        string
+	Name         string
+	Target       float64
+	Projection   *domain.Projection
+	KnownChart   string
+	LearnedChart string
+	JSONURL      string
+}
+
+func GetProgressTemplate() string {
+	template := `
+<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="UTF-8" />
+		<title>Progress</title>
+		<link rel="icon" href="https://fav.farm/📊" />
+		<style>
+			%s
+			%s
+			%s
+			%s
+		</style>
+	</head>
+	<body>
+		<nav>
+			<a href="http://localhost:4321">👈🏻 Back to works</a>
+			<a href="{{.JSONURL}}">📄 JSON</a>
+		</nav>
+		<h1>Progress</h1>
+		<form class="filters" method="GET">
+			<label>
+				<span>Text</span>
+				<select name="scope">
+					<option value="" {{if eq .Scope ""}}selected{{end}}>none</option>
+					{{$selected := .Scope}}
+					{{range .Options}}
+						<option value="{{.Value}}" {{if eq .Value $selected}}selected{{end}}>{{html .Label}}</option>
+					{{end}}
+				</select>
+			</label>
+			<label>
+				<span>Target coverage</span>
+				<input type="number" name="target" min="1" max="100" step="any" value="{{.Target}}">
+			</label>
+			<label>
+				<span>Learned per</span>
+				<select name="per">
+					<option value="week" {{if eq .Per "week"}}selected{{end}}>week</option>
+					<option value="day" {{if eq .Per "day"}}selected{{end}}>day</option>
+				</select>
+			</label>
+			<label>
+				<span>Pace over the last</span>
+				<input type="number" name="days" min="1" value="{{.Window}}">
+				<span>days</span>
+			</label>
+			<button type="submit">Apply</button>
+		</form>
+		<p>
+			You currently know {{.Progress.Known}} words and have been learning
+			{{printf "%%.1f" .Progress.Pace}} a day over the last {{.Progress.PaceWindow}} days.
+		</p>
+		{{with .Projection}}
+			<h2>{{html $.Name}}</h2>
+			<p>
+				{{if .Reached}}
+					You know {{printf "%%.1f" .Coverage}}%% of the running words, which is more than the target of {{.Target}}%%.
+				{{else if not .Reachable}}
+					You know {{printf "%%.1f" .Coverage}}%% of the running words; the target of {{.Target}}%% cannot be reached.
+				{{else if .Date.IsZero}}
+					You know {{printf "%%.1f" .Coverage}}%% of the running words and need to learn {{.LemmasToLearn}} more lemmas to reach {{.Target}}%%,
+					but no words have been learned lately.
+				{{else}}
+					You know {{printf "%%.1f" .Coverage}}%% of the running words and need to learn {{.LemmasToLearn}} more lemmas to reach {{.Target}}%%,
+					which at the current pace will take {{.Days}} days, until {{.Date.Format "2006-01-02"}}.
+				{{end}}
+			</p>
+		{{end}}
+		{{if .KnownChart}}
+			<h2>Known words</h2>
+			{{.KnownChart}}
+			<h2>Learned per {{.Per}}</h2>
+			{{.LearnedChart}}
+		{{else}}
+			<p>No changes of learning status have been recorded yet</p>
+		{{end}}
+		<h2>Coverage per work</h2>
+		<div class="table">
+			<table>
+				<thead>
+					<tr>
+						<th>Author</th>
+						<th>Title</th>
+						<th>Coverage</th>
+						<th></th>
+					</tr>
+				</thead>
+				<tbody>
+				{{range .Works}}
+					<tr>
+						<td>{{html .Author.Name}}</td>
+						<td>{{html .Title}}</td>
+						<td>{{printf "%%.1f" .Coverage}}%%</td>
+						<td>
+							<a href="http://localhost:4321/coverage/{{.ID}}" title="Coverage curve">🎯</a>
+							<a href="http://localhost:4321/progress?scope=work:{{.ID}}&target={{$.Target}}" title="Project when the target coverage is reached">📅</a>
+						</td>
+					</tr>
+				{{else}}
+					<tr><td colspan="4">No works to display</td></tr>
+				{{end}}
+				</tbody>
+			</table>
+		</div>
+	</body>
+</html>
+`
+
+	return fmt.Sprintf(template, baseStyles, tableStyles, wordListStyles, chartStyles)
+}
+
+// GetKnownChart renders the number of known words at the end of every day as
+// an SVG line chart.
+func GetKnownChart(points []domain.ProgressPoint) string {
+	const width, height, padding = 640.0, 320.0, 40.0
+
+	if len(points) == 0 {
+		return ""
+	}
+
+	minKnown, maxKnown := points[0].Known, points[0].Known
+	for _, point := range points {
+		minKnown = min(minKnown, point.Known)
+		maxKnown = max(maxKnown, point.Known)
+	}
+	maxKnown = max(maxKnown, minKnown+1)
+
+	x := func(i int) float64 {
+		return padding + float64(i)/float64(max(len(points)-1, 1))*(width-2*padding)
+	}
+	y := func(known int) float64 {
+		return height - padding - float64(known-minKnown)/float64(maxKnown-minKnown)*(height-2*padding)
+	}
+
+	var svg strings.Builder
+
+	writeChartAxes(&svg, width, height, padding, points)
+	fmt.Fprintf(&svg, `<text x="%.1f" y="%.1f" text-anchor="end">%d</text>`, padding-4, y(minKnown)+4, minKnown)
+	fmt.Fprintf(&svg, `<text x="%.1f" y="%.1f" text-anchor="end">%d</text>`, padding-4, y(maxKnown)+4, maxKnown)
+
+	coordinates := make([]string, len(points))
+	for i, point := range points {
+		coordinates[i] = fmt.Sprintf("%.1f,%.1f", x(i), y(point.Known))
+	}
+
+	fmt.Fprintf(&svg, `<polyline fill="none" stroke="steelblue" stroke-width="2" points="%s" />`, strings.Join(coordinates, " "))
+	svg.WriteString(`</svg>`)
+
+	return svg.String()
+}
+
+// GetLearnedChart renders the words learned in every day or week as an SVG bar
+// chart, with the words forgotten below the axis.
+func GetLearnedChart(points []domain.ProgressPoint) string {
+	const width, height, padding = 640.0, 320.0, 40.0
+
+	if len(points) == 0 {
+		return ""
+	}
+
+	maxLearned, maxForgotten := 1, 0
+	for _, point := range points {
+		maxLearned = max(maxLearned, point.Learned)
+		maxForgotten = max(maxForgotten, point.Forgotten)
+	}
+
+	scale := (height - 2*padding) / float64(maxLearned+maxForgotten)
+	baseline := padding + float64(maxLearned)*scale
+	slot := (width - 2*padding) / float64(len(points))
+	barWidth := max(1, slot*0.8)
+
+	var svg strings.Builder
+
+	writeChartAxes(&svg, width, height, padding, points)
+	fmt.Fprintf(&svg, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="gray" />`, padding, baseline, width-padding, baseline)
+	fmt.Fprintf(&svg, `<text x="%.1f" y="%.1f" text-anchor="end">%d</text>`, padding-4, padding+4, maxLearned)
+	fmt.Fprintf(&svg, `<text x="%.1f" y="%.1f" text-anchor="end">0</text>`, padding-4, baseline+4)
+
+	if maxForgotten > 0 {
+		fmt.Fprintf(&svg, `<text x="%.1f" y="%.1f" text-anchor="end">-%d</text>`, padding-4, height-padding+4, maxForgotten)
+	}
+
+	for i, point := range points {
+		left := padding + float64(i)*slot + (slot-barWidth)/2
+		label := point.Start.Format("2006-01-02")
+
+		if point.Learned > 0 {
+			fmt.Fprintf(&svg, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="steelblue"><title>%s: %d learned</title></rect>`,
+				left, baseline-float64(point.Learned)*scale, barWidth, float64(point.Learned)*scale, label, point.Learned)
+		}
+
+		if point.Forgotten > 0 {
+			fmt.Fprintf(&svg, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="indianred"><title>%s: %d forgotten</title></rect>`,
+				left, baseline, barWidth, float64(point.Forgotten)*scale, label, point.Forgotten)
+		}
+	}
+
+	svg.WriteString(`</svg>`)
+
+	return svg.String()
+}
+
+// writeChartAxes opens the SVG element and draws the axes of a chart over
+// time, labelled with the first and last dates.
+func writeChartAxes(svg *strings.Builder, width, height, padding float64, points []domain.ProgressPoint) {
+	fmt.Fprintf(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f">`, width, height, width, height)
+	fmt.Fprintf(svg, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="black" />`, padding, height-padding, width-padding, height-padding)
+	fmt.Fprintf(svg, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="black" />`, padding, padding, padding, height-padding)
+	fmt.Fprintf(svg, `<text x="%.1f" y="%.1f">%s</text>`, padding, height-padding+16, points[0].Start.Format("2006-01-02"))
+	fmt.Fprintf(svg, `<text x="%.1f" y="%.1f" text-anchor="end">%s</text>`, width-padding, height-padding+16, points[len(points)-1].Start.Format("2006-01-02"))
+}
@@ -0,0 +1,198 @@
+package template
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type ReaderPageData struct {
+	Work        domain.Work
+	Page        *domain.ReaderPage
+	PageCount   int
+	Words       map[uuid.UUID]domain.WordInWork
+	Units       []domain.ReaderUnit
+	Query       url.Values
+	PreviousURL string
+	NextURL     string
+}
+
+var readerStyles = `
+.text {
+	font-size: 1.2rem;
+	line-height: 2;
+	max-width: 45rem;
+	padding: 0 0.5rem;
+}
+
+.text h2 {
+	font-size: 1.2rem;
+}
+
+.sentence {
+	margin-right: 0.75rem;
+}
+
+.word {
+	cursor: pointer;
+	position: relative;
+}
+
+.word.unknown {
+	background-color: #fff1a8;
+}
+
+.word .gloss {
+	background-color: white;
+	border: 1px solid #ddd;
+	border-radius: 8px;
+	box-shadow: 0 2px 8px rgba(0, 0, 0, 0.15);
+	display: none;
+	font-size: 0.9rem;
+	left: 0;
+	line-height: 1.4;
+	padding: 0.5rem 0.75rem;
+	position: absolute;
+	top: 1.8rem;
+	width: 18rem;
+	z-index: 1;
+}
+
+.word:hover .gloss,
+.word:focus .gloss,
+.word:focus-within .gloss {
+	display: block;
+}
+
+.word .gloss .subtle-inline {
+	font-style: italic;
+	opacity: 0.6;
+}
+
+.word .gloss button {
+	all: revert;
+	margin-top: 0.25rem;
+}
+`
+
+func GetReaderTemplate() string {
+	template := `
+<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="UTF-8" />
+		<title>{{html .Work.Title}}</title>
+		<link rel="icon" href="https://fav.farm/👓" />
+		<style>
+			%s
+			%s
+			%s
+		</style>
+	</head>
+	<body>
+		<nav>
+			<a href="http://localhost:4321">👈🏻 Back to works</a>
+			<a href="http://localhost:4321/glossary/{{.Work.ID}}?known=false">📖 Glossary</a>
+		</nav>
+		<h1>{{html .Work.Title}} <span class="subtle">by</span> {{html .Work.Author.Name}}</h1>
+		<form class="filters" method="GET">
+			<label>
+				<span>Pages</span>
+				<select name="unit">
+					{{range .Units}}
+						<option value="{{.}}" {{if eq ($.Query.Get "unit") (print .)}}selected{{end}}>by {{.}}</option>
+					{{end}}
+				</select>
+			</label>
+			<label>
+				<span>Sentences per page</span>
+				<input type="number" name="sentences" min="1" value="{{.Query.Get "sentences"}}" placeholder="10">
+			</label>
+			<label>
+				<span>Section level</span>
+				<input type="number" name="level" min="0" value="{{.Query.Get "level"}}" placeholder="0" title="1 for the top level, 0 for the innermost sections">
+			</label>
+			<label>
+				<span>Page</span>
+				<input type="number" name="page" min="1" max="{{.PageCount}}" value="{{with .Page}}{{.Number}}{{end}}">
+			</label>
+			<button type="submit">Go</button>
+		</form>
+		{{with .Page}}
+			<div class="text">
+				{{if .Heading}}<h2>{{html .Heading}}</h2>{{end}}
+				<p>
+					{{range .Sentences}}
+						<span class="sentence">
+							{{range .}}
+								{{$word := index $.Words .WordID}}
+								<span class="word{{if not $word.Known}} unknown{{end}}" data-word-id="{{.WordID}}" tabindex="0">{{.OriginalForm}}<span class="gloss">
+									<strong>{{$word.LemmaRich}}</strong> {{$word.Translation}}<br>
+									<span class="subtle-inline">{{.MorphoSyntacticalAnalysis}}</span><br>
+									<button onclick="toggleKnown(this)" data-id="{{.WordID}}" data-known="{{$word.Known}}">{{if $word.Known}}❓ Mark as unknown{{else}}✅ Mark as known{{end}}</button>
+								</span></span>
+							{{end}}
+						</span>
+					{{end}}
+				</p>
+			</div>
+		{{else}}
+			<p>No text to display</p>
+		{{end}}
+		<div class="pagination">
+			{{if .PreviousURL}}
+				<a href="{{.PreviousURL}}">👈🏻 Previous</a>
+			{{end}}
+			<span>Page {{with .Page}}{{.Number}}{{end}} of {{.PageCount}}</span>
+			{{if .NextURL}}
+				<a href="{{.NextURL}}">Next 👉🏻</a>
+			{{end}}
+		</div>
+	</body>
+	<script>
+		{{with .Page}}
+			// The position is saved when the page is hidden, such as by following
+			// the pagination links, rather than whenever a page is loaded.
+			function saveReadingPosition() {
+				navigator.sendBeacon("/reading-position/{{$.Work.ID}}", new URLSearchParams({ position: "{{.FirstWordIndex}}" }));
+			}
+
+			window.addEventListener("pagehide", saveReadingPosition);
+			document.addEventListener("visibilitychange", () => {
+				if (document.visibilityState === "hidden") {
+					saveReadingPosition();
+				}
+			});
+		{{end}}
+
+		async function toggleKnown(button) {
+			const id = button.getAttribute("data-id");
+			const newKnown = button.getAttribute("data-known") !== "true";
+
+			try {
+				const response = await fetch("/toggle-known-status/" + id, { method: "POST" });
+
+				if (!response.ok) {
+					button.textContent = "👎🏻 Failed; click to try again";
+					return;
+				}
+
+				document.querySelectorAll('.word[data-word-id="' + id + '"]').forEach(word => {
+					word.classList.toggle("unknown", !newKnown);
+
+					const wordButton = word.querySelector("button");
+					wordButton.setAttribute("data-known", newKnown.toString());
+					wordButton.textContent = newKnown ? "❓ Mark as unknown" : "✅ Mark as known";
+				});
+			} catch (error) {
+				button.textContent = "👎🏻 Failed; click to try again";
+			}
+		}
+	</script>
+</html>
+`
+
+	return fmt.Sprintf(template, baseStyles, wordListStyles, readerStyles)
+}
@@ -0,0 +1,127 @@
+package template
+
+import (
+	"fmt"
+
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type RecommendationsPageData struct {
+	MaxNewLemmas    int
+	Recommendations []domain.Recommendation
+	ReadingPath     []domain.ReadingPathStep
+}
+
+var recommendationStyles = `
+h2 {
+	padding-left: 0.5rem;
+}
+
+form {
+	display: flex;
+	gap: 1rem;
+	padding-left: 0.5rem;
+}
+
+form button {
+	all: revert;
+}
+
+details summary {
+	cursor: pointer;
+}
+`
+
+func GetRecommendationsTemplate() string {
+	template := `
+<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="UTF-8" />
+		<title>What to read next</title>
+		<link rel="icon" href="https://fav.farm/🧭" />
+		<style>
+			%s
+			%s
+			%s
+		</style>
+	</head>
+	<body>
+		<nav>
+			<a href="http://localhost:4321">👈🏻 Back to works</a>
+		</nav>
+		<h1>What to read next</h1>
+		<h2>Unread works by new vocabulary</h2>
+		<table>
+			<thead>
+				<tr>
+					<th>Author</th>
+					<th>Title</th>
+					<th>New lemmas</th>
+					<th>Coverage</th>
+				</tr>
+			</thead>
+			<tbody>
+			{{range .Recommendations}}
+				<tr>
+					<td>{{html .Work.Author.Name}}</td>
+					<td>{{html .Work.Title}}</td>
+					<td>
+						<a title="{{html .Work.Title}} frequency list" href="http://localhost:4321/frequency-list/{{.Work.ID}}?known=false">{{.NewLemmaCount}}</a>
+					</td>
+					<td>{{printf "%%.1f" .Coverage}}%%</td>
+				</tr>
+			{{else}}
+				<tr><td colspan="4">No unread works to display</td></tr>
+			{{end}}
+			</tbody>
+		</table>
+		<h2>Reading path</h2>
+		<form method="GET">
+			<label>
+				<span>Maximum number of new lemmas per work</span>
+				<input type="number" name="maxNew" min="0" value="{{.MaxNewLemmas}}">
+			</label>
+			<button type="submit">Update</button>
+		</form>
+		<table>
+			<thead>
+				<tr>
+					<th>Author</th>
+					<th>Title</th>
+					<th>New lemmas</th>
+					<th>Coverage</th>
+					<th>Learnt in total</th>
+				</tr>
+			</thead>
+			<tbody>
+			{{range .ReadingPath}}
+				<tr>
+					<td>{{html .Work.Author.Name}}</td>
+					<td>{{html .Work.Title}}</td>
+					<td>
+						{{if .NewLemmas}}
+							<details>
+								<summary>{{.NewLemmaCount}}</summary>
+								{{range .NewLemmas}}
+									{{.LemmaRich}} <span title="Occurrences in corpus">({{.Count}})</span><br/>
+								{{end}}
+							</details>
+						{{else}}
+							0
+						{{end}}
+					</td>
+					<td>{{printf "%%.1f" .Coverage}}%%</td>
+					<td>{{.LearntLemmaCount}}</td>
+				</tr>
+			{{else}}
+				<tr><td colspan="5">No unread works to display</td></tr>
+			{{end}}
+			</tbody>
+		</table>
+	</body>
+</html>
+`
+
+	return fmt.Sprintf(template, baseStyles, tableStyles, recommendationStyles)
+}
@@ -0,0 +1,166 @@
+package template
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type GradeOption struct {
+	Grade    domain.Grade
+	Label    string
+	Interval string
+}
+
+type ReviewPageData struct {
+	Card     *domain.Flashcard
+	DueCount int
+	Grades   []GradeOption
+	Front    domain.FlashcardFront
+	Fronts   []domain.FlashcardFront
+	Orders   []domain.QueueOrder
+	Works    []domain.Work
+	Query    url.Values
+}
+
+var reviewStyles = `
+.card {
+	border: 1px solid #ddd;
+	border-radius: 8px;
+	margin: 0 0.5rem 1rem;
+	max-width: 40rem;
+	padding: 1rem 1.5rem;
+}
+
+.card .lemma {
+	font-size: 1.6rem;
+}
+
+.card mark {
+	background-color: #fff1a8;
+	font-weight: bold;
+}
+
+.card details {
+	margin-top: 1rem;
+}
+
+.card summary {
+	cursor: pointer;
+}
+
+.grades {
+	display: flex;
+	gap: 0.5rem;
+}
+
+.grades button {
+	all: revert;
+}
+`
+
+func GetReviewTemplate() string {
+	template := `
+<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="UTF-8" />
+		<title>Review</title>
+		<link rel="icon" href="https://fav.farm/🃏" />
+		<style>
+			%s
+			%s
+			%s
+			%s
+		</style>
+	</head>
+	<body>
+		<nav>
+			<a href="http://localhost:4321">👈🏻 Back to works</a>
+		</nav>
+		<h1>Review <span class="subtle-inline">{{.DueCount}} due</span></h1>
+		{{if .Query.Get "added"}}
+			<p>Added {{.Query.Get "added"}} words to the queue.</p>
+		{{end}}
+		<form class="filters" method="GET">
+			<label>
+				<span>Work</span>
+				<select name="work">
+					<option value="">all works</option>
+					{{range .Works}}
+						<option value="{{.ID}}" {{if eq ($.Query.Get "work") (print .ID)}}selected{{end}}>{{html .Title}} ({{html .Author.Name}})</option>
+					{{end}}
+				</select>
+			</label>
+			<label>
+				<span>Front</span>
+				<select name="front">
+					{{range .Fronts}}
+						<option value="{{.}}" {{if eq . $.Front}}selected{{end}}>{{.}}</option>
+					{{end}}
+				</select>
+			</label>
+			<button type="submit">Apply</button>
+		</form>
+		{{with .Card}}
+			<div class="card">
+				{{if eq $.Front "context"}}
+					<p>
+						{{range .Sentence}}
+							{{if eq .WordIndex $.Card.Example.WordIndex}}<mark>{{.OriginalForm}}</mark>{{else}}{{.OriginalForm}}{{end}}
+						{{end}}
+					</p>
+				{{else}}
+					<p class="lemma">{{.LemmaRaw}}</p>
+				{{end}}
+				<details>
+					<summary>Show answer</summary>
+					<p class="lemma">{{.LemmaRich}}</p>
+					<p>{{.Translation}}</p>
+					<p>
+						<strong>{{.Example.OriginalForm}}</strong>: {{.Example.MorphoSyntacticalAnalysis}}
+						<span class="subtle-inline">in {{html .Title}} by {{html .Author}}</span>
+					</p>
+					<form class="grades" method="POST" action="http://localhost:4321/review/{{.ID}}">
+						<input type="hidden" name="work" value="{{$.Query.Get "work"}}">
+						<input type="hidden" name="front" value="{{$.Front}}">
+						{{range $.Grades}}
+							<button type="submit" name="grade" value="{{printf "%%d" .Grade}}">{{.Label}} <span class="subtle-inline">{{.Interval}}</span></button>
+						{{end}}
+					</form>
+				</details>
+			</div>
+		{{else}}
+			<p>No cards due. 🎉</p>
+		{{end}}
+		<h2>Prepare a text</h2>
+		<form class="filters" method="POST" action="http://localhost:4321/review-seed">
+			<label>
+				<span>Work</span>
+				<select name="work" required>
+					{{range .Works}}
+						<option value="{{.ID}}" {{if eq ($.Query.Get "work") (print .ID)}}selected{{end}}>{{html .Title}} ({{html .Author.Name}})</option>
+					{{end}}
+				</select>
+			</label>
+			<label>
+				<span>Order</span>
+				<select name="order">
+					{{range .Orders}}
+						<option value="{{.}}">{{if eq . "first"}}first occurrence{{else}}{{.}}{{end}}</option>
+					{{end}}
+				</select>
+			</label>
+			<label>
+				<span>New words</span>
+				<input type="number" name="count" min="1" value="20">
+			</label>
+			<button type="submit">Add unknown words to queue</button>
+		</form>
+	</body>
+</html>
+`
+
+	return fmt.Sprintf(template, baseStyles, wordListStyles, statisticsStyles, reviewStyles)
+}
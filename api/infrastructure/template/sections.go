@@ -0,0 +1,113 @@
+package template
+
+import (
+	"fmt"
+
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type SectionRow struct {
+	domain.Section
+	Richness domain.LexicalRichness
+}
+
+type SectionsPageData struct {
+	Work     domain.Work
+	Sections []SectionRow
+}
+
+func GetSectionsTemplate() string {
+	template := `
+<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="UTF-8" />
+		<title>{{html .Work.Title}} – Sections</title>
+		<link rel="icon" href="https://fav.farm/📑" />
+		<style>
+			%s
+			%s
+			%s
+			%s
+		</style>
+	</head>
+	<body>
+		<nav>
+			<a href="http://localhost:4321">👈🏻 Back to works</a>
+			<a href="http://localhost:4321/api/sections/{{.Work.ID}}">📄 JSON</a>
+		</nav>
+		<h1>{{html .Work.Title}} <span class="subtle-inline">by {{html .Work.Author.Name}}</span></h1>
+		<table>
+			<thead>
+				<tr>
+					<th colspan="5">Section</th>
+					<th title="Range of word indices">Words</th>
+					<th title="Number of running words">Tokens</th>
+					<th title="Number of distinct lemmas">Types</th>
+					<th title="Type/token ratio">TTR</th>
+					<th title="Lemmas occurring once">Hapax</th>
+					<th title="Measure of textual lexical diversity">MTLD</th>
+					<th title="Mean LASLA frequency of the lemmas used; lower means rarer">LASLA</th>
+				</tr>
+			</thead>
+			<tbody>
+			{{range .Sections}}
+				<tr>
+					<td class="section depth-{{.Depth}}">
+						{{html .Label}} <span class="subtle-inline">{{.Kind}} {{html .Citation}}</span>
+					</td>
+					<td>
+						<a title="{{html .Citation}} frequency list" href="http://localhost:4321/frequency-list-section/{{.ID}}?known=false">📈</a>
+					</td>
+					<td>
+						<a title="{{html .Citation}} coverage curve" href="http://localhost:4321/coverage-section/{{.ID}}">🎯</a>
+					</td>
+					<td>
+						<a title="{{html .Citation}} glossary" href="http://localhost:4321/glossary-section/{{.ID}}?known=false">📖</a>
+					</td>
+					<td>
+						<a title="{{html .Citation}} keyness" href="http://localhost:4321/keyness-section/{{.ID}}">🔑</a>
+					</td>
+					<td>{{.FirstWordIndex}}–{{.LastWordIndex}}</td>
+					<td>{{.Richness.TokenCount}}</td>
+					<td>{{.Richness.TypeCount}}</td>
+					<td>{{printf "%%.3f" .Richness.TypeTokenRatio}}</td>
+					<td>{{.Richness.HapaxLegomena}}</td>
+					<td>{{printf "%%.1f" .Richness.MTLD}}</td>
+					<td>{{printf "%%.0f" .Richness.MeanFrequencyInLASLA}}</td>
+				</tr>
+			{{else}}
+				<tr><td colspan="12">This work has no sections; add headings such as "# Book 1" to a plain text, or upload a TEI document</td></tr>
+			{{end}}
+			</tbody>
+		</table>
+	</body>
+</html>
+`
+
+	return fmt.Sprintf(template, baseStyles, tableStyles, statisticsStyles, sectionStyles)
+}
+
+// sectionStyles indents sections by their depth in the tree.
+var sectionStyles = `
+td.section {
+	white-space: nowrap;
+}
+
+td.depth-1 {
+	padding-left: 2rem;
+}
+
+td.depth-2 {
+	padding-left: 3.5rem;
+}
+
+td.depth-3 {
+	padding-left: 5rem;
+}
+
+td.depth-4,
+td.depth-5 {
+	padding-left: 6.5rem;
+}
+`
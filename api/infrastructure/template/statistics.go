@@ -0,0 +1,158 @@
+package template
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type StatisticsRow struct {
+	ID       uuid.UUID
+	Author   string
+	Title    string
+	Richness domain.LexicalRichness
+}
+
+type StatisticsPageData struct {
+	Works       []StatisticsRow
+	Authors     []StatisticsRow
+	Collections []StatisticsRow
+	Corpus      StatisticsRow
+	Query       url.Values
+}
+
+func GetStatisticsTemplate() string {
+	header := `
+				<tr>
+					<th>%s</th>
+					<th title="Number of running words">Tokens</th>
+					<th title="Number of distinct lemmas">Types</th>
+					<th title="Type/token ratio">TTR</th>
+					<th title="Lemmas occurring once">Hapax</th>
+					<th title="Lemmas occurring twice">Dis</th>
+					<th title="Measure of textual lexical diversity">MTLD</th>
+					<th title="Yule's characteristic K; higher means more repetitive">Yule's K</th>
+					<th title="Mean LASLA frequency of the lemmas used; lower means rarer">LASLA</th>
+				</tr>
+	`
+
+	cells := `
+					<td>{{.Richness.TokenCount}}</td>
+					<td>{{.Richness.TypeCount}}</td>
+					<td>{{printf "%.3f" .Richness.TypeTokenRatio}}</td>
+					<td>{{.Richness.HapaxLegomena}}</td>
+					<td>{{.Richness.DisLegomena}}</td>
+					<td>{{printf "%.1f" .Richness.MTLD}}</td>
+					<td>{{printf "%.1f" .Richness.YulesK}}</td>
+					<td>{{printf "%.0f" .Richness.MeanFrequencyInLASLA}}</td>
+	`
+
+	template := `
+<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="UTF-8" />
+		<title>Lexical richness</title>
+		<link rel="icon" href="https://fav.farm/🧮" />
+		<style>
+			%s
+			%s
+			%s
+			%s
+		</style>
+	</head>
+	<body>
+		<nav>
+			<a href="http://localhost:4321">👈🏻 Back to works</a>
+			<a href="http://localhost:4321/api/statistics?{{.Query.Encode}}">📄 JSON</a>
+		</nav>
+		<h1>Lexical richness</h1>
+		<form class="filters" method="GET">
+			%s
+			<button type="submit">Apply</button>
+		</form>
+		<h2>Works</h2>
+		<table>
+			<thead>
+				%s
+			</thead>
+			<tbody>
+			{{range .Works}}
+				<tr>
+					<td>{{html .Title}} <span class="subtle-inline">by {{html .Author}}</span></td>
+					%s
+				</tr>
+			{{else}}
+				<tr><td colspan="9">No works to display</td></tr>
+			{{end}}
+			</tbody>
+		</table>
+		<h2>Authors</h2>
+		<table>
+			<thead>
+				%s
+			</thead>
+			<tbody>
+			{{range .Authors}}
+				<tr>
+					<td>{{html .Author}}</td>
+					%s
+				</tr>
+			{{end}}
+			{{with .Corpus}}
+				<tr>
+					<td><strong>Corpus</strong></td>
+					%s
+				</tr>
+			{{end}}
+			</tbody>
+		</table>
+		{{if .Collections}}
+			<h2>Collections</h2>
+			<table>
+				<thead>
+					%s
+				</thead>
+				<tbody>
+				{{range .Collections}}
+					<tr>
+						<td>{{html .Title}}</td>
+						%s
+					</tr>
+				{{end}}
+				</tbody>
+			</table>
+		{{end}}
+	</body>
+</html>
+`
+
+	return fmt.Sprintf(
+		template,
+		baseStyles,
+		tableStyles,
+		wordListStyles,
+		statisticsStyles,
+		workFilterInputs,
+		fmt.Sprintf(header, "Work"),
+		cells,
+		fmt.Sprintf(header, "Author"),
+		cells,
+		cells,
+		fmt.Sprintf(header, "Collection"),
+		cells,
+	)
+}
+
+var statisticsStyles = `
+h2 {
+	padding-left: 0.5rem;
+}
+
+.subtle-inline {
+	font-style: italic;
+	opacity: 0.6;
+}
+`
@@ -2,14 +2,32 @@ package template
 
 import (
 	"fmt"
+	"net/url"
 
 	"github.com/nienkeboomsma/vocabularium/domain"
 )
 
 type WordListPageData struct {
-	Title  string
-	Author string
-	Words  *[]domain.WordInWork
+	Title         string
+	Author        string
+	Words         *[]domain.WordInWork
+	Glossary      *domain.GlossaryOptions
+	Dispersion    bool
+	WorkFilter    bool
+	Query         url.Values
+	PartsOfSpeech []domain.PartOfSpeech
+	Total         int
+	Page          int
+	PageCount     int
+	PreviousURL   string
+	NextURL       string
+	Exports       []ExportLink
+	MarkKnownURL  string
+}
+
+type ExportLink struct {
+	Label string
+	URL   string
 }
 
 var wordListStyles = `
@@ -20,6 +38,35 @@ var wordListStyles = `
 	padding: 0 0.2rem 0 0.25rem;
 	opacity: 0.4;
 }
+
+.filters {
+	align-items: end;
+	display: flex;
+	flex-wrap: wrap;
+	gap: 1rem;
+	margin-bottom: 1rem;
+	padding-left: 0.5rem;
+}
+
+.filters label span {
+	display: block;
+	font-size: 0.8rem;
+}
+
+.filters input[type="number"] {
+	width: 6rem;
+}
+
+.filters button {
+	all: revert;
+}
+
+.pagination {
+	display: flex;
+	gap: 1rem;
+	margin-top: 1rem;
+	padding-left: 0.5rem;
+}
 `
 
 func GetWordListTemplate(listType, emoji string) string {
@@ -29,10 +76,10 @@ func GetWordListTemplate(listType, emoji string) string {
 	<head>
 		<title>
 			{{if .Title}}
-    			{{.Title}} by
+    			{{html .Title}} by
        		{{end}}
          	{{if .Author}}
-         		{{.Author}}
+         		{{html .Author}}
     		{{end}}
       		{{if not .Title}}
 				{{if not .Author}}
@@ -51,14 +98,17 @@ func GetWordListTemplate(listType, emoji string) string {
 		<nav>
 			<a href="http://localhost:4321">👈🏻 Back to works</a>
 			<a id="toggle-link"></a>
+			{{range .Exports}}
+				<a href="{{.URL}}">{{.Label}}</a>
+			{{end}}
 		</nav>
 		<h1>
 			%s <span class="subtle">for</span>
 				{{if .Title}}
-    				{{.Title}} <span class="subtle">by</span>
+    				{{html .Title}} <span class="subtle">by</span>
     			{{end}}
        			{{if .Author}}
-       				{{.Author}}
+       				{{html .Author}}
            		{{end}}
 				{{if not .Title}}
 					{{if not .Author}}
@@ -66,6 +116,107 @@ func GetWordListTemplate(listType, emoji string) string {
 					{{end}}
 				{{end}}
            </h1>
+		<form class="filters" method="GET">
+			<label>
+				<span>Search</span>
+				<input type="search" name="q" value="{{.Query.Get "q"}}">
+			</label>
+			{{if not .Glossary}}
+				<label>
+					<span>Sort by</span>
+					<select name="sort">
+						<option value="count" {{if eq (.Query.Get "sort") "count"}}selected{{end}}>count</option>
+						<option value="alphabetical" {{if eq (.Query.Get "sort") "alphabetical"}}selected{{end}}>lemma</option>
+						<option value="lasla" {{if eq (.Query.Get "sort") "lasla"}}selected{{end}}>LASLA frequency</option>
+						{{if not .Dispersion}}
+							<option value="first" {{if eq (.Query.Get "sort") "first"}}selected{{end}}>first occurrence</option>
+						{{end}}
+						{{if .Dispersion}}
+							<option value="range" {{if eq (.Query.Get "sort") "range"}}selected{{end}}>number of works</option>
+							<option value="dp" {{if eq (.Query.Get "sort") "dp"}}selected{{end}}>DP</option>
+							<option value="d" {{if eq (.Query.Get "sort") "d"}}selected{{end}}>Juilland's D</option>
+							<option value="adjusted" {{if eq (.Query.Get "sort") "adjusted"}}selected{{end}}>adjusted frequency</option>
+						{{end}}
+					</select>
+				</label>
+				<label>
+					<span>Order</span>
+					<select name="order">
+						<option value="" {{if eq (.Query.Get "order") ""}}selected{{end}}>default</option>
+						<option value="asc" {{if eq (.Query.Get "order") "asc"}}selected{{end}}>ascending</option>
+						<option value="desc" {{if eq (.Query.Get "order") "desc"}}selected{{end}}>descending</option>
+					</select>
+				</label>
+			{{end}}
+			<label>
+				<span>Min. count</span>
+				<input type="number" name="minCount" min="0" value="{{.Query.Get "minCount"}}">
+			</label>
+			<label>
+				<span>Max. count</span>
+				<input type="number" name="maxCount" min="0" value="{{.Query.Get "maxCount"}}">
+			</label>
+			<label>
+				<span>Min. LASLA frequency</span>
+				<input type="number" name="minFrequency" min="0" value="{{.Query.Get "minFrequency"}}">
+			</label>
+			<label>
+				<span>LASLA frequency below</span>
+				<input type="number" name="maxFrequency" min="0" value="{{.Query.Get "maxFrequency"}}">
+			</label>
+			<label>
+				<span>Part of speech</span>
+				<select name="pos">
+					<option value="">all</option>
+					{{$pos := .Query.Get "pos"}}
+					{{range .PartsOfSpeech}}
+						<option value="{{.Tag}}" {{if eq $pos .Tag}}selected{{end}}>{{.Name}}</option>
+					{{end}}
+				</select>
+			</label>
+			<label>
+				<span>Known status</span>
+				<select name="known">
+					<option value="" {{if eq (.Query.Get "known") ""}}selected{{end}}>all words</option>
+					<option value="false" {{if eq (.Query.Get "known") "false"}}selected{{end}}>unknown words</option>
+					<option value="true" {{if eq (.Query.Get "known") "true"}}selected{{end}}>known words</option>
+				</select>
+			</label>
+			{{with .Glossary}}
+				<label>
+					<span>Range in</span>
+					<select name="unit">
+						<option value="word" {{if eq .Unit "word"}}selected{{end}}>words</option>
+						<option value="sentence" {{if eq .Unit "sentence"}}selected{{end}}>sentences</option>
+					</select>
+				</label>
+				<label>
+					<span>From</span>
+					<input type="number" name="from" min="0" value="{{with .From}}{{.}}{{end}}">
+				</label>
+				<label>
+					<span>To</span>
+					<input type="number" name="to" min="0" value="{{with .To}}{{.}}{{end}}">
+				</label>
+				<label>
+					<input type="checkbox" name="first" value="true" {{if .FirstOccurrenceOnly}}checked{{end}}>
+					First occurrence only
+				</label>
+			{{end}}
+			{{if .WorkFilter}}
+				%s
+			{{end}}
+			<button type="submit">Apply</button>
+		</form>
+		{{if .MarkKnownURL}}
+			<form class="filters" method="POST" action="{{.MarkKnownURL}}" onsubmit="return confirm('Mark ' + (this.top.value || 'all {{.Total}}') + ' words in this list as known?')">
+				<label>
+					<span>Top</span>
+					<input type="number" name="top" min="1" placeholder="all">
+				</label>
+				<button type="submit" title="Mark all words that match the filters, or the top ones in the current order, as known">✅ Mark as known</button>
+			</form>
+		{{end}}
 		<div class="table">
 			<table>
 				<thead>
@@ -73,15 +224,30 @@ func GetWordListTemplate(listType, emoji string) string {
 						<th>Lemma</th>
 						<th>Translation</th>
 						<th>Count</th>
+						<th>LASLA</th>
+						{{if .Dispersion}}
+							<th title="Number of works the lemma occurs in">Works</th>
+							<th title="Gries' deviation of proportions; 0 means evenly spread">DP</th>
+							<th title="Juilland's D; 1 means evenly spread">D</th>
+							<th title="Count multiplied by Juilland's D">Adjusted</th>
+						{{end}}
 						<th></th>
 					</tr>
 				</thead>
 				<tbody>
+				{{$dispersion := .Dispersion}}
 				{{range .Words}}
 					<tr>
 						<td>{{.LemmaRich}}</td>
 						<td>{{.Translation}}</td>
 						<td>{{.Count}}</td>
+						<td>{{.FrequencyInLASLA}}</td>
+						{{if $dispersion}}
+							<td>{{.Dispersion.Range}}</td>
+							<td>{{printf "%%.2f" .Dispersion.DP}}</td>
+							<td>{{printf "%%.2f" .Dispersion.JuillandD}}</td>
+							<td>{{printf "%%.1f" .Dispersion.AdjustedFrequency}}</td>
+						{{end}}
 						<td>
 						    {{if .Known}}
 								<button title="Mark word as unknown" onclick="toggleKnown(this)" data-id="{{.ID}}" data-known="true">
@@ -91,33 +257,46 @@ func GetWordListTemplate(listType, emoji string) string {
 								<button title="Mark word as known" onclick="toggleKnown(this)" data-id="{{.ID}}" data-known="false">
 									✅
 								</button>
+								<button title="Ignore word, e.g. a proper name" onclick="ignoreWord(this)" data-ignore-id="{{.ID}}">
+									🙈
+								</button>
 							{{end}}
+							<a href="http://localhost:4321/history/{{.ID}}" title="History of the learning status">🕘</a>
 						</td>
 					</tr>
 				{{else}}
-					<tr><td colspan="4">No words to display</td></tr>
+					<tr><td colspan="9">No words to display</td></tr>
 				{{end}}
 				</tbody>
 			</table>
 		</div>
+		<div class="pagination">
+			{{if .PreviousURL}}
+				<a href="{{.PreviousURL}}">👈🏻 Previous</a>
+			{{end}}
+			<span>Page {{.Page}} of {{.PageCount}} ({{.Total}} words)</span>
+			{{if .NextURL}}
+				<a href="{{.NextURL}}">Next 👉🏻</a>
+			{{end}}
+		</div>
 	</body>
 	<script>
 		const currentURL = new URL(window.location.href);
-		const currentPath = currentURL.pathname;
+		const knownFilter = currentURL.searchParams.get("known");
 
-		let text, newPath;
+		let text;
 
-		if (currentPath.includes("true")) {
-			newPath = currentPath.replace("true", "false");
+		const newURL = new URL(window.location.href);
+		newURL.searchParams.delete("page");
+
+		if (knownFilter === "false") {
+			newURL.searchParams.delete("known");
 			text = "🔄 Show known words";
-		} else if (currentPath.includes("false")) {
-			newPath = currentPath.replace("false", "true");
+		} else {
+			newURL.searchParams.set("known", "false");
 			text = "🔄 Hide known words";
 		}
 
-		const newURL = new URL(window.location.href);
-		newURL.pathname = newPath;
-
 		const toggleLink = document.getElementById("toggle-link");
 		toggleLink.href = newURL.href;
 		toggleLink.textContent = text;
@@ -137,7 +316,7 @@ func GetWordListTemplate(listType, emoji string) string {
 					return;
 				}
 
-				if (newKnown && currentPath.includes("true")) {
+				if (knownFilter !== null && knownFilter !== newKnown.toString()) {
 					const matchingButtons = document.querySelectorAll('button[data-id="' + id + '"]');
 					matchingButtons.forEach(btn => {
 						const row = btn.closest("tr");
@@ -158,8 +337,43 @@ func GetWordListTemplate(listType, emoji string) string {
 				button.title = "Failed to mark words as " + (newKnown ? "known" : "unknown") + "; click to try again";
 			}
 		}
+
+		async function ignoreWord(button) {
+			const id = button.getAttribute("data-ignore-id");
+			const url = "http://localhost:4321/learning-status/" + id;
+
+			try {
+				const response = await fetch(url, {
+					method: "POST",
+					body: new URLSearchParams({ status: "ignored" }),
+				});
+
+				if (!response.ok) {
+					button.textContent = "👎🏻";
+					button.title = "Failed to ignore word; click to try again";
+					return;
+				}
+
+				if (knownFilter === "false") {
+					const row = button.closest("tr");
+					if (row) row.remove();
+					return;
+				}
+
+				const knownButton = document.querySelector('button[data-id="' + id + '"]');
+				if (knownButton) {
+					knownButton.textContent = "❌";
+					knownButton.setAttribute("data-known", "true");
+					knownButton.setAttribute("title", "Mark word as unknown");
+				}
+				button.remove();
+			} catch (error) {
+				button.textContent = "👎🏻";
+				button.title = "Failed to ignore word; click to try again";
+			}
+		}
 	</script>
 </html>
 `
-	return fmt.Sprintf(template, emoji, baseStyles, tableStyles, wordListStyles, listType)
+	return fmt.Sprintf(template, emoji, baseStyles, tableStyles, wordListStyles, listType, workFilterInputs)
 }
@@ -61,36 +61,82 @@ func GetWorkListTemplate() string {
 	<body>
 		<nav>
 			<a href="http://localhost:4321/upload">📥 Upload work</a>
+			<a href="http://localhost:4321/collections">🗂️ Collections</a>
+			<a href="http://localhost:4321/classes">🏫 Classes</a>
+			<a href="http://localhost:4321/compare">⚖️ Compare</a>
+			<a href="http://localhost:4321/statistics">🧮 Lexical richness</a>
+			<a href="http://localhost:4321/recommendations">🧭 What to read next</a>
+			<a href="http://localhost:4321/review">🃏 Review</a>
+			<a href="http://localhost:4321/progress">📊 Progress</a>
+			<a href="http://localhost:4321/import-known">📋 Import known words</a>
+			<a href="http://localhost:4321/actions">↩️ Undo</a>
+			<a href="http://localhost:4321/?format=csv">💾 Export as CSV</a>
+			<form action="http://localhost:4321/logout" method="POST">
+				<button type="submit">🚪 Log out</button>
+			</form>
 		</nav>
-		<h1>Works <a class="inline-link" title="Corpus frequency list" href="http://localhost:4321/frequency-list-corpus/true">📈</a></h1>
+		<h1>Works <a class="inline-link" title="Corpus frequency list" href="http://localhost:4321/frequency-list-corpus?known=false">📈</a> <a class="inline-link" title="Corpus coverage curve" href="http://localhost:4321/coverage-corpus">🎯</a></h1>
 		{{if .}}
 			<div class="table">
 				<table>
 					<thead>
 						<tr>
-							<th colspan="2">Author</th>
+							<th colspan="4"><a title="Sort by author" href="http://localhost:4321/?sort=author">Author</a></th>
+							<th></th>
+							<th colspan="8"><a title="Sort by title" href="http://localhost:4321/?sort=title">Title</a></th>
+							<th><a title="Sort by text coverage" href="http://localhost:4321/?sort=coverage&order=desc">Coverage</a></th>
 							<th></th>
-							<th colspan="3">Title</th>
 							<th></th>
 						</tr>
 					</thead>
 					<tbody>
 						{{range .}}
 							<tr>
-								<td>{{.Author.Name}}</td>
+								<td>{{html .Author.Name}}</td>
+								<td>
+									<a title="{{html .Author.Name}} frequency list" href="http://localhost:4321/frequency-list-author/{{.Author.ID}}?known=false">📈</a>
+								</td>
+								<td>
+									<a title="{{html .Author.Name}} coverage curve" href="http://localhost:4321/coverage-author/{{.Author.ID}}">🎯</a>
+								</td>
 								<td>
-									<a title="{{.Author.Name}} frequency list" href="http://localhost:4321/frequency-list-author/{{.Author.ID}}/true">📈</a>
+									<a title="{{html .Author.Name}} keyness" href="http://localhost:4321/keyness-author/{{.Author.ID}}">🔑</a>
 								</td>
 								<td></td>
-								<td style="">{{.Title}}</td>
+								<td style="">{{html .Title}}</td>
+								<td>
+									<a title="Read {{html .Title}}" href="http://localhost:4321/read/{{.ID}}">👓</a>
+								</td>
+								<td>
+									<a title="{{html .Title}} frequency list" href="http://localhost:4321/frequency-list/{{.ID}}?known=false">📈</a>
+								</td>
+								<td>
+									<a title="{{html .Title}} glossary" href="http://localhost:4321/glossary/{{.ID}}?known=false">📖</a>
+								</td>
+								<td>
+									<a title="{{html .Title}} keyness" href="http://localhost:4321/keyness/{{.ID}}">🔑</a>
+								</td>
+								<td>
+									<a title="{{html .Title}} sections" href="http://localhost:4321/sections/{{.ID}}">📑</a>
+								</td>
 								<td>
-									<a title="{{.Title}} frequency list" href="http://localhost:4321/frequency-list/{{.ID}}/true">📈</a>
+									<a title="Reader's edition of {{html .Title}}" href="http://localhost:4321/edition/{{.ID}}">🖨️</a>
 								</td>
 								<td>
-									<a title="{{.Title}} glossary" href="http://localhost:4321/glossary/{{.ID}}/true">📖</a>
+									<a title="Edit details of {{html .Title}}" href="http://localhost:4321/edit/{{.ID}}">✏️</a>
 								</td>
 								<td>
-									<button title="Delete {{.Title}} by {{.Author}}" onclick="confirmAndDelete(this)" data-id="{{.ID}}">❌</button>
+									<a title="{{.KnownTokenCount}} of {{.TokenCount}} words known; show coverage curve" href="http://localhost:4321/coverage/{{.ID}}">{{printf "%%.1f" .Coverage}}%%</a>
+								</td>
+								<td>
+									{{if .Read}}
+										<button title="Mark {{html .Title}} as unread" onclick="toggleRead(this)" data-id="{{.ID}}" data-read="true">📗</button>
+									{{else}}
+										<button title="Mark {{html .Title}} as read" onclick="toggleRead(this)" data-id="{{.ID}}" data-read="false">📕</button>
+									{{end}}
+								</td>
+								<td>
+									<button title="Delete {{html .Title}} by {{html .Author}}" onclick="confirmAndDelete(this)" data-id="{{.ID}}">❌</button>
 								</td>
 							</tr>
 						{{end}}
@@ -102,6 +148,29 @@ func GetWorkListTemplate() string {
 		{{end}}
 	</body>
 	<script>
+		async function toggleRead(button) {
+			const id = button.getAttribute("data-id");
+			const newRead = button.getAttribute("data-read") !== "true";
+			const url = "http://localhost:4321/toggle-read-status/" + id;
+
+			try {
+				const response = await fetch(url, { method: "POST" });
+
+				if (!response.ok) {
+					button.textContent = "👎🏻";
+					button.title = "Failed to mark work as " + (newRead ? "read" : "unread") + "; click to try again";
+					return;
+				}
+
+				button.textContent = newRead ? "📗" : "📕";
+				button.setAttribute("data-read", newRead.toString());
+				button.title = "Mark work as " + (newRead ? "unread" : "read");
+			} catch (error) {
+				button.textContent = "👎🏻";
+				button.title = "Failed to mark work as " + (newRead ? "read" : "unread") + "; click to try again";
+			}
+		}
+
 		async function confirmAndDelete(button) {
 			const id = button.getAttribute("data-id");
 			const confirmed = confirm("Are you sure you want to delete this work?");
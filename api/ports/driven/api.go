@@ -3,13 +3,78 @@ package driven
 import "net/http"
 
 type API interface {
+	AssignToClass() http.HandlerFunc
+	Authenticate(next http.Handler) http.Handler
+	DeleteClass() http.HandlerFunc
+	DeleteClassAssignment() http.HandlerFunc
+	DeleteCollection() http.HandlerFunc
 	DeleteWork() http.HandlerFunc
+	EditWork() http.HandlerFunc
+	GetActions() http.HandlerFunc
+	GetClass() http.HandlerFunc
+	GetClassAsJSON() http.HandlerFunc
+	GetClassGlossary() http.HandlerFunc
+	GetClasses() http.HandlerFunc
+	GetCollection() http.HandlerFunc
+	GetCollections() http.HandlerFunc
+	GetComparison() http.HandlerFunc
+	GetComparisonAsJSON() http.HandlerFunc
+	GetCoverageCurve() http.HandlerFunc
+	GetCoverageCurveByAuthor() http.HandlerFunc
+	GetCoverageCurveByCollection() http.HandlerFunc
+	GetCoverageCurveBySection() http.HandlerFunc
+	GetCoverageCurveByWork() http.HandlerFunc
+	GetEdition() http.HandlerFunc
 	GetFrequencyList() http.HandlerFunc
 	GetFrequencyListByWork() http.HandlerFunc
 	GetFrequencyListByAuthor() http.HandlerFunc
+	GetFrequencyListByCollection() http.HandlerFunc
+	GetFrequencyListBySection() http.HandlerFunc
+	GetGlossaryByCollection() http.HandlerFunc
+	GetGlossaryBySection() http.HandlerFunc
 	GetGlossaryByWork() http.HandlerFunc
+	GetKeynessByAuthor() http.HandlerFunc
+	GetKeynessByAuthorAsJSON() http.HandlerFunc
+	GetKeynessByCollection() http.HandlerFunc
+	GetKeynessByCollectionAsJSON() http.HandlerFunc
+	GetKeynessBySection() http.HandlerFunc
+	GetKeynessBySectionAsJSON() http.HandlerFunc
+	GetKeynessByWork() http.HandlerFunc
+	GetKeynessByWorkAsJSON() http.HandlerFunc
+	GetLearningStateAsJSON() http.HandlerFunc
+	GetLogin() http.HandlerFunc
+	GetProgress() http.HandlerFunc
+	GetProgressAsJSON() http.HandlerFunc
+	GetReader() http.HandlerFunc
+	GetRecommendations() http.HandlerFunc
+	GetRegistration() http.HandlerFunc
+	GetReview() http.HandlerFunc
+	GetSections() http.HandlerFunc
+	GetSectionsAsJSON() http.HandlerFunc
+	GetStatistics() http.HandlerFunc
+	GetStatisticsAsJSON() http.HandlerFunc
+	GetWordHistory() http.HandlerFunc
 	GetWorks() http.HandlerFunc
+	GradeFlashcard() http.HandlerFunc
+	ImportKnownWords() http.HandlerFunc
+	JoinClass() http.HandlerFunc
+	LeaveClass() http.HandlerFunc
 	Lemmatise() http.HandlerFunc
+	LogIn() http.HandlerFunc
+	LogOut() http.HandlerFunc
+	MarkKnown() http.HandlerFunc
+	Register() http.HandlerFunc
+	RemoveClassStudent() http.HandlerFunc
+	SaveClass() http.HandlerFunc
+	SaveCollection() http.HandlerFunc
+	SaveReadingPosition() http.HandlerFunc
+	SeedReviewQueue() http.HandlerFunc
+	SetLearningStatus() http.HandlerFunc
 	ToggleKnownStatus() http.HandlerFunc
+	ToggleReadStatus() http.HandlerFunc
+	UndoAction() http.HandlerFunc
+	UndoLatestActions() http.HandlerFunc
+	UpdateWorkMetadata() http.HandlerFunc
 	Upload() http.HandlerFunc
+	UploadKnownWords() http.HandlerFunc
 }
@@ -0,0 +1 @@
+ALTER TABLE work DROP COLUMN IF EXISTS read;
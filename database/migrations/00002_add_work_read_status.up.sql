@@ -0,0 +1 @@
+ALTER TABLE work ADD COLUMN IF NOT EXISTS read BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS collection_work;
+DROP TABLE IF EXISTS collection;
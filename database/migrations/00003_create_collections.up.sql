@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS collection (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS collection_name_key ON collection (name) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS collection_work (
+    collection_id UUID NOT NULL REFERENCES collection(id),
+    work_id UUID NOT NULL REFERENCES work(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (collection_id, work_id)
+);
@@ -0,0 +1,8 @@
+ALTER TABLE work
+    DROP COLUMN IF EXISTS period,
+    DROP COLUMN IF EXISTS year,
+    DROP COLUMN IF EXISTS genre,
+    DROP COLUMN IF EXISTS form,
+    DROP COLUMN IF EXISTS edition,
+    DROP COLUMN IF EXISTS notes,
+    DROP COLUMN IF EXISTS tags;
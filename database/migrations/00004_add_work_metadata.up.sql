@@ -0,0 +1,8 @@
+ALTER TABLE work
+    ADD COLUMN IF NOT EXISTS period TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS year INT,
+    ADD COLUMN IF NOT EXISTS genre TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS form TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS edition TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS notes TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
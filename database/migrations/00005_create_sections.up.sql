@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS section (
+    id UUID PRIMARY KEY,
+    work_id UUID NOT NULL REFERENCES work(id),
+    parent_id UUID REFERENCES section(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    depth INT NOT NULL,
+    kind TEXT NOT NULL,
+    label TEXT NOT NULL,
+    citation TEXT NOT NULL,
+    first_word_index INT NOT NULL,
+    last_word_index INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (work_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS section_work_id_idx ON section (work_id, first_word_index, last_word_index);
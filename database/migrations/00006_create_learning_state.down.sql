@@ -0,0 +1,12 @@
+ALTER TABLE word ADD COLUMN IF NOT EXISTS known BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE word
+SET known = true
+WHERE id IN (
+    SELECT word_id
+    FROM learning_state
+    WHERE status IN ('known', 'ignored')
+);
+
+DROP TABLE IF EXISTS review;
+DROP TABLE IF EXISTS learning_state;
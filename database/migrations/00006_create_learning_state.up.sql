@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS learning_state (
+    word_id UUID PRIMARY KEY REFERENCES word(id),
+    status TEXT NOT NULL DEFAULT 'new' CHECK (status IN ('new', 'learning', 'review', 'known', 'ignored')),
+    due_at TIMESTAMPTZ,
+    stability DOUBLE PRECISION NOT NULL DEFAULT 0,
+    difficulty DOUBLE PRECISION NOT NULL DEFAULT 2.5,
+    repetitions INT NOT NULL DEFAULT 0,
+    lapses INT NOT NULL DEFAULT 0,
+    last_reviewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS learning_state_due_at_idx ON learning_state (due_at) WHERE status IN ('learning', 'review');
+
+CREATE TABLE IF NOT EXISTS review (
+    id UUID PRIMARY KEY,
+    word_id UUID NOT NULL REFERENCES word(id),
+    grade INT NOT NULL CHECK (grade BETWEEN 1 AND 4),
+    previous_status TEXT NOT NULL,
+    status TEXT NOT NULL,
+    stability DOUBLE PRECISION NOT NULL,
+    difficulty DOUBLE PRECISION NOT NULL,
+    due_at TIMESTAMPTZ,
+    reviewed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS review_word_id_idx ON review (word_id, reviewed_at);
+
+INSERT INTO learning_state (word_id, status)
+SELECT id, 'known'
+FROM word
+WHERE known
+ON CONFLICT (word_id) DO NOTHING;
+
+ALTER TABLE word DROP COLUMN IF EXISTS known;
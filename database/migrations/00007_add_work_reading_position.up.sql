@@ -0,0 +1 @@
+ALTER TABLE work ADD COLUMN IF NOT EXISTS reading_position INT NOT NULL DEFAULT 0;
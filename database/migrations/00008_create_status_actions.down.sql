@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS status_change;
+DROP TABLE IF EXISTS status_action;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS status_action (
+    id UUID PRIMARY KEY,
+    description TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('new', 'learning', 'review', 'known', 'ignored')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    undone_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS status_action_created_at_idx ON status_action (created_at);
+
+CREATE TABLE IF NOT EXISTS status_change (
+    action_id UUID NOT NULL REFERENCES status_action(id) ON DELETE CASCADE,
+    word_id UUID NOT NULL REFERENCES word(id),
+    previous_status TEXT NOT NULL,
+    previous_due_at TIMESTAMPTZ,
+    previous_stability DOUBLE PRECISION NOT NULL,
+    previous_difficulty DOUBLE PRECISION NOT NULL,
+    previous_repetitions INT NOT NULL,
+    previous_lapses INT NOT NULL,
+    previous_last_reviewed_at TIMESTAMPTZ,
+    PRIMARY KEY (action_id, word_id)
+);
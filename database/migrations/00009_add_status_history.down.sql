@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS status_change_word_id_idx;
+ALTER TABLE status_change DROP COLUMN IF EXISTS status;
+ALTER TABLE status_action DROP COLUMN IF EXISTS source;
@@ -0,0 +1,18 @@
+ALTER TABLE status_action ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'bulk' CHECK (source IN ('manual', 'bulk', 'import', 'review'));
+
+UPDATE status_action
+SET source = 'import'
+WHERE description LIKE 'Imported %';
+
+ALTER TABLE status_action ALTER COLUMN source DROP DEFAULT;
+
+ALTER TABLE status_change ADD COLUMN IF NOT EXISTS status TEXT;
+
+UPDATE status_change c
+SET status = a.status
+FROM status_action a
+WHERE a.id = c.action_id;
+
+ALTER TABLE status_change ALTER COLUMN status SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS status_change_word_id_idx ON status_change (word_id);
@@ -0,0 +1,30 @@
+-- Only the learning state of the oldest account is kept.
+DELETE FROM status_action WHERE user_id <> (SELECT id FROM app_user ORDER BY created_at ASC LIMIT 1);
+DROP INDEX IF EXISTS status_action_created_at_idx;
+ALTER TABLE status_action DROP COLUMN IF EXISTS user_id;
+CREATE INDEX IF NOT EXISTS status_action_created_at_idx ON status_action (created_at);
+
+DELETE FROM review WHERE user_id <> (SELECT id FROM app_user ORDER BY created_at ASC LIMIT 1);
+DROP INDEX IF EXISTS review_word_id_idx;
+ALTER TABLE review DROP COLUMN IF EXISTS user_id;
+CREATE INDEX IF NOT EXISTS review_word_id_idx ON review (word_id, reviewed_at);
+
+DELETE FROM learning_state WHERE user_id <> (SELECT id FROM app_user ORDER BY created_at ASC LIMIT 1);
+DROP INDEX IF EXISTS learning_state_due_at_idx;
+ALTER TABLE learning_state DROP CONSTRAINT IF EXISTS learning_state_pkey;
+ALTER TABLE learning_state DROP COLUMN IF EXISTS user_id;
+ALTER TABLE learning_state ADD PRIMARY KEY (word_id);
+CREATE INDEX IF NOT EXISTS learning_state_due_at_idx ON learning_state (due_at) WHERE status IN ('learning', 'review');
+
+ALTER TABLE work ADD COLUMN IF NOT EXISTS read BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE work ADD COLUMN IF NOT EXISTS reading_position INT NOT NULL DEFAULT 0;
+
+UPDATE work w
+SET read = uw.read, reading_position = uw.reading_position
+FROM user_work uw
+WHERE uw.work_id = w.id
+AND uw.user_id = (SELECT id FROM app_user ORDER BY created_at ASC LIMIT 1);
+
+DROP TABLE IF EXISTS user_work;
+DROP TABLE IF EXISTS user_session;
+DROP TABLE IF EXISTS app_user;
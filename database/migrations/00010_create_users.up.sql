@@ -0,0 +1,60 @@
+CREATE TABLE IF NOT EXISTS app_user (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    password_hash TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS user_session (
+    token_hash TEXT PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES app_user(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS user_session_user_id_idx ON user_session (user_id);
+
+-- Everything learnt so far belongs to an account without a password, which
+-- the first user to register takes over.
+INSERT INTO app_user (id, name, password_hash)
+VALUES (gen_random_uuid(), 'owner', '');
+
+CREATE TABLE IF NOT EXISTS user_work (
+    user_id UUID NOT NULL REFERENCES app_user(id) ON DELETE CASCADE,
+    work_id UUID NOT NULL REFERENCES work(id),
+    read BOOLEAN NOT NULL DEFAULT false,
+    reading_position INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, work_id)
+);
+
+INSERT INTO user_work (user_id, work_id, read, reading_position)
+SELECT u.id, w.id, w.read, w.reading_position
+FROM work w
+CROSS JOIN app_user u
+WHERE w.read OR w.reading_position > 0;
+
+ALTER TABLE work DROP COLUMN IF EXISTS read;
+ALTER TABLE work DROP COLUMN IF EXISTS reading_position;
+
+ALTER TABLE learning_state ADD COLUMN IF NOT EXISTS user_id UUID REFERENCES app_user(id) ON DELETE CASCADE;
+UPDATE learning_state SET user_id = (SELECT id FROM app_user);
+ALTER TABLE learning_state ALTER COLUMN user_id SET NOT NULL;
+ALTER TABLE learning_state DROP CONSTRAINT IF EXISTS learning_state_pkey;
+ALTER TABLE learning_state ADD PRIMARY KEY (user_id, word_id);
+
+DROP INDEX IF EXISTS learning_state_due_at_idx;
+CREATE INDEX IF NOT EXISTS learning_state_due_at_idx ON learning_state (user_id, due_at) WHERE status IN ('learning', 'review');
+
+ALTER TABLE review ADD COLUMN IF NOT EXISTS user_id UUID REFERENCES app_user(id) ON DELETE CASCADE;
+UPDATE review SET user_id = (SELECT id FROM app_user);
+ALTER TABLE review ALTER COLUMN user_id SET NOT NULL;
+
+DROP INDEX IF EXISTS review_word_id_idx;
+CREATE INDEX IF NOT EXISTS review_word_id_idx ON review (user_id, word_id, reviewed_at);
+
+ALTER TABLE status_action ADD COLUMN IF NOT EXISTS user_id UUID REFERENCES app_user(id) ON DELETE CASCADE;
+UPDATE status_action SET user_id = (SELECT id FROM app_user);
+ALTER TABLE status_action ALTER COLUMN user_id SET NOT NULL;
+
+DROP INDEX IF EXISTS status_action_created_at_idx;
+CREATE INDEX IF NOT EXISTS status_action_created_at_idx ON status_action (user_id, created_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS class_assignment;
+DROP TABLE IF EXISTS class_student;
+DROP TABLE IF EXISTS class;
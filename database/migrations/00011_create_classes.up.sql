@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS class (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    instructor_id UUID NOT NULL REFERENCES app_user(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS class_instructor_id_idx ON class (instructor_id) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS class_student (
+    class_id UUID NOT NULL REFERENCES class(id),
+    user_id UUID NOT NULL REFERENCES app_user(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (class_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS class_student_user_id_idx ON class_student (user_id);
+
+-- A passage is a section of the work; the assignment goes when the sections
+-- of the work are replaced.
+CREATE TABLE IF NOT EXISTS class_assignment (
+    id UUID PRIMARY KEY,
+    class_id UUID NOT NULL REFERENCES class(id),
+    work_id UUID NOT NULL REFERENCES work(id),
+    section_id UUID REFERENCES section(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS class_assignment_class_id_idx ON class_assignment (class_id, created_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS review_action_id_idx;
+ALTER TABLE review DROP COLUMN IF EXISTS action_id;
@@ -0,0 +1,14 @@
+ALTER TABLE review ADD COLUMN IF NOT EXISTS action_id UUID REFERENCES status_action(id) ON DELETE CASCADE;
+
+-- A review and its action were saved with the same time.
+UPDATE review r
+SET action_id = a.id
+FROM status_action a
+JOIN status_change c
+ON c.action_id = a.id
+WHERE a.source = 'review'
+AND a.user_id = r.user_id
+AND a.created_at = r.reviewed_at
+AND c.word_id = r.word_id;
+
+CREATE INDEX IF NOT EXISTS review_action_id_idx ON review (action_id);
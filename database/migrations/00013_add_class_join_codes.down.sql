@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS class_join_code_idx;
+ALTER TABLE class DROP COLUMN IF EXISTS join_code;
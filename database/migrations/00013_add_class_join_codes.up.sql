@@ -0,0 +1,11 @@
+-- Students join a class with its code rather than being added by the
+-- instructor, so that nobody is enrolled without their consent.
+ALTER TABLE class ADD COLUMN IF NOT EXISTS join_code TEXT;
+
+UPDATE class
+SET join_code = upper(substr(md5(random()::text || id::text), 1, 8))
+WHERE join_code IS NULL;
+
+ALTER TABLE class ALTER COLUMN join_code SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS class_join_code_idx ON class (join_code);
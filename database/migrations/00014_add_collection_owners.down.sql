@@ -0,0 +1,11 @@
+DELETE FROM collection_work
+WHERE collection_id IN (
+    SELECT id FROM collection
+    WHERE user_id <> (SELECT id FROM app_user ORDER BY created_at ASC LIMIT 1)
+);
+DELETE FROM collection WHERE user_id <> (SELECT id FROM app_user ORDER BY created_at ASC LIMIT 1);
+
+DROP INDEX IF EXISTS collection_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS collection_name_key ON collection (name) WHERE deleted_at IS NULL;
+
+ALTER TABLE collection DROP COLUMN IF EXISTS user_id;
@@ -0,0 +1,8 @@
+-- Collections belong to the user who made them; existing ones go to the
+-- account that owns everything learnt before users were introduced.
+ALTER TABLE collection ADD COLUMN IF NOT EXISTS user_id UUID REFERENCES app_user(id) ON DELETE CASCADE;
+UPDATE collection SET user_id = (SELECT id FROM app_user ORDER BY created_at ASC LIMIT 1) WHERE user_id IS NULL;
+ALTER TABLE collection ALTER COLUMN user_id SET NOT NULL;
+
+DROP INDEX IF EXISTS collection_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS collection_name_key ON collection (user_id, name) WHERE deleted_at IS NULL;
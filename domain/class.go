@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultGlossaryThreshold is the percentage of a class below which a word
+// counts as unknown to the class, and goes into its pre-reading glossary.
+const DefaultGlossaryThreshold = 50.0
+
+// Class is a group of students whose instructor assigns them works or
+// passages to read. Only the instructor sees what the students know. Students
+// join a class themselves with its join code, which only the instructor sees.
+type Class struct {
+	ID           uuid.UUID
+	Name         string
+	InstructorID uuid.UUID
+	JoinCode     string
+	Students     []User
+	Assignments  []Assignment
+	Created      time.Time
+	Modified     time.Time
+	Deleted      time.Time
+}
+
+// NewJoinCode returns a random code of eight letters and digits for students
+// to join a class with.
+func NewJoinCode() (string, error) {
+	b := make([]byte, 5)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate join code: %w", err)
+	}
+
+	return base32.StdEncoding.EncodeToString(b), nil
+}
+
+// IsTaughtBy reports whether the user is the instructor of the class.
+func (c Class) IsTaughtBy(userID uuid.UUID) bool {
+	return c.InstructorID == userID
+}
+
+// Assignment is a work, or a passage of one, that a class is to read. A
+// passage is a section of the work; SectionID is the nil UUID if the whole
+// work is assigned.
+type Assignment struct {
+	ID        uuid.UUID
+	ClassID   uuid.UUID
+	WorkID    uuid.UUID
+	SectionID uuid.UUID
+	Title     string
+	Author    string
+	Citation  string
+	Created   time.Time
+}
+
+// Scope returns the part of the corpus the assignment covers.
+func (a Assignment) Scope() Scope {
+	if a.SectionID != uuid.Nil {
+		return Scope{Kind: ScopeKindSection, ID: a.SectionID}
+	}
+
+	return Scope{Kind: ScopeKindWork, ID: a.WorkID}
+}
+
+// Name returns the title of the work, followed by the citation of the passage
+// if only a passage is assigned, e.g. "Carmina 64".
+func (a Assignment) Name() string {
+	if a.Citation == "" {
+		return a.Title
+	}
+
+	return a.Title + " " + a.Citation
+}
+
+// StudentCoverage is the number of running words in an assignment, and the
+// number of those whose lemma the student knows.
+type StudentCoverage struct {
+	AssignmentID    uuid.UUID
+	StudentID       uuid.UUID
+	TokenCount      int
+	KnownTokenCount int
+}
+
+func (c StudentCoverage) Coverage() float64 {
+	return percentage(c.KnownTokenCount, c.TokenCount)
+}
+
+// AssignmentKnowledge is the coverage of an assignment for every student of
+// the class, in the order of the students, along with the mean and lowest
+// coverage in the class.
+type AssignmentKnowledge struct {
+	Assignment   Assignment
+	Students     []StudentCoverage
+	MeanCoverage float64
+	MinCoverage  float64
+}
+
+// NewClassKnowledge arranges the coverage of the assignments by the students
+// of the class. Students for whom no coverage is given, such as those added
+// after the query, count as knowing none of the words.
+func NewClassKnowledge(class Class, coverage []StudentCoverage) []AssignmentKnowledge {
+	type key struct {
+		assignmentID uuid.UUID
+		studentID    uuid.UUID
+	}
+
+	byKey := make(map[key]StudentCoverage, len(coverage))
+	tokenCounts := map[uuid.UUID]int{}
+
+	for _, c := range coverage {
+		byKey[key{c.AssignmentID, c.StudentID}] = c
+		tokenCounts[c.AssignmentID] = max(tokenCounts[c.AssignmentID], c.TokenCount)
+	}
+
+	knowledge := make([]AssignmentKnowledge, 0, len(class.Assignments))
+
+	for _, assignment := range class.Assignments {
+		row := AssignmentKnowledge{
+			Assignment: assignment,
+			Students:   make([]StudentCoverage, 0, len(class.Students)),
+		}
+
+		total := 0.0
+
+		for i, student := range class.Students {
+			c, ok := byKey[key{assignment.ID, student.ID}]
+			if !ok {
+				c = StudentCoverage{
+					AssignmentID: assignment.ID,
+					StudentID:    student.ID,
+					TokenCount:   tokenCounts[assignment.ID],
+				}
+			}
+
+			row.Students = append(row.Students, c)
+			total += c.Coverage()
+
+			if i == 0 || c.Coverage() < row.MinCoverage {
+				row.MinCoverage = c.Coverage()
+			}
+		}
+
+		if len(class.Students) > 0 {
+			row.MeanCoverage = total / float64(len(class.Students))
+		}
+
+		knowledge = append(knowledge, row)
+	}
+
+	return knowledge
+}
+
+// ClassWord is a word in an assignment, with the number of students of the
+// class who know it.
+type ClassWord struct {
+	WordInWork
+	KnownBy  int
+	Students int
+}
+
+// KnownShare returns the percentage of the class that knows the word.
+func (w ClassWord) KnownShare() float64 {
+	return percentage(w.KnownBy, w.Students)
+}
+
+// NewClassGlossary returns the words, in the given order, that fewer than
+// threshold percent of the students know, along with the number of students
+// who do. knownBy maps the IDs of words to that number.
+func NewClassGlossary(words []WordInWork, knownBy map[uuid.UUID]int, students int, threshold float64) []ClassWord {
+	glossary := []ClassWord{}
+
+	for _, word := range words {
+		classWord := ClassWord{
+			WordInWork: word,
+			KnownBy:    knownBy[word.ID],
+			Students:   students,
+		}
+
+		if students > 0 && classWord.KnownShare() >= threshold {
+			continue
+		}
+
+		glossary = append(glossary, classWord)
+	}
+
+	return glossary
+}
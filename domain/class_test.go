@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJoinCode(t *testing.T) {
+	a, err := NewJoinCode()
+	assert.NoError(t, err)
+
+	b, err := NewJoinCode()
+	assert.NoError(t, err)
+
+	assert.Regexp(t, `^[A-Z2-7]{8}$`, a)
+	assert.NotEqual(t, a, b)
+}
+
+func TestAssignmentScope(t *testing.T) {
+	workID, sectionID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name       string
+		assignment Assignment
+		wantScope  Scope
+		wantName   string
+	}{
+		{
+			name:       "whole work",
+			assignment: Assignment{WorkID: workID, Title: "Carmina"},
+			wantScope:  Scope{Kind: ScopeKindWork, ID: workID},
+			wantName:   "Carmina",
+		},
+		{
+			name:       "passage",
+			assignment: Assignment{WorkID: workID, SectionID: sectionID, Title: "Carmina", Citation: "64"},
+			wantScope:  Scope{Kind: ScopeKindSection, ID: sectionID},
+			wantName:   "Carmina 64",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.wantScope, test.assignment.Scope())
+			assert.Equal(t, test.wantName, test.assignment.Name())
+		})
+	}
+}
+
+func TestNewClassKnowledge(t *testing.T) {
+	ariadne, lesbia := User{ID: uuid.New()}, User{ID: uuid.New()}
+	c64, c5 := Assignment{ID: uuid.New()}, Assignment{ID: uuid.New()}
+	class := Class{Students: []User{ariadne, lesbia}, Assignments: []Assignment{c64, c5}}
+
+	coverage := []StudentCoverage{
+		{AssignmentID: c64.ID, StudentID: lesbia.ID, TokenCount: 200, KnownTokenCount: 100},
+		{AssignmentID: c64.ID, StudentID: ariadne.ID, TokenCount: 200, KnownTokenCount: 180},
+		{AssignmentID: c5.ID, StudentID: ariadne.ID, TokenCount: 50, KnownTokenCount: 25},
+	}
+
+	got := NewClassKnowledge(class, coverage)
+
+	assert.Len(t, got, 2)
+
+	assert.Equal(t, c64, got[0].Assignment)
+	assert.Equal(t, []StudentCoverage{coverage[1], coverage[0]}, got[0].Students)
+	assert.InDelta(t, 70.0, got[0].MeanCoverage, 1e-9)
+	assert.InDelta(t, 50.0, got[0].MinCoverage, 1e-9)
+
+	assert.Equal(t, StudentCoverage{AssignmentID: c5.ID, StudentID: lesbia.ID, TokenCount: 50}, got[1].Students[1])
+	assert.InDelta(t, 25.0, got[1].MeanCoverage, 1e-9)
+	assert.InDelta(t, 0.0, got[1].MinCoverage, 1e-9)
+}
+
+func TestNewClassGlossary(t *testing.T) {
+	pelion := WordInWork{Word: Word{ID: uuid.New(), LemmaRaw: "Pelion"}}
+	pinus := WordInWork{Word: Word{ID: uuid.New(), LemmaRaw: "pinus"}}
+	et := WordInWork{Word: Word{ID: uuid.New(), LemmaRaw: "et"}}
+	words := []WordInWork{pelion, pinus, et}
+
+	knownBy := map[uuid.UUID]int{pinus.ID: 1, et.ID: 4}
+
+	tests := []struct {
+		name      string
+		students  int
+		threshold float64
+		want      []ClassWord
+	}{
+		{
+			name:      "fewer than half the class",
+			students:  4,
+			threshold: 50,
+			want: []ClassWord{
+				{WordInWork: pelion, KnownBy: 0, Students: 4},
+				{WordInWork: pinus, KnownBy: 1, Students: 4},
+			},
+		},
+		{
+			name:      "known by nobody",
+			students:  4,
+			threshold: 25,
+			want:      []ClassWord{{WordInWork: pelion, KnownBy: 0, Students: 4}},
+		},
+		{
+			name:      "class without students",
+			students:  0,
+			threshold: 50,
+			want: []ClassWord{
+				{WordInWork: pelion, KnownBy: 0},
+				{WordInWork: pinus, KnownBy: 1},
+				{WordInWork: et, KnownBy: 4},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, NewClassGlossary(words, knownBy, test.students, test.threshold))
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Collection is a named selection of works, made by and visible only to one
+// user, that can be used as a scope for word lists and statistics.
+type Collection struct {
+	ID       uuid.UUID
+	Name     string
+	WorkIDs  []uuid.UUID
+	Created  time.Time
+	Modified time.Time
+	Deleted  time.Time
+}
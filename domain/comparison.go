@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+)
+
+// ComparedWord holds the number of occurrences of a lemma in two scopes, A and
+// B, at least one of which is non-zero.
+type ComparedWord struct {
+	Word
+	CountA int
+	CountB int
+}
+
+type Comparison struct {
+	OnlyA []ComparedWord
+	OnlyB []ComparedWord
+	Both  []ComparedWord
+}
+
+// NewComparison divides the words into those that occur only in A, only in B
+// and in both. Every group is sorted by the number of occurrences, with the
+// words in both sorted by their combined count.
+func NewComparison(words []ComparedWord) Comparison {
+	comparison := Comparison{
+		OnlyA: []ComparedWord{},
+		OnlyB: []ComparedWord{},
+		Both:  []ComparedWord{},
+	}
+
+	for _, word := range words {
+		switch {
+		case word.CountA > 0 && word.CountB > 0:
+			comparison.Both = append(comparison.Both, word)
+		case word.CountA > 0:
+			comparison.OnlyA = append(comparison.OnlyA, word)
+		case word.CountB > 0:
+			comparison.OnlyB = append(comparison.OnlyB, word)
+		}
+	}
+
+	byCount := func(count func(ComparedWord) int) func(a, b ComparedWord) int {
+		return func(a, b ComparedWord) int {
+			return cmp.Or(
+				cmp.Compare(count(b), count(a)),
+				cmp.Compare(a.LemmaRaw, b.LemmaRaw),
+			)
+		}
+	}
+
+	slices.SortStableFunc(comparison.OnlyA, byCount(func(w ComparedWord) int { return w.CountA }))
+	slices.SortStableFunc(comparison.OnlyB, byCount(func(w ComparedWord) int { return w.CountB }))
+	slices.SortStableFunc(comparison.Both, byCount(func(w ComparedWord) int { return w.CountA + w.CountB }))
+
+	return comparison
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewComparison(t *testing.T) {
+	comparison := NewComparison([]ComparedWord{
+		{Word: Word{LemmaRaw: "castra"}, CountA: 12, CountB: 0},
+		{Word: Word{LemmaRaw: "urbs"}, CountA: 3, CountB: 40},
+		{Word: Word{LemmaRaw: "plebs"}, CountA: 0, CountB: 25},
+		{Word: Word{LemmaRaw: "legio"}, CountA: 30, CountB: 0},
+		{Word: Word{LemmaRaw: "sum"}, CountA: 100, CountB: 200},
+		{Word: Word{LemmaRaw: "nihil"}, CountA: 0, CountB: 0},
+	})
+
+	lemmas := func(words []ComparedWord) []string {
+		result := []string{}
+		for _, word := range words {
+			result = append(result, word.LemmaRaw)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"legio", "castra"}, lemmas(comparison.OnlyA))
+	assert.Equal(t, []string{"plebs"}, lemmas(comparison.OnlyB))
+	assert.Equal(t, []string{"sum", "urbs"}, lemmas(comparison.Both))
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"slices"
+)
+
+var DefaultCoverageThresholds = []float64{90, 95, 98}
+
+type CoverageStep struct {
+	Word       WordInWork
+	LemmaCount int
+	Coverage   float64
+}
+
+type CoverageThreshold struct {
+	Target     float64
+	LemmaCount int
+	Reached    bool
+}
+
+type CoverageCurve struct {
+	TokenCount      int
+	KnownTokenCount int
+	Steps           []CoverageStep
+	Thresholds      []CoverageThreshold
+}
+
+// NewCoverageCurve takes a frequency list and determines, for every unknown
+// lemma taken in descending order of frequency, the text coverage that would
+// be reached after learning it and all the lemmas before it. For each target
+// percentage it records how many of those lemmas need to be learnt.
+func NewCoverageCurve(words []WordInWork, thresholds []float64) CoverageCurve {
+	curve := CoverageCurve{}
+	unknown := []WordInWork{}
+
+	for _, word := range words {
+		curve.TokenCount += word.Count
+
+		if word.Known {
+			curve.KnownTokenCount += word.Count
+			continue
+		}
+
+		unknown = append(unknown, word)
+	}
+
+	slices.SortStableFunc(unknown, func(a, b WordInWork) int {
+		return b.Count - a.Count
+	})
+
+	covered := curve.KnownTokenCount
+
+	for i, word := range unknown {
+		covered += word.Count
+		curve.Steps = append(curve.Steps, CoverageStep{
+			Word:       word,
+			LemmaCount: i + 1,
+			Coverage:   percentage(covered, curve.TokenCount),
+		})
+	}
+
+	for _, target := range thresholds {
+		threshold := CoverageThreshold{Target: target}
+
+		if curve.Coverage() >= target {
+			threshold.Reached = true
+			curve.Thresholds = append(curve.Thresholds, threshold)
+			continue
+		}
+
+		for _, step := range curve.Steps {
+			if step.Coverage >= target {
+				threshold.LemmaCount = step.LemmaCount
+				threshold.Reached = true
+				break
+			}
+		}
+
+		curve.Thresholds = append(curve.Thresholds, threshold)
+	}
+
+	return curve
+}
+
+// Coverage returns the percentage of running tokens that is currently known.
+func (c CoverageCurve) Coverage() float64 {
+	return percentage(c.KnownTokenCount, c.TokenCount)
+}
+
+// StepsToLearn returns the unknown lemmas needed to reach the highest
+// reachable threshold, in the order in which they should be learnt.
+func (c CoverageCurve) StepsToLearn() []CoverageStep {
+	count := 0
+
+	for _, threshold := range c.Thresholds {
+		if threshold.Reached {
+			count = max(count, threshold.LemmaCount)
+		}
+	}
+
+	return c.Steps[:count]
+}
+
+func percentage(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return float64(part) / float64(total) * 100
+}
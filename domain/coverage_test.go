@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCoverageCurve(t *testing.T) {
+	sum := WordInWork{Word: Word{LemmaRich: "sum, es, esse", Known: true}, Count: 50}
+	et := WordInWork{Word: Word{LemmaRich: "ĕt, conj. adv."}, Count: 30}
+	arma := WordInWork{Word: Word{LemmaRich: "arma, orum, n."}, Count: 15}
+	cano := WordInWork{Word: Word{LemmaRich: "cano, is, ere"}, Count: 5}
+
+	tests := []struct {
+		name          string
+		words         []WordInWork
+		thresholds    []float64
+		expectedSteps []CoverageStep
+		expected      []CoverageThreshold
+	}{
+		{
+			name:       "unknown lemmas are taken in frequency order",
+			words:      []WordInWork{cano, sum, arma, et},
+			thresholds: []float64{90, 95, 98},
+			expectedSteps: []CoverageStep{
+				{Word: et, LemmaCount: 1, Coverage: 80},
+				{Word: arma, LemmaCount: 2, Coverage: 95},
+				{Word: cano, LemmaCount: 3, Coverage: 100},
+			},
+			expected: []CoverageThreshold{
+				{Target: 90, LemmaCount: 2, Reached: true},
+				{Target: 95, LemmaCount: 2, Reached: true},
+				{Target: 98, LemmaCount: 3, Reached: true},
+			},
+		},
+		{
+			name:       "threshold already reached",
+			words:      []WordInWork{sum, cano},
+			thresholds: []float64{90},
+			expectedSteps: []CoverageStep{
+				{Word: cano, LemmaCount: 1, Coverage: 100},
+			},
+			expected: []CoverageThreshold{
+				{Target: 90, LemmaCount: 0, Reached: true},
+			},
+		},
+		{
+			name:          "empty list",
+			words:         []WordInWork{},
+			thresholds:    []float64{90},
+			expectedSteps: nil,
+			expected: []CoverageThreshold{
+				{Target: 90, LemmaCount: 0, Reached: false},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := NewCoverageCurve(test.words, test.thresholds)
+			assert.Equal(t, test.expectedSteps, output.Steps)
+			assert.Equal(t, test.expected, output.Thresholds)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "github.com/google/uuid"
+
+// Deck is a word list prepared for export to other review tools, such as
+// Anki, in the order of the list.
+type Deck struct {
+	Name  string
+	Cards []DeckCard
+}
+
+type DeckCard struct {
+	WordInWork
+	Example Example
+}
+
+// Example is the first occurrence of a word in a scope. Works lists the titles
+// of all works in the scope the word occurs in, and Sentence the words of the
+// sentence of the example, of which the one at Position is the word itself.
+type Example struct {
+	WordID   uuid.UUID
+	Works    []string
+	Title    string
+	Author   string
+	Sentence []string
+	Position int
+}
+
+func NewDeck(name string, words []WordInWork, examples map[uuid.UUID]Example) Deck {
+	deck := Deck{
+		Name:  name,
+		Cards: make([]DeckCard, 0, len(words)),
+	}
+
+	for _, word := range words {
+		deck.Cards = append(deck.Cards, DeckCard{
+			WordInWork: word,
+			Example:    examples[word.ID],
+		})
+	}
+
+	return deck
+}
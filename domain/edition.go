@@ -0,0 +1,243 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type EditionFormat string
+
+const (
+	EditionFormatLaTeX EditionFormat = "latex"
+	EditionFormatTypst EditionFormat = "typst"
+	EditionFormatEPUB  EditionFormat = "epub"
+)
+
+// EditionUnit determines how a reader's edition is divided into pages, each
+// with its own glossary: into chunks of about the same number of words, or
+// into sections.
+type EditionUnit string
+
+const (
+	EditionUnitPage    EditionUnit = "page"
+	EditionUnitSection EditionUnit = "section"
+)
+
+const DefaultWordsPerPage = 150
+
+// EditionOptions determine which words are glossed in a reader's edition.
+// Words more frequent in LASLA than MaxFrequencyInLASLA are assumed to be
+// familiar and are left out, as are known words when ExcludeKnown is set; a
+// zero MaxFrequencyInLASLA glosses words regardless of their frequency. Pages
+// end at the first sentence boundary after WordsPerPage words, or, for
+// sections, follow the sections at Level (1 being the top level), or the
+// innermost sections if Level is zero.
+type EditionOptions struct {
+	Unit                EditionUnit
+	WordsPerPage        int
+	Level               int
+	MaxFrequencyInLASLA int
+	ExcludeKnown        bool
+}
+
+// Edition is a work laid out for reading, with a glossary of the words that
+// need glossing beneath every page and all of them in the WordList.
+type Edition struct {
+	ID       uuid.UUID
+	Title    string
+	Author   string
+	Pages    []EditionPage
+	WordList []WordInWork
+}
+
+type EditionPage struct {
+	Heading   string
+	Sentences [][]EditionToken
+	Glossary  []WordInWork
+}
+
+type EditionToken struct {
+	Form    string
+	WordID  uuid.UUID
+	Glossed bool
+}
+
+// NewEdition lays out the words of the work, which must be in order, on
+// pages, and glosses the words on each page that meet the options. Words are
+// glossed on every page they occur on, and glossaries are sorted
+// alphabetically.
+func NewEdition(work Work, workWords []WorkWord, words []WordInWork, sections []Section, options EditionOptions) Edition {
+	edition := Edition{
+		ID:       work.ID,
+		Title:    work.Title,
+		Author:   work.Author.Name,
+		Pages:    []EditionPage{},
+		WordList: []WordInWork{},
+	}
+
+	wordsByID := make(map[uuid.UUID]WordInWork, len(words))
+
+	for _, word := range words {
+		wordsByID[word.ID] = word
+	}
+
+	glossed := func(id uuid.UUID) bool {
+		word, ok := wordsByID[id]
+
+		switch {
+		case !ok:
+			return false
+		case options.ExcludeKnown && word.Known:
+			return false
+		case options.MaxFrequencyInLASLA > 0 && word.FrequencyInLASLA > options.MaxFrequencyInLASLA:
+			return false
+		default:
+			return true
+		}
+	}
+
+	pageOf := pageByWordCount(workWords, cmp.Or(options.WordsPerPage, DefaultWordsPerPage))
+	headings := map[int]string{}
+
+	if options.Unit == EditionUnitSection {
+		if pages := editionSections(sections, options.Level); len(pages) > 0 {
+			pageOf, headings = pageBySection(pages)
+		}
+	}
+
+	inWordList := map[uuid.UUID]bool{}
+	inGlossary := map[uuid.UUID]bool{}
+
+	var page *EditionPage
+	currentPage, currentSentence := -1, -1
+
+	for _, ww := range workWords {
+		if p := pageOf(ww); page == nil || p != currentPage {
+			edition.Pages = append(edition.Pages, EditionPage{Heading: headings[p]})
+			page = &edition.Pages[len(edition.Pages)-1]
+			currentPage, currentSentence = p, -1
+			inGlossary = map[uuid.UUID]bool{}
+		}
+
+		if ww.SentenceIndex != currentSentence {
+			page.Sentences = append(page.Sentences, []EditionToken{})
+			currentSentence = ww.SentenceIndex
+		}
+
+		token := EditionToken{
+			Form:    ww.OriginalForm,
+			WordID:  ww.WordID,
+			Glossed: glossed(ww.WordID),
+		}
+
+		last := len(page.Sentences) - 1
+		page.Sentences[last] = append(page.Sentences[last], token)
+
+		if !token.Glossed {
+			continue
+		}
+
+		if !inGlossary[ww.WordID] {
+			page.Glossary = append(page.Glossary, wordsByID[ww.WordID])
+			inGlossary[ww.WordID] = true
+		}
+
+		if !inWordList[ww.WordID] {
+			edition.WordList = append(edition.WordList, wordsByID[ww.WordID])
+			inWordList[ww.WordID] = true
+		}
+	}
+
+	for i := range edition.Pages {
+		slices.SortFunc(edition.Pages[i].Glossary, compareLemmas)
+	}
+
+	slices.SortFunc(edition.WordList, compareLemmas)
+
+	return edition
+}
+
+// pageByWordCount starts a new page at the first sentence that begins after
+// the current page has reached the given number of words.
+func pageByWordCount(workWords []WorkWord, wordsPerPage int) func(WorkWord) int {
+	pages := make(map[int]int, len(workWords))
+	page, count, sentence := 0, 0, -1
+
+	for _, ww := range workWords {
+		if ww.SentenceIndex != sentence && count >= wordsPerPage {
+			page++
+			count = 0
+		}
+
+		sentence = ww.SentenceIndex
+		pages[ww.WordIndex] = page
+		count++
+	}
+
+	return func(ww WorkWord) int {
+		return pages[ww.WordIndex]
+	}
+}
+
+// pageBySection puts every word on the page of the section it is in. Words
+// outside all sections go on a page without a heading. Words must be passed in
+// order, as sections are not searched again once the words have passed them.
+func pageBySection(sections []Section) (func(WorkWord) int, map[int]string) {
+	headings := make(map[int]string, len(sections))
+
+	for i, section := range sections {
+		headings[i] = section.Citation
+	}
+
+	current := 0
+
+	return func(ww WorkWord) int {
+		for current < len(sections) && ww.WordIndex > sections[current].LastWordIndex {
+			current++
+		}
+
+		if current < len(sections) && ww.WordIndex >= sections[current].FirstWordIndex {
+			return current
+		}
+
+		return -1
+	}, headings
+}
+
+// editionSections returns the sections at the given level, or the innermost
+// sections if the level is zero, in document order.
+func editionSections(sections []Section, level int) []Section {
+	parents := map[uuid.UUID]bool{}
+
+	for _, section := range sections {
+		parents[section.ParentID] = true
+	}
+
+	selected := []Section{}
+
+	for _, section := range sections {
+		if (level == 0 && !parents[section.ID]) || section.Depth == level-1 {
+			selected = append(selected, section)
+		}
+	}
+
+	slices.SortFunc(selected, func(a, b Section) int {
+		return cmp.Compare(a.Position, b.Position)
+	})
+
+	return selected
+}
+
+// compareLemmas sorts lemmas alphabetically, treating u/v and i/j as the same
+// letter.
+func compareLemmas(a, b WordInWork) int {
+	fold := strings.NewReplacer("v", "u", "j", "i")
+
+	return cmp.Or(
+		strings.Compare(fold.Replace(strings.ToLower(a.LemmaRaw)), fold.Replace(strings.ToLower(b.LemmaRaw))),
+		strings.Compare(a.LemmaRaw, b.LemmaRaw),
+	)
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEdition(t *testing.T) {
+	gallia := WordInWork{Word: Word{ID: uuid.New(), LemmaRaw: "Gallia", FrequencyInLASLA: 50}}
+	sum := WordInWork{Word: Word{ID: uuid.New(), LemmaRaw: "sum", FrequencyInLASLA: 20000, Known: true}}
+	omnis := WordInWork{Word: Word{ID: uuid.New(), LemmaRaw: "omnis", FrequencyInLASLA: 3000}}
+	divido := WordInWork{Word: Word{ID: uuid.New(), LemmaRaw: "diuido", FrequencyInLASLA: 200}}
+	words := []WordInWork{gallia, sum, omnis, divido}
+
+	// Gallia est omnis diuisa. Gallia est.
+	workWords := []WorkWord{
+		{WordIndex: 1, SentenceIndex: 1, WordID: gallia.ID, OriginalForm: "Gallia"},
+		{WordIndex: 2, SentenceIndex: 1, WordID: sum.ID, OriginalForm: "est"},
+		{WordIndex: 3, SentenceIndex: 1, WordID: omnis.ID, OriginalForm: "omnis"},
+		{WordIndex: 4, SentenceIndex: 1, WordID: divido.ID, OriginalForm: "diuisa"},
+		{WordIndex: 5, SentenceIndex: 2, WordID: gallia.ID, OriginalForm: "Gallia"},
+		{WordIndex: 6, SentenceIndex: 2, WordID: sum.ID, OriginalForm: "est"},
+	}
+
+	book := Section{ID: uuid.New(), Position: 1, Depth: 0, Citation: "1", FirstWordIndex: 1, LastWordIndex: 6}
+	sections := []Section{
+		book,
+		{ID: uuid.New(), ParentID: book.ID, Position: 2, Depth: 1, Citation: "1.1", FirstWordIndex: 1, LastWordIndex: 3},
+		{ID: uuid.New(), ParentID: book.ID, Position: 3, Depth: 1, Citation: "1.2", FirstWordIndex: 4, LastWordIndex: 6},
+	}
+
+	lemmas := func(words []WordInWork) []string {
+		lemmas := []string{}
+
+		for _, word := range words {
+			lemmas = append(lemmas, word.LemmaRaw)
+		}
+
+		return lemmas
+	}
+
+	tests := []struct {
+		name         string
+		options      EditionOptions
+		wantHeadings []string
+		wantSizes    [][]int
+		wantGlossary [][]string
+		wantWordList []string
+	}{
+		{
+			name:         "pages end at sentence boundaries",
+			options:      EditionOptions{Unit: EditionUnitPage, WordsPerPage: 3},
+			wantHeadings: []string{""},
+			wantSizes:    [][]int{{4}, {2}},
+			wantGlossary: [][]string{{"diuido", "Gallia", "omnis", "sum"}, {"Gallia", "sum"}},
+			wantWordList: []string{"diuido", "Gallia", "omnis", "sum"},
+		},
+		{
+			name:         "innermost sections split sentences",
+			options:      EditionOptions{Unit: EditionUnitSection, ExcludeKnown: true},
+			wantHeadings: []string{"1.1", "1.2"},
+			wantSizes:    [][]int{{3}, {1, 2}},
+			wantGlossary: [][]string{{"Gallia", "omnis"}, {"diuido", "Gallia"}},
+			wantWordList: []string{"diuido", "Gallia", "omnis"},
+		},
+		{
+			name:         "top-level sections with a frequency threshold",
+			options:      EditionOptions{Unit: EditionUnitSection, Level: 1, MaxFrequencyInLASLA: 1000},
+			wantHeadings: []string{"1"},
+			wantSizes:    [][]int{{4, 2}},
+			wantGlossary: [][]string{{"diuido", "Gallia"}},
+			wantWordList: []string{"diuido", "Gallia"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edition := NewEdition(Work{Title: "De bello Gallico", Author: Author{Name: "Caesar"}}, workWords, words, sections, tt.options)
+
+			assert.Equal(t, "De bello Gallico", edition.Title)
+			assert.Equal(t, "Caesar", edition.Author)
+			assert.Len(t, edition.Pages, len(tt.wantSizes))
+
+			for i, page := range edition.Pages {
+				if i < len(tt.wantHeadings) {
+					assert.Equal(t, tt.wantHeadings[i], page.Heading, "page %d", i)
+				}
+
+				sizes := []int{}
+
+				for _, sentence := range page.Sentences {
+					sizes = append(sizes, len(sentence))
+				}
+
+				assert.Equal(t, tt.wantSizes[i], sizes, "page %d", i)
+				assert.Equal(t, tt.wantGlossary[i], lemmas(page.Glossary), "page %d", i)
+			}
+
+			assert.Equal(t, tt.wantWordList, lemmas(edition.WordList))
+		})
+	}
+}
+
+func TestNewEditionMarksGlossedTokens(t *testing.T) {
+	known := WordInWork{Word: Word{ID: uuid.New(), LemmaRaw: "et", Known: true}}
+	unknown := WordInWork{Word: Word{ID: uuid.New(), LemmaRaw: "arma"}}
+
+	edition := NewEdition(Work{}, []WorkWord{
+		{WordIndex: 1, SentenceIndex: 1, WordID: unknown.ID, OriginalForm: "arma"},
+		{WordIndex: 2, SentenceIndex: 1, WordID: known.ID, OriginalForm: "et"},
+	}, []WordInWork{known, unknown}, nil, EditionOptions{ExcludeKnown: true})
+
+	assert.Equal(t, [][]EditionToken{{
+		{Form: "arma", WordID: unknown.ID, Glossed: true},
+		{Form: "et", WordID: known.ID, Glossed: false},
+	}}, edition.Pages[0].Sentences)
+}
@@ -0,0 +1,30 @@
+package domain
+
+// FlashcardFront determines what the front of a flashcard shows: the lemma on
+// its own, or the form in which it occurs in its sentence.
+type FlashcardFront string
+
+const (
+	FlashcardFrontLemma   FlashcardFront = "lemma"
+	FlashcardFrontContext FlashcardFront = "context"
+)
+
+// QueueOrder is the order in which the unknown words of a work are added to
+// the review queue.
+type QueueOrder string
+
+const (
+	QueueOrderFrequency       QueueOrder = "frequency"
+	QueueOrderFirstOccurrence QueueOrder = "first"
+)
+
+// Flashcard is a due word together with an occurrence of it in the corpus,
+// whose sentence and morphological analysis are shown on the card.
+type Flashcard struct {
+	Word
+	State    LearningState
+	Example  WorkWord
+	Title    string
+	Author   string
+	Sentence []WorkWord
+}
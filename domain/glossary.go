@@ -0,0 +1,17 @@
+package domain
+
+type RangeUnit string
+
+const (
+	RangeUnitWord     RangeUnit = "word"
+	RangeUnitSentence RangeUnit = "sentence"
+)
+
+// GlossaryOptions narrow down a glossary. From and To are inclusive word or
+// sentence indexes; nil means the range is unbounded on that side.
+type GlossaryOptions struct {
+	Unit                RangeUnit
+	From                *int
+	To                  *int
+	FirstOccurrenceOnly bool
+}
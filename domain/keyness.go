@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+type KeynessReference string
+
+const (
+	KeynessReferenceCorpus KeynessReference = "corpus"
+	KeynessReferenceLASLA  KeynessReference = "lasla"
+)
+
+type KeywordSort string
+
+const (
+	KeywordSortLogLikelihood  KeywordSort = "ll"
+	KeywordSortPercentDiff    KeywordSort = "diff"
+	KeywordSortLogRatio       KeywordSort = "ratio"
+	KeywordSortTargetCount    KeywordSort = "target"
+	KeywordSortReferenceCount KeywordSort = "reference"
+	KeywordSortAlphabetical   KeywordSort = "alphabetical"
+)
+
+// Keyword compares the frequency of a lemma in a target text with its
+// frequency in a reference corpus. LogLikelihood is signed: it is negative
+// when the lemma is relatively less frequent in the target than in the
+// reference.
+type Keyword struct {
+	Word
+	TargetCount    int
+	ReferenceCount int
+	LogLikelihood  float64
+	PercentDiff    float64
+	LogRatio       float64
+}
+
+// ScoreKeywords calculates the keyness statistics for every keyword, using
+// the sums of the target and reference counts as the sizes of both corpora.
+func ScoreKeywords(keywords []Keyword) {
+	targetTotal, referenceTotal := 0, 0
+
+	for _, keyword := range keywords {
+		targetTotal += keyword.TargetCount
+		referenceTotal += keyword.ReferenceCount
+	}
+
+	if targetTotal == 0 || referenceTotal == 0 {
+		return
+	}
+
+	for i, keyword := range keywords {
+		a, b := float64(keyword.TargetCount), float64(keyword.ReferenceCount)
+		c, d := float64(targetTotal), float64(referenceTotal)
+
+		// Log-likelihood as described by Rayson and Garside (2000)
+		e1 := c * (a + b) / (c + d)
+		e2 := d * (a + b) / (c + d)
+		ll := 2 * (xLogXOverY(a, e1) + xLogXOverY(b, e2))
+
+		if a/c < b/d {
+			ll = -ll
+		}
+
+		// %DIFF as described by Gabrielatos and Marchi (2012), who substitute
+		// a very small number for a reference frequency of zero
+		targetNormalised := a / c * 1_000_000
+		referenceNormalised := max(b/d*1_000_000, 1e-18)
+
+		// Log ratio as described by Hardie (2014), with 0.5 added to zero
+		// frequencies to avoid dividing by zero
+		logRatio := math.Log2((max(a, 0.5) / c) / (max(b, 0.5) / d))
+
+		keywords[i].LogLikelihood = ll
+		keywords[i].PercentDiff = (targetNormalised - referenceNormalised) * 100 / referenceNormalised
+		keywords[i].LogRatio = logRatio
+	}
+}
+
+// SortKeywords sorts keywords in descending order of the chosen statistic, or
+// alphabetically.
+func SortKeywords(keywords []Keyword, sort KeywordSort) {
+	slices.SortStableFunc(keywords, func(a, b Keyword) int {
+		switch sort {
+		case KeywordSortPercentDiff:
+			return cmp.Compare(b.PercentDiff, a.PercentDiff)
+		case KeywordSortLogRatio:
+			return cmp.Compare(b.LogRatio, a.LogRatio)
+		case KeywordSortTargetCount:
+			return cmp.Compare(b.TargetCount, a.TargetCount)
+		case KeywordSortReferenceCount:
+			return cmp.Compare(b.ReferenceCount, a.ReferenceCount)
+		case KeywordSortAlphabetical:
+			return cmp.Compare(a.LemmaRaw, b.LemmaRaw)
+		default:
+			return cmp.Compare(b.LogLikelihood, a.LogLikelihood)
+		}
+	})
+}
+
+func xLogXOverY(x, y float64) float64 {
+	if x == 0 {
+		return 0
+	}
+
+	return x * math.Log(x/y)
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoreKeywords(t *testing.T) {
+	keywords := []Keyword{
+		{Word: Word{LemmaRaw: "princeps"}, TargetCount: 10, ReferenceCount: 100},
+		{Word: Word{LemmaRaw: "sum"}, TargetCount: 990, ReferenceCount: 97900},
+		{Word: Word{LemmaRaw: "consul"}, TargetCount: 0, ReferenceCount: 2000},
+	}
+
+	ScoreKeywords(keywords)
+
+	assert.InDelta(t, 27.2725, keywords[0].LogLikelihood, 0.0001)
+	assert.InDelta(t, 900.0, keywords[0].PercentDiff, 0.0001)
+	assert.InDelta(t, 3.3219, keywords[0].LogRatio, 0.0001)
+
+	assert.Positive(t, keywords[1].LogLikelihood)
+	assert.Positive(t, keywords[1].PercentDiff)
+
+	assert.Negative(t, keywords[2].LogLikelihood)
+	assert.InDelta(t, -100.0, keywords[2].PercentDiff, 0.0001)
+	assert.InDelta(t, -5.3219, keywords[2].LogRatio, 0.0001)
+
+	SortKeywords(keywords, KeywordSortLogLikelihood)
+
+	assert.Equal(t, "princeps", keywords[0].LemmaRaw)
+	assert.Equal(t, "consul", keywords[2].LemmaRaw)
+}
+
+func TestScoreKeywordsWithoutReference(t *testing.T) {
+	keywords := []Keyword{
+		{Word: Word{LemmaRaw: "princeps"}, TargetCount: 10, ReferenceCount: 0},
+	}
+
+	ScoreKeywords(keywords)
+
+	assert.Equal(t, 0.0, keywords[0].LogLikelihood)
+}
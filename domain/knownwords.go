@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// KnownWordFormat is the format of a list of known words: plain text with a
+// lemma on every line, CSV or TSV with the lemmas in a column named lemma,
+// headword or word (or else the first column), a plain text export of Anki
+// notes, or a Dickinson College Commentaries core vocabulary list, whose
+// Headword column gives the lemma with its principal parts.
+type KnownWordFormat string
+
+const (
+	KnownWordFormatText KnownWordFormat = "text"
+	KnownWordFormatCSV  KnownWordFormat = "csv"
+	KnownWordFormatAnki KnownWordFormat = "anki"
+	KnownWordFormatDCC  KnownWordFormat = "dcc"
+)
+
+var KnownWordFormats = []KnownWordFormat{
+	KnownWordFormatText,
+	KnownWordFormatCSV,
+	KnownWordFormatAnki,
+	KnownWordFormatDCC,
+}
+
+func ParseKnownWordFormat(s string) (KnownWordFormat, error) {
+	for _, format := range KnownWordFormats {
+		if string(format) == s {
+			return format, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid known word format %q", s)
+}
+
+// KnownWordEntry is a lemma read from a list of known words, along with the
+// line it was found on.
+type KnownWordEntry struct {
+	Line  int
+	Lemma string
+}
+
+type AmbiguousKnownWordEntry struct {
+	KnownWordEntry
+	Candidates []WordInWork
+}
+
+// KnownWordReport is the outcome of an import of known words. Matched holds
+// every word an entry matched, Marked the number of those that were not yet
+// known; entries that matched no word or more than one are left for the user
+// to resolve.
+type KnownWordReport struct {
+	Entries   int
+	Matched   []WordInWork
+	Marked    int
+	Unmatched []KnownWordEntry
+	Ambiguous []AmbiguousKnownWordEntry
+}
+
+var (
+	markupTags = regexp.MustCompile(`<[^>]*>`)
+	notes      = regexp.MustCompile(`\([^)]*\)`)
+)
+
+// StripTags removes HTML tags, which Anki exports keep in their fields, and
+// unescapes the entities left behind.
+func StripTags(s string) string {
+	return html.UnescapeString(markupTags.ReplaceAllString(s, ""))
+}
+
+var plainLetters = strings.NewReplacer(
+	"ā", "a", "ă", "a", "á", "a", "à", "a", "â", "a", "ä", "a",
+	"ē", "e", "ĕ", "e", "é", "e", "è", "e", "ê", "e", "ë", "e",
+	"ī", "i", "ĭ", "i", "í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ō", "o", "ŏ", "o", "ó", "o", "ò", "o", "ô", "o", "ö", "o",
+	"ū", "u", "ŭ", "u", "ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ȳ", "y", "ý", "y", "ÿ", "y",
+	"æ", "ae", "œ", "oe",
+	"v", "u", "j", "i",
+)
+
+// NormaliseLemma reduces a lemma or dictionary entry to its headword, for
+// matching lemmas regardless of how they are spelt: without markup, notes in
+// parentheses or further principal parts, in lower case, without macrons,
+// breves and other diacritics, with u for v and i for j, and without the
+// number that distinguishes homonyms.
+func NormaliseLemma(s string) string {
+	s = StripTags(s)
+	s = notes.ReplaceAllString(s, "")
+	s, _, _ = strings.Cut(s, ",")
+	s, _, _ = strings.Cut(s, ";")
+	s = strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Mn, r) {
+			return -1
+		}
+
+		return r
+	}, strings.ToLower(s))
+	s = plainLetters.Replace(s)
+	s = strings.Join(strings.Fields(s), " ")
+	s = strings.TrimRight(s, "0123456789")
+
+	return strings.Trim(s, " -.")
+}
+
+// MatchKnownWords looks up the word every entry refers to by comparing the
+// normalised entry with the normalised lemma and the headword of the rich
+// lemma of every word. A numbered homonym matches the word with exactly that
+// lemma.
+func MatchKnownWords(entries []KnownWordEntry, words []WordInWork) KnownWordReport {
+	report := KnownWordReport{
+		Entries:   len(entries),
+		Matched:   []WordInWork{},
+		Unmatched: []KnownWordEntry{},
+		Ambiguous: []AmbiguousKnownWordEntry{},
+	}
+
+	exact := map[string][]WordInWork{}
+	normalised := map[string][]WordInWork{}
+
+	for _, word := range words {
+		exact[strings.ToLower(word.LemmaRaw)] = append(exact[strings.ToLower(word.LemmaRaw)], word)
+
+		keys := []string{NormaliseLemma(word.LemmaRaw), NormaliseLemma(word.LemmaRich)}
+
+		for i, key := range keys {
+			if key == "" || slices.Contains(keys[:i], key) {
+				continue
+			}
+
+			normalised[key] = append(normalised[key], word)
+		}
+	}
+
+	matched := map[uuid.UUID]bool{}
+
+	for _, entry := range entries {
+		var candidates []WordInWork
+
+		// Numbered homonyms pick out a single word.
+		if strings.TrimRight(entry.Lemma, "0123456789") != entry.Lemma {
+			candidates = exact[strings.ToLower(entry.Lemma)]
+		}
+
+		if len(candidates) != 1 {
+			candidates = normalised[NormaliseLemma(entry.Lemma)]
+		}
+
+		switch len(candidates) {
+		case 0:
+			report.Unmatched = append(report.Unmatched, entry)
+		case 1:
+			if !matched[candidates[0].ID] {
+				report.Matched = append(report.Matched, candidates[0])
+				matched[candidates[0].ID] = true
+			}
+		default:
+			sorted := slices.Clone(candidates)
+			slices.SortFunc(sorted, compareLemmas)
+
+			report.Ambiguous = append(report.Ambiguous, AmbiguousKnownWordEntry{
+				KnownWordEntry: entry,
+				Candidates:     sorted,
+			})
+		}
+	}
+
+	slices.SortFunc(report.Matched, compareLemmas)
+
+	return report
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormaliseLemma(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "pēdīco, as, are", want: "pedico"},
+		{input: "ĕgō̆, mei, pron.", want: "ego"},
+		{input: "vōs, uestrum, pl. pron.", want: "uos"},
+		{input: "Iūlius", want: "iulius"},
+		{input: "edo2", want: "edo"},
+		{input: "cum (conj.)", want: "cum"},
+		{input: "-que", want: "que"},
+		{input: "caelum<sup>1</sup>", want: "caelum"},
+		{input: "cælum", want: "caelum"},
+		{input: "res  publica", want: "res publica"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			assert.Equal(t, test.want, NormaliseLemma(test.input))
+		})
+	}
+}
+
+func TestMatchKnownWords(t *testing.T) {
+	vos := WordInWork{Word: Word{ID: uuid.New(), LemmaRaw: "vos", LemmaRich: "vōs, uestrum, pl. pron."}}
+	edo := WordInWork{Word: Word{ID: uuid.New(), LemmaRaw: "edo", LemmaRich: "ĕdo, ēdi, ēsum, ĕdĕre"}}
+	edo2 := WordInWork{Word: Word{ID: uuid.New(), LemmaRaw: "edo2", LemmaRich: "ēdo, ēdĭdi, ēdĭtum, ĕre"}}
+	iacio := WordInWork{Word: Word{ID: uuid.New(), LemmaRaw: "jacio", LemmaRich: "jăcĭo, is, ere, jēci, jactum"}}
+	words := []WordInWork{vos, edo, edo2, iacio}
+
+	entries := []KnownWordEntry{
+		{Line: 1, Lemma: "uos"},
+		{Line: 2, Lemma: "vōs"},
+		{Line: 3, Lemma: "iacio, iacere, iēcī, iactum"},
+		{Line: 4, Lemma: "edo"},
+		{Line: 5, Lemma: "edo2"},
+		{Line: 6, Lemma: "amo"},
+	}
+
+	got := MatchKnownWords(entries, words)
+
+	assert.Equal(t, 6, got.Entries)
+	assert.Equal(t, []WordInWork{edo2, iacio, vos}, got.Matched)
+	assert.Equal(t, []KnownWordEntry{{Line: 6, Lemma: "amo"}}, got.Unmatched)
+	assert.Equal(t, []AmbiguousKnownWordEntry{
+		{KnownWordEntry: KnownWordEntry{Line: 4, Lemma: "edo"}, Candidates: []WordInWork{edo, edo2}},
+	}, got.Ambiguous)
+}
@@ -0,0 +1,316 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LearningStatus string
+
+const (
+	LearningStatusNew      LearningStatus = "new"
+	LearningStatusLearning LearningStatus = "learning"
+	LearningStatusReview   LearningStatus = "review"
+	LearningStatusKnown    LearningStatus = "known"
+	LearningStatusIgnored  LearningStatus = "ignored"
+)
+
+var LearningStatuses = []LearningStatus{
+	LearningStatusNew,
+	LearningStatusLearning,
+	LearningStatusReview,
+	LearningStatusKnown,
+	LearningStatusIgnored,
+}
+
+// KnownLearningStatuses are the statuses that count as known in word lists,
+// coverage and the other statistics: words that have been marked as known and
+// words that need no learning at all, such as proper names. Words still in
+// review are being learnt and do not count as known yet.
+var KnownLearningStatuses = []LearningStatus{
+	LearningStatusKnown,
+	LearningStatusIgnored,
+}
+
+func ParseLearningStatus(s string) (LearningStatus, error) {
+	for _, status := range LearningStatuses {
+		if string(status) == s {
+			return status, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid learning status %q", s)
+}
+
+func (s LearningStatus) IsKnown() bool {
+	for _, status := range KnownLearningStatuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Grade rates how well a word was recalled during a review.
+type Grade int
+
+const (
+	GradeAgain Grade = 1
+	GradeHard  Grade = 2
+	GradeGood  Grade = 3
+	GradeEasy  Grade = 4
+)
+
+func ParseGrade(s string) (Grade, error) {
+	switch s {
+	case "1", "again":
+		return GradeAgain, nil
+	case "2", "hard":
+		return GradeHard, nil
+	case "3", "good":
+		return GradeGood, nil
+	case "4", "easy":
+		return GradeEasy, nil
+	default:
+		return 0, fmt.Errorf("invalid grade %q", s)
+	}
+}
+
+const (
+	// DefaultDifficulty is the ease factor of a word that has not been
+	// reviewed yet; MinDifficulty is the lowest it can drop to.
+	DefaultDifficulty = 2.5
+	MinDifficulty     = 1.3
+
+	// RelearningDelay is how soon a forgotten word is shown again.
+	RelearningDelay = 10 * time.Minute
+)
+
+// LearningState is the spaced-repetition schedule of a word, following SM-2.
+// Stability is the current interval in days and Difficulty the ease factor
+// by which it grows after every successful review; the lower the ease factor,
+// the harder the word. Repetitions counts the successful reviews since the
+// word was last forgotten, and Lapses how often it was forgotten after it had
+// been learnt. A zero Due means the word is not scheduled.
+type LearningState struct {
+	WordID       uuid.UUID
+	Status       LearningStatus
+	Due          time.Time
+	Stability    float64
+	Difficulty   float64
+	Repetitions  int
+	Lapses       int
+	LastReviewed time.Time
+	Created      time.Time
+	Modified     time.Time
+}
+
+// Review records a single review of a word, together with the schedule that
+// resulted from it.
+type Review struct {
+	ID             uuid.UUID
+	WordID         uuid.UUID
+	Grade          Grade
+	PreviousStatus LearningStatus
+	Status         LearningStatus
+	Stability      float64
+	Difficulty     float64
+	Due            time.Time
+	Reviewed       time.Time
+}
+
+// StatusSource is where a change of learning status came from: a word marked
+// by hand, a bulk action on a word list, an imported list or a review.
+type StatusSource string
+
+const (
+	StatusSourceManual StatusSource = "manual"
+	StatusSourceBulk   StatusSource = "bulk"
+	StatusSourceImport StatusSource = "import"
+	StatusSourceReview StatusSource = "review"
+)
+
+// StatusAction is a change of the learning status of one or more words, such
+// as a word marked by hand, a review, or a whole word list or imported list
+// marked as known. It keeps the previous state of every word it changed, so
+// that it can be undone as a whole; WordCount is the number of words it
+// changed.
+type StatusAction struct {
+	ID          uuid.UUID
+	Source      StatusSource
+	Description string
+	Status      LearningStatus
+	WordCount   int
+	Created     time.Time
+	Undone      time.Time
+}
+
+func (a StatusAction) IsUndone() bool {
+	return !a.Undone.IsZero()
+}
+
+// StatusChange is an entry in the history of a word: the change of its
+// learning status by an action.
+type StatusChange struct {
+	ActionID       uuid.UUID
+	WordID         uuid.UUID
+	Source         StatusSource
+	Description    string
+	PreviousStatus LearningStatus
+	Status         LearningStatus
+	Changed        time.Time
+	Undone         time.Time
+}
+
+func (c StatusChange) IsUndone() bool {
+	return !c.Undone.IsZero()
+}
+
+func NewLearningState(wordID uuid.UUID) LearningState {
+	return LearningState{
+		WordID:     wordID,
+		Status:     LearningStatusNew,
+		Difficulty: DefaultDifficulty,
+	}
+}
+
+// IsDue reports whether the word is scheduled for review at the given time.
+func (s LearningState) IsDue(now time.Time) bool {
+	if s.Status != LearningStatusLearning && s.Status != LearningStatusReview {
+		return false
+	}
+
+	return !s.Due.IsZero() && !s.Due.After(now)
+}
+
+// Review schedules the next review of the word after it has been graded. A
+// forgotten word goes (back) to learning and is shown again shortly; otherwise
+// the interval becomes 1 day, then 6 days, and from then on is multiplied by
+// the ease factor, or by 1.2 when the word was hard to recall. Easy answers get
+// a bonus of 30%. The ease factor changes as in SM-2, where the grades again,
+// hard, good and easy correspond to the qualities 2 to 5.
+func (s LearningState) Review(grade Grade, now time.Time) (LearningState, Review) {
+	next := s
+	next.LastReviewed = now
+
+	if next.Difficulty == 0 {
+		next.Difficulty = DefaultDifficulty
+	}
+
+	quality := float64(grade) + 1
+	next.Difficulty = math.Max(MinDifficulty, next.Difficulty+0.1-(5-quality)*(0.08+(5-quality)*0.02))
+
+	if grade == GradeAgain {
+		if s.Status == LearningStatusReview || s.Status == LearningStatusKnown {
+			next.Lapses++
+		}
+
+		next.Status = LearningStatusLearning
+		next.Repetitions = 0
+		next.Stability = 0
+		next.Due = now.Add(RelearningDelay)
+	} else {
+		next.Repetitions++
+
+		switch {
+		case next.Repetitions == 1:
+			next.Stability = 1
+		case next.Repetitions == 2:
+			next.Stability = 6
+		case grade == GradeHard:
+			next.Stability = s.Stability * 1.2
+		default:
+			next.Stability = s.Stability * next.Difficulty
+		}
+
+		if grade == GradeEasy {
+			next.Stability *= 1.3
+		}
+
+		next.Stability = math.Round(next.Stability)
+		next.Status = LearningStatusReview
+		next.Due = now.Add(time.Duration(next.Stability * float64(24*time.Hour)))
+	}
+
+	review := Review{
+		ID:             uuid.New(),
+		WordID:         s.WordID,
+		Grade:          grade,
+		PreviousStatus: s.Status,
+		Status:         next.Status,
+		Stability:      next.Stability,
+		Difficulty:     next.Difficulty,
+		Due:            next.Due,
+		Reviewed:       now,
+	}
+
+	return next, review
+}
+
+// WithStatus sets the status of the word by hand. Words that are marked as
+// known or ignored are taken out of the review schedule, and words that are
+// reset to new start over.
+func (s LearningState) WithStatus(status LearningStatus, now time.Time) LearningState {
+	next := s
+	next.Status = status
+
+	switch status {
+	case LearningStatusKnown, LearningStatusIgnored:
+		next.Due = time.Time{}
+	case LearningStatusNew:
+		next = NewLearningState(s.WordID)
+		next.Lapses = s.Lapses
+		next.LastReviewed = s.LastReviewed
+		next.Created = s.Created
+	case LearningStatusLearning, LearningStatusReview:
+		if next.Due.IsZero() {
+			next.Due = now
+		}
+	}
+
+	return next
+}
+
+// ToggleKnown marks a word that counts as known as unknown, and any other word
+// as known. A word that had been reviewed before it was marked as known
+// returns to its schedule: review, or learning if it was last forgotten, due
+// when it would have been had it not been marked. Other words become new.
+func (s LearningState) ToggleKnown(now time.Time) LearningState {
+	if !s.Status.IsKnown() {
+		return s.WithStatus(LearningStatusKnown, now)
+	}
+
+	if s.LastReviewed.IsZero() {
+		return s.WithStatus(LearningStatusNew, now)
+	}
+
+	next := s
+
+	if s.Stability > 0 {
+		next.Status = LearningStatusReview
+		next.Due = s.LastReviewed.Add(time.Duration(s.Stability * float64(24*time.Hour)))
+	} else {
+		next.Status = LearningStatusLearning
+		next.Due = s.LastReviewed.Add(RelearningDelay)
+	}
+
+	return next
+}
+
+// Enqueue adds a new word to the review queue, due at the given time. Words
+// that are already being learnt, known or ignored are left as they are.
+func (s LearningState) Enqueue(due time.Time) (LearningState, bool) {
+	if s.Status != LearningStatusNew {
+		return s, false
+	}
+
+	next := s
+	next.Status = LearningStatusLearning
+	next.Due = due
+
+	return next, true
+}
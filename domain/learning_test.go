@@ -0,0 +1,240 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLearningStateReview(t *testing.T) {
+	now := time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC)
+	state := NewLearningState(uuid.New())
+
+	steps := []struct {
+		grade          Grade
+		wantStatus     LearningStatus
+		wantStability  float64
+		wantDifficulty float64
+		wantLapses     int
+		wantDue        time.Duration
+	}{
+		{grade: GradeGood, wantStatus: LearningStatusReview, wantStability: 1, wantDifficulty: 2.5, wantDue: 24 * time.Hour},
+		{grade: GradeGood, wantStatus: LearningStatusReview, wantStability: 6, wantDifficulty: 2.5, wantDue: 6 * 24 * time.Hour},
+		{grade: GradeGood, wantStatus: LearningStatusReview, wantStability: 15, wantDifficulty: 2.5, wantDue: 15 * 24 * time.Hour},
+		{grade: GradeHard, wantStatus: LearningStatusReview, wantStability: 18, wantDifficulty: 2.36, wantDue: 18 * 24 * time.Hour},
+		{grade: GradeEasy, wantStatus: LearningStatusReview, wantStability: 58, wantDifficulty: 2.46, wantDue: 58 * 24 * time.Hour},
+		{grade: GradeAgain, wantStatus: LearningStatusLearning, wantStability: 0, wantDifficulty: 2.14, wantLapses: 1, wantDue: RelearningDelay},
+		{grade: GradeGood, wantStatus: LearningStatusReview, wantStability: 1, wantDifficulty: 2.14, wantLapses: 1, wantDue: 24 * time.Hour},
+	}
+
+	for i, step := range steps {
+		previous := state.Status
+
+		next, review := state.Review(step.grade, now)
+
+		assert.Equal(t, step.wantStatus, next.Status, "step %d", i)
+		assert.Equal(t, step.wantStability, next.Stability, "step %d", i)
+		assert.InDelta(t, step.wantDifficulty, next.Difficulty, 0.0001, "step %d", i)
+		assert.Equal(t, step.wantLapses, next.Lapses, "step %d", i)
+		assert.Equal(t, now.Add(step.wantDue), next.Due, "step %d", i)
+		assert.Equal(t, now, next.LastReviewed, "step %d", i)
+
+		assert.Equal(t, state.WordID, review.WordID, "step %d", i)
+		assert.Equal(t, step.grade, review.Grade, "step %d", i)
+		assert.Equal(t, previous, review.PreviousStatus, "step %d", i)
+		assert.Equal(t, next.Status, review.Status, "step %d", i)
+		assert.Equal(t, next.Due, review.Due, "step %d", i)
+
+		state = next
+		now = next.Due
+	}
+}
+
+func TestLearningStateMinimumDifficulty(t *testing.T) {
+	state := NewLearningState(uuid.New())
+	now := time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC)
+
+	for range 10 {
+		state, _ = state.Review(GradeAgain, now)
+	}
+
+	assert.Equal(t, MinDifficulty, state.Difficulty)
+	assert.Equal(t, 0, state.Lapses)
+}
+
+func TestLearningStateIsDue(t *testing.T) {
+	now := time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		state LearningState
+		want  bool
+	}{
+		{name: "new", state: LearningState{Status: LearningStatusNew}, want: false},
+		{name: "review due", state: LearningState{Status: LearningStatusReview, Due: now}, want: true},
+		{name: "review overdue", state: LearningState{Status: LearningStatusReview, Due: now.Add(-time.Hour)}, want: true},
+		{name: "review later", state: LearningState{Status: LearningStatusReview, Due: now.Add(time.Hour)}, want: false},
+		{name: "learning due", state: LearningState{Status: LearningStatusLearning, Due: now}, want: true},
+		{name: "known", state: LearningState{Status: LearningStatusKnown, Due: now}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.state.IsDue(now))
+		})
+	}
+}
+
+func TestLearningStateWithStatus(t *testing.T) {
+	now := time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC)
+	reviewed := LearningState{
+		WordID:       uuid.New(),
+		Status:       LearningStatusReview,
+		Due:          now.Add(24 * time.Hour),
+		Stability:    6,
+		Difficulty:   2.2,
+		Repetitions:  2,
+		Lapses:       1,
+		LastReviewed: now,
+	}
+
+	known := reviewed.WithStatus(LearningStatusKnown, now)
+	assert.Equal(t, LearningStatusKnown, known.Status)
+	assert.True(t, known.Due.IsZero())
+	assert.Equal(t, 6.0, known.Stability)
+
+	reset := reviewed.WithStatus(LearningStatusNew, now)
+	assert.Equal(t, LearningStatusNew, reset.Status)
+	assert.Equal(t, DefaultDifficulty, reset.Difficulty)
+	assert.Equal(t, 0, reset.Repetitions)
+	assert.Equal(t, 1, reset.Lapses)
+	assert.True(t, reset.Due.IsZero())
+
+	learning := NewLearningState(reviewed.WordID).WithStatus(LearningStatusLearning, now)
+	assert.Equal(t, now, learning.Due)
+}
+
+func TestLearningStatusIsKnown(t *testing.T) {
+	want := map[LearningStatus]bool{
+		LearningStatusNew:      false,
+		LearningStatusLearning: false,
+		LearningStatusReview:   false,
+		LearningStatusKnown:    true,
+		LearningStatusIgnored:  true,
+	}
+
+	for status, known := range want {
+		assert.Equal(t, known, status.IsKnown(), string(status))
+	}
+}
+
+func TestParseGrade(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Grade
+		wantErr bool
+	}{
+		{input: "1", want: GradeAgain},
+		{input: "hard", want: GradeHard},
+		{input: "3", want: GradeGood},
+		{input: "easy", want: GradeEasy},
+		{input: "5", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseGrade(tt.input)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLearningStateEnqueue(t *testing.T) {
+	due := time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		state      LearningState
+		wantStatus LearningStatus
+		wantOK     bool
+	}{
+		{name: "new", state: NewLearningState(uuid.New()), wantStatus: LearningStatusLearning, wantOK: true},
+		{name: "learning", state: LearningState{Status: LearningStatusLearning}, wantStatus: LearningStatusLearning, wantOK: false},
+		{name: "review", state: LearningState{Status: LearningStatusReview}, wantStatus: LearningStatusReview, wantOK: false},
+		{name: "ignored", state: LearningState{Status: LearningStatusIgnored}, wantStatus: LearningStatusIgnored, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.state.Enqueue(due)
+
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantStatus, got.Status)
+
+			if ok {
+				assert.True(t, got.IsDue(due))
+			}
+		})
+	}
+}
+
+func TestLearningStateToggleKnown(t *testing.T) {
+	now := time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC)
+	lastReviewed := now.Add(-48 * time.Hour)
+
+	tests := []struct {
+		name       string
+		state      LearningState
+		wantStatus LearningStatus
+		wantDue    time.Time
+	}{
+		{
+			name:       "new word",
+			state:      NewLearningState(uuid.New()),
+			wantStatus: LearningStatusKnown,
+		},
+		{
+			name:       "word in review",
+			state:      LearningState{Status: LearningStatusReview, Due: now.Add(96 * time.Hour), Stability: 6, LastReviewed: lastReviewed},
+			wantStatus: LearningStatusKnown,
+		},
+		{
+			name:       "known word that was in review",
+			state:      LearningState{Status: LearningStatusKnown, Stability: 6, Difficulty: 2.2, Repetitions: 2, LastReviewed: lastReviewed},
+			wantStatus: LearningStatusReview,
+			wantDue:    lastReviewed.Add(6 * 24 * time.Hour),
+		},
+		{
+			name:       "known word that was forgotten",
+			state:      LearningState{Status: LearningStatusKnown, Lapses: 1, LastReviewed: lastReviewed},
+			wantStatus: LearningStatusLearning,
+			wantDue:    lastReviewed.Add(RelearningDelay),
+		},
+		{
+			name:       "known word that was never reviewed",
+			state:      LearningState{Status: LearningStatusKnown, Difficulty: DefaultDifficulty},
+			wantStatus: LearningStatusNew,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.state.ToggleKnown(now)
+
+			assert.Equal(t, test.wantStatus, got.Status)
+			assert.Equal(t, test.wantDue, got.Due)
+			assert.Equal(t, test.state.Stability, got.Stability)
+			assert.Equal(t, test.state.Repetitions, got.Repetitions)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// DefaultPaceWindow is the number of days over which the learning pace is
+// averaged.
+const DefaultPaceWindow = 28
+
+// DailyProgress is the number of words that became known at the time given by
+// Day, and the number that stopped being known, according to the actions that
+// have not been undone. NewProgress groups them by the day Day falls on in its
+// own time zone.
+type DailyProgress struct {
+	Day       time.Time
+	Learned   int
+	Forgotten int
+}
+
+// ProgressPoint is the progress during a day or a week starting at Start, with
+// the number of words known at its end.
+type ProgressPoint struct {
+	Start     time.Time
+	Learned   int
+	Forgotten int
+	Known     int
+}
+
+// Progress is the number of known words over time, by day and by week, from
+// the first recorded change of status until today. Pace is the average number
+// of words learned per day over the last PaceWindow days, net of those
+// forgotten.
+type Progress struct {
+	Known      int
+	Days       []ProgressPoint
+	Weeks      []ProgressPoint
+	Pace       float64
+	PaceWindow int
+}
+
+// Projection estimates when a text will reach the target coverage if words
+// keep being learned at the current pace, in order of their frequency in the
+// text. Date is zero if the target is out of reach or nothing is being
+// learned.
+type Projection struct {
+	Target        float64
+	Coverage      float64
+	LemmasToLearn int
+	Reached       bool
+	Reachable     bool
+	Days          int
+	Date          time.Time
+}
+
+// NewProgress spreads the known words over the days in which they were
+// learned, which must be in order. Days are taken in the location of now. Words that were known before their status
+// was recorded, such as those migrated from earlier versions, are counted as
+// known from the start.
+func NewProgress(known int, days []DailyProgress, now time.Time, window int) Progress {
+	if window <= 0 {
+		window = DefaultPaceWindow
+	}
+
+	progress := Progress{
+		Known:      known,
+		Days:       []ProgressPoint{},
+		Weeks:      []ProgressPoint{},
+		PaceWindow: window,
+	}
+
+	if len(days) == 0 {
+		return progress
+	}
+
+	today := startOfDay(now)
+
+	byDay := make(map[time.Time]DailyProgress, len(days))
+	baseline := known
+
+	for _, day := range days {
+		start := startOfDay(day.Day.In(now.Location()))
+		previous := byDay[start]
+
+		byDay[start] = DailyProgress{
+			Day:       start,
+			Learned:   previous.Learned + day.Learned,
+			Forgotten: previous.Forgotten + day.Forgotten,
+		}
+		baseline -= day.Learned - day.Forgotten
+	}
+
+	cumulative := baseline
+	paceStart := today.AddDate(0, 0, -window+1)
+	learnedInWindow := 0
+
+	for day := startOfDay(days[0].Day.In(now.Location())); !day.After(today); day = day.AddDate(0, 0, 1) {
+		change := byDay[day]
+		cumulative += change.Learned - change.Forgotten
+
+		progress.Days = append(progress.Days, ProgressPoint{
+			Start:     day,
+			Learned:   change.Learned,
+			Forgotten: change.Forgotten,
+			Known:     cumulative,
+		})
+
+		if !day.Before(paceStart) {
+			learnedInWindow += change.Learned - change.Forgotten
+		}
+
+		week := startOfWeek(day)
+
+		if len(progress.Weeks) == 0 || !progress.Weeks[len(progress.Weeks)-1].Start.Equal(week) {
+			progress.Weeks = append(progress.Weeks, ProgressPoint{Start: week})
+		}
+
+		last := &progress.Weeks[len(progress.Weeks)-1]
+		last.Learned += change.Learned
+		last.Forgotten += change.Forgotten
+		last.Known = cumulative
+	}
+
+	progress.Pace = max(0, float64(learnedInWindow)/float64(window))
+
+	return progress
+}
+
+// Project estimates when the text whose coverage curve is given reaches the
+// target coverage at the current pace.
+func (p Progress) Project(curve CoverageCurve, target float64, now time.Time) Projection {
+	projection := Projection{
+		Target:   target,
+		Coverage: curve.Coverage(),
+	}
+
+	if projection.Coverage >= target {
+		projection.Reached = true
+		projection.Reachable = true
+		return projection
+	}
+
+	for _, step := range curve.Steps {
+		if step.Coverage >= target {
+			projection.LemmasToLearn = step.LemmaCount
+			projection.Reachable = true
+			break
+		}
+	}
+
+	if !projection.Reachable || p.Pace <= 0 {
+		return projection
+	}
+
+	projection.Days = int(math.Ceil(float64(projection.LemmasToLearn) / p.Pace))
+	projection.Date = startOfDay(now).AddDate(0, 0, projection.Days)
+
+	return projection
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// startOfWeek returns the Monday of the week the day is in.
+func startOfWeek(day time.Time) time.Time {
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
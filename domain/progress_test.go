@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProgress(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2025, 9, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	// 2025-09-05 is a Friday, 2025-09-08 a Monday.
+	days := []DailyProgress{
+		{Day: day(5).Add(9 * time.Hour), Learned: 10},
+		{Day: day(6), Learned: 5, Forgotten: 1},
+		{Day: day(9), Learned: 8, Forgotten: 2},
+	}
+
+	got := NewProgress(120, days, day(10).Add(18*time.Hour), 7)
+
+	assert.Equal(t, 120, got.Known)
+	assert.Equal(t, []ProgressPoint{
+		{Start: day(5), Learned: 10, Known: 110},
+		{Start: day(6), Learned: 5, Forgotten: 1, Known: 114},
+		{Start: day(7), Known: 114},
+		{Start: day(8), Known: 114},
+		{Start: day(9), Learned: 8, Forgotten: 2, Known: 120},
+		{Start: day(10), Known: 120},
+	}, got.Days)
+	assert.Equal(t, []ProgressPoint{
+		{Start: day(1), Learned: 15, Forgotten: 1, Known: 114},
+		{Start: day(8), Learned: 8, Forgotten: 2, Known: 120},
+	}, got.Weeks)
+	assert.InDelta(t, 20.0/7, got.Pace, 0.0001)
+}
+
+func TestNewProgressInLocation(t *testing.T) {
+	amsterdam := time.FixedZone("CEST", 2*60*60)
+
+	// 22:30 UTC on the 5th is already the 6th in Amsterdam.
+	days := []DailyProgress{
+		{Day: time.Date(2025, 9, 5, 8, 0, 0, 0, time.UTC), Learned: 3},
+		{Day: time.Date(2025, 9, 5, 22, 30, 0, 0, time.UTC), Learned: 4},
+	}
+
+	got := NewProgress(7, days, time.Date(2025, 9, 6, 12, 0, 0, 0, amsterdam), 7)
+
+	assert.Equal(t, []ProgressPoint{
+		{Start: time.Date(2025, 9, 5, 0, 0, 0, 0, amsterdam), Learned: 3, Known: 3},
+		{Start: time.Date(2025, 9, 6, 0, 0, 0, 0, amsterdam), Learned: 4, Known: 7},
+	}, got.Days)
+}
+
+func TestNewProgressWithoutHistory(t *testing.T) {
+	got := NewProgress(42, nil, time.Now(), 0)
+
+	assert.Equal(t, 42, got.Known)
+	assert.Empty(t, got.Days)
+	assert.Empty(t, got.Weeks)
+	assert.Zero(t, got.Pace)
+	assert.Equal(t, DefaultPaceWindow, got.PaceWindow)
+}
+
+func TestProgressProject(t *testing.T) {
+	now := time.Date(2025, 9, 10, 18, 0, 0, 0, time.UTC)
+	curve := NewCoverageCurve([]WordInWork{
+		{Word: Word{Known: true}, Count: 80},
+		{Count: 10},
+		{Count: 6},
+		{Count: 4},
+	}, nil)
+
+	tests := []struct {
+		name   string
+		pace   float64
+		target float64
+		want   Projection
+	}{
+		{
+			name:   "already reached",
+			pace:   1,
+			target: 80,
+			want:   Projection{Target: 80, Coverage: 80, Reached: true, Reachable: true},
+		},
+		{
+			name:   "reachable at the current pace",
+			pace:   0.5,
+			target: 95,
+			want: Projection{
+				Target:        95,
+				Coverage:      80,
+				LemmasToLearn: 2,
+				Reachable:     true,
+				Days:          4,
+				Date:          time.Date(2025, 9, 14, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:   "nothing being learned",
+			pace:   0,
+			target: 95,
+			want:   Projection{Target: 95, Coverage: 80, LemmasToLearn: 2, Reachable: true},
+		},
+		{
+			name:   "out of reach",
+			pace:   1,
+			target: 101,
+			want:   Projection{Target: 101, Coverage: 80},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Progress{Pace: test.pace}.Project(curve, test.target, now)
+
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
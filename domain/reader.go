@@ -0,0 +1,93 @@
+package domain
+
+// ReaderUnit determines how the reader divides a work into pages: into a
+// fixed number of sentences, or into sections.
+type ReaderUnit string
+
+const (
+	ReaderUnitSentence ReaderUnit = "sentence"
+	ReaderUnitSection  ReaderUnit = "section"
+)
+
+const DefaultSentencesPerPage = 10
+
+// ReaderPage is a page of a work in the reader. FirstWordIndex is the index of
+// its first word, which is what is stored as the reading position.
+type ReaderPage struct {
+	Number         int
+	Heading        string
+	FirstWordIndex int
+	Sentences      [][]WorkWord
+}
+
+// Paginate divides the words of the work, which must be in order, into pages
+// of the given number of sentences or, for sections, into the sections at the
+// given level (see EditionOptions). A work without sections is paginated by
+// sentence.
+func Paginate(workWords []WorkWord, sections []Section, unit ReaderUnit, sentencesPerPage, level int) []ReaderPage {
+	if sentencesPerPage <= 0 {
+		sentencesPerPage = DefaultSentencesPerPage
+	}
+
+	sentenceCount := 0
+	previousSentence := -1
+
+	pageOf := func(ww WorkWord) int {
+		if ww.SentenceIndex != previousSentence {
+			previousSentence = ww.SentenceIndex
+			sentenceCount++
+		}
+
+		return (sentenceCount - 1) / sentencesPerPage
+	}
+	headings := map[int]string{}
+
+	if unit == ReaderUnitSection {
+		if selected := editionSections(sections, level); len(selected) > 0 {
+			pageOf, headings = pageBySection(selected)
+		}
+	}
+
+	pages := []ReaderPage{}
+
+	var page *ReaderPage
+	currentPage, currentSentence := 0, -1
+
+	for _, ww := range workWords {
+		if p := pageOf(ww); page == nil || p != currentPage {
+			pages = append(pages, ReaderPage{
+				Number:         len(pages) + 1,
+				Heading:        headings[p],
+				FirstWordIndex: ww.WordIndex,
+			})
+			page = &pages[len(pages)-1]
+			currentPage, currentSentence = p, -1
+		}
+
+		if ww.SentenceIndex != currentSentence {
+			page.Sentences = append(page.Sentences, []WorkWord{})
+			currentSentence = ww.SentenceIndex
+		}
+
+		last := len(page.Sentences) - 1
+		page.Sentences[last] = append(page.Sentences[last], ww)
+	}
+
+	return pages
+}
+
+// PageOfWord returns the number of the page that contains the word with the
+// given index, or the first page if there is none.
+func PageOfWord(pages []ReaderPage, wordIndex int) int {
+	number := 1
+
+	for _, page := range pages {
+		if page.FirstWordIndex > wordIndex {
+			break
+		}
+
+		number = page.Number
+	}
+
+	return number
+}
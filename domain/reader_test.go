@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginate(t *testing.T) {
+	// Five sentences of two words each.
+	workWords := []WorkWord{}
+
+	for i := 1; i <= 10; i++ {
+		workWords = append(workWords, WorkWord{WordIndex: i, SentenceIndex: (i + 1) / 2})
+	}
+
+	book := Section{ID: uuid.New(), Position: 1, Depth: 0, Citation: "1", FirstWordIndex: 1, LastWordIndex: 10}
+	sections := []Section{
+		book,
+		{ID: uuid.New(), ParentID: book.ID, Position: 2, Depth: 1, Citation: "1.1", FirstWordIndex: 1, LastWordIndex: 5},
+		{ID: uuid.New(), ParentID: book.ID, Position: 3, Depth: 1, Citation: "1.2", FirstWordIndex: 6, LastWordIndex: 10},
+	}
+
+	tests := []struct {
+		name             string
+		unit             ReaderUnit
+		sentencesPerPage int
+		level            int
+		sections         []Section
+		wantHeadings     []string
+		wantFirst        []int
+		wantSentences    []int
+	}{
+		{
+			name:             "by sentence",
+			unit:             ReaderUnitSentence,
+			sentencesPerPage: 2,
+			sections:         sections,
+			wantHeadings:     []string{"", "", ""},
+			wantFirst:        []int{1, 5, 9},
+			wantSentences:    []int{2, 2, 1},
+		},
+		{
+			name:          "by innermost section",
+			unit:          ReaderUnitSection,
+			sections:      sections,
+			wantHeadings:  []string{"1.1", "1.2"},
+			wantFirst:     []int{1, 6},
+			wantSentences: []int{3, 3},
+		},
+		{
+			name:          "by top-level section",
+			unit:          ReaderUnitSection,
+			level:         1,
+			sections:      sections,
+			wantHeadings:  []string{"1"},
+			wantFirst:     []int{1},
+			wantSentences: []int{5},
+		},
+		{
+			name:          "by section without sections",
+			unit:          ReaderUnitSection,
+			wantHeadings:  []string{""},
+			wantFirst:     []int{1},
+			wantSentences: []int{5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages := Paginate(workWords, tt.sections, tt.unit, tt.sentencesPerPage, tt.level)
+
+			headings, first, sentences := []string{}, []int{}, []int{}
+
+			for i, page := range pages {
+				assert.Equal(t, i+1, page.Number)
+
+				headings = append(headings, page.Heading)
+				first = append(first, page.FirstWordIndex)
+				sentences = append(sentences, len(page.Sentences))
+			}
+
+			assert.Equal(t, tt.wantHeadings, headings)
+			assert.Equal(t, tt.wantFirst, first)
+			assert.Equal(t, tt.wantSentences, sentences)
+		})
+	}
+}
+
+func TestPageOfWord(t *testing.T) {
+	pages := []ReaderPage{
+		{Number: 1, FirstWordIndex: 1},
+		{Number: 2, FirstWordIndex: 20},
+		{Number: 3, FirstWordIndex: 40},
+	}
+
+	tests := []struct {
+		wordIndex int
+		want      int
+	}{
+		{wordIndex: 0, want: 1},
+		{wordIndex: 1, want: 1},
+		{wordIndex: 19, want: 1},
+		{wordIndex: 20, want: 2},
+		{wordIndex: 100, want: 3},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, PageOfWord(pages, tt.wordIndex), "word %d", tt.wordIndex)
+	}
+}
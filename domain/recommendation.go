@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+const DefaultMaxNewLemmas = 100
+
+type Recommendation struct {
+	Work          Work
+	NewLemmas     []WordInWork
+	NewTokenCount int
+}
+
+type ReadingPathStep struct {
+	Recommendation
+	LearntLemmaCount int
+}
+
+// NewLemmaCount returns the number of distinct lemmas in the work that are not
+// yet known.
+func (r Recommendation) NewLemmaCount() int {
+	return len(r.NewLemmas)
+}
+
+// Coverage returns the percentage of running tokens in the work that would be
+// known before reading it.
+func (r Recommendation) Coverage() float64 {
+	return percentage(r.Work.TokenCount-r.NewTokenCount, r.Work.TokenCount)
+}
+
+// RankWorks orders all unread works by the number of unknown lemmas that
+// would need to be learnt before reading them; ties are broken by text
+// coverage. The words map should contain every word in the corpus, so that
+// its known status and corpus count can be looked up.
+func RankWorks(works []Work, lemmaCounts map[uuid.UUID]map[uuid.UUID]int, words map[uuid.UUID]WordInWork) []Recommendation {
+	recommendations := []Recommendation{}
+
+	for _, work := range works {
+		if work.Read {
+			continue
+		}
+
+		recommendations = append(recommendations, recommend(work, lemmaCounts[work.ID], words, map[uuid.UUID]bool{}))
+	}
+
+	slices.SortStableFunc(recommendations, compareRecommendations)
+
+	return recommendations
+}
+
+// GetReadingPath orders all unread works so that each next work introduces as
+// few new lemmas as possible, while preferring lemmas that are frequent in
+// the corpus. Works that would add more than maxNewLemmas new lemmas are
+// postponed for as long as there are alternatives. Lemmas introduced by
+// earlier works in the path are considered learnt for the works that follow.
+func GetReadingPath(works []Work, lemmaCounts map[uuid.UUID]map[uuid.UUID]int, words map[uuid.UUID]WordInWork, maxNewLemmas int) []ReadingPathStep {
+	path := []ReadingPathStep{}
+	learnt := map[uuid.UUID]bool{}
+
+	remaining := []Work{}
+	for _, work := range works {
+		if !work.Read {
+			remaining = append(remaining, work)
+		}
+	}
+
+	for len(remaining) > 0 {
+		candidates := []Recommendation{}
+		for _, work := range remaining {
+			candidates = append(candidates, recommend(work, lemmaCounts[work.ID], words, learnt))
+		}
+
+		manageable := slices.DeleteFunc(slices.Clone(candidates), func(r Recommendation) bool {
+			return r.NewLemmaCount() > maxNewLemmas
+		})
+
+		var next Recommendation
+
+		if len(manageable) > 0 {
+			next = slices.MaxFunc(manageable, func(a, b Recommendation) int {
+				if a.NewLemmaCount() == 0 || b.NewLemmaCount() == 0 {
+					return cmp.Compare(b.NewLemmaCount(), a.NewLemmaCount())
+				}
+
+				return cmp.Or(
+					cmp.Compare(averageCorpusCount(a.NewLemmas), averageCorpusCount(b.NewLemmas)),
+					cmp.Compare(b.NewLemmaCount(), a.NewLemmaCount()),
+				)
+			})
+		} else {
+			next = slices.MinFunc(candidates, compareRecommendations)
+		}
+
+		for _, word := range next.NewLemmas {
+			learnt[word.ID] = true
+		}
+
+		path = append(path, ReadingPathStep{
+			Recommendation:   next,
+			LearntLemmaCount: len(learnt),
+		})
+
+		remaining = slices.DeleteFunc(remaining, func(w Work) bool {
+			return w.ID == next.Work.ID
+		})
+	}
+
+	return path
+}
+
+func recommend(work Work, counts map[uuid.UUID]int, words map[uuid.UUID]WordInWork, learnt map[uuid.UUID]bool) Recommendation {
+	recommendation := Recommendation{Work: work, NewLemmas: []WordInWork{}}
+
+	for wordID, count := range counts {
+		word := words[wordID]
+
+		if word.Known || learnt[wordID] {
+			continue
+		}
+
+		recommendation.NewLemmas = append(recommendation.NewLemmas, word)
+		recommendation.NewTokenCount += count
+	}
+
+	slices.SortFunc(recommendation.NewLemmas, func(a, b WordInWork) int {
+		return cmp.Or(b.Count-a.Count, cmp.Compare(a.LemmaRich, b.LemmaRich))
+	})
+
+	return recommendation
+}
+
+func compareRecommendations(a, b Recommendation) int {
+	return cmp.Or(
+		cmp.Compare(a.NewLemmaCount(), b.NewLemmaCount()),
+		cmp.Compare(b.Coverage(), a.Coverage()),
+	)
+}
+
+func averageCorpusCount(words []WordInWork) float64 {
+	if len(words) == 0 {
+		return 0
+	}
+
+	total := 0
+	for _, word := range words {
+		total += word.Count
+	}
+
+	return float64(total) / float64(len(words))
+}
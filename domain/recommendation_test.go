@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecommendations(t *testing.T) {
+	sum := WordInWork{Word: Word{ID: uuid.New(), LemmaRich: "sum, es, esse", Known: true}, Count: 100}
+	et := WordInWork{Word: Word{ID: uuid.New(), LemmaRich: "ĕt, conj. adv."}, Count: 80}
+	arma := WordInWork{Word: Word{ID: uuid.New(), LemmaRich: "arma, orum, n."}, Count: 10}
+	cano := WordInWork{Word: Word{ID: uuid.New(), LemmaRich: "cano, is, ere"}, Count: 2}
+	vir := WordInWork{Word: Word{ID: uuid.New(), LemmaRich: "vir, viri, m."}, Count: 1}
+
+	words := map[uuid.UUID]WordInWork{sum.ID: sum, et.ID: et, arma.ID: arma, cano.ID: cano, vir.ID: vir}
+
+	aeneid := Work{ID: uuid.New(), Title: "Aeneis", TokenCount: 10}
+	gallicWar := Work{ID: uuid.New(), Title: "De bello Gallico", TokenCount: 10}
+	amphitryo := Work{ID: uuid.New(), Title: "Amphitryo", TokenCount: 10}
+	read := Work{ID: uuid.New(), Title: "Already read", Read: true, TokenCount: 10}
+
+	lemmaCounts := map[uuid.UUID]map[uuid.UUID]int{
+		aeneid.ID:    {sum.ID: 4, arma.ID: 3, cano.ID: 2, vir.ID: 1},
+		gallicWar.ID: {sum.ID: 5, et.ID: 5},
+		amphitryo.ID: {sum.ID: 5, cano.ID: 4, vir.ID: 1},
+		read.ID:      {sum.ID: 10},
+	}
+
+	works := []Work{aeneid, gallicWar, amphitryo, read}
+
+	t.Run("rank works", func(t *testing.T) {
+		ranking := RankWorks(works, lemmaCounts, words)
+
+		titles := []string{}
+		for _, recommendation := range ranking {
+			titles = append(titles, recommendation.Work.Title)
+		}
+
+		assert.Equal(t, []string{"De bello Gallico", "Amphitryo", "Aeneis"}, titles)
+		assert.Equal(t, 50.0, ranking[0].Coverage())
+		assert.Equal(t, []WordInWork{et}, ranking[0].NewLemmas)
+	})
+
+	t.Run("reading path", func(t *testing.T) {
+		path := GetReadingPath(works, lemmaCounts, words, 2)
+
+		titles := []string{}
+		newLemmaCounts := []int{}
+		for _, step := range path {
+			titles = append(titles, step.Work.Title)
+			newLemmaCounts = append(newLemmaCounts, step.NewLemmaCount())
+		}
+
+		assert.Equal(t, []string{"De bello Gallico", "Amphitryo", "Aeneis"}, titles)
+		assert.Equal(t, []int{1, 2, 1}, newLemmaCounts)
+		assert.Equal(t, 4, path[2].LearntLemmaCount)
+	})
+
+	t.Run("reading path prefers frequent lemmas", func(t *testing.T) {
+		path := GetReadingPath([]Work{amphitryo, gallicWar}, lemmaCounts, words, 2)
+
+		assert.Equal(t, "De bello Gallico", path[0].Work.Title)
+	})
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+// mtldThreshold is the type/token ratio at which MTLD considers a factor
+// complete, as proposed by McCarthy and Jarvis (2010).
+const mtldThreshold = 0.72
+
+type LexicalRichness struct {
+	TokenCount           int
+	TypeCount            int
+	TypeTokenRatio       float64
+	HapaxLegomena        int
+	DisLegomena          int
+	MTLD                 float64
+	YulesK               float64
+	MeanFrequencyInLASLA float64
+}
+
+// NewLexicalRichness calculates vocabulary statistics for a stream of lemmas in
+// text order. The LASLA frequencies are used to determine how rare the lemmas
+// are on average; lemmas missing from the map do not occur in LASLA and are
+// left out of the mean.
+func NewLexicalRichness(lemmas []uuid.UUID, frequenciesInLASLA map[uuid.UUID]int) LexicalRichness {
+	richness := LexicalRichness{TokenCount: len(lemmas)}
+
+	if len(lemmas) == 0 {
+		return richness
+	}
+
+	counts := map[uuid.UUID]int{}
+	for _, lemma := range lemmas {
+		counts[lemma]++
+	}
+
+	richness.TypeCount = len(counts)
+	richness.TypeTokenRatio = float64(richness.TypeCount) / float64(richness.TokenCount)
+
+	// Yule's K is based on the frequency spectrum: how many lemmas occur
+	// once, twice, etc.
+	spectrum := map[int]int{}
+	frequencySum, frequencyCount := 0, 0
+
+	for lemma, count := range counts {
+		spectrum[count]++
+
+		if frequency, ok := frequenciesInLASLA[lemma]; ok {
+			frequencySum += frequency
+			frequencyCount++
+		}
+	}
+
+	richness.HapaxLegomena = spectrum[1]
+	richness.DisLegomena = spectrum[2]
+
+	if frequencyCount > 0 {
+		richness.MeanFrequencyInLASLA = float64(frequencySum) / float64(frequencyCount)
+	}
+
+	sum := 0.0
+	for m, v := range spectrum {
+		sum += float64(m * m * v)
+	}
+
+	n := float64(richness.TokenCount)
+	richness.YulesK = 10_000 * (sum - n) / (n * n)
+
+	reversed := make([]uuid.UUID, len(lemmas))
+	for i, lemma := range lemmas {
+		reversed[len(lemmas)-1-i] = lemma
+	}
+
+	richness.MTLD = (mtldPass(lemmas) + mtldPass(reversed)) / 2
+
+	return richness
+}
+
+// mtldPass divides the text into factors: stretches over which the type/token
+// ratio stays above the threshold. The remainder is counted as a partial
+// factor. The result is the mean length of a factor.
+func mtldPass(lemmas []uuid.UUID) float64 {
+	factors := 0.0
+	types := map[uuid.UUID]bool{}
+	tokens := 0
+
+	for _, lemma := range lemmas {
+		types[lemma] = true
+		tokens++
+
+		if float64(len(types))/float64(tokens) <= mtldThreshold {
+			factors++
+			types = map[uuid.UUID]bool{}
+			tokens = 0
+		}
+	}
+
+	if tokens > 0 {
+		ttr := float64(len(types)) / float64(tokens)
+		factors += (1 - ttr) / (1 - mtldThreshold)
+	}
+
+	if factors == 0 {
+		return float64(len(lemmas))
+	}
+
+	return float64(len(lemmas)) / factors
+}
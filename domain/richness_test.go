@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLexicalRichness(t *testing.T) {
+	arma, vir, cano, et := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	frequencies := map[uuid.UUID]int{arma: 10, vir: 20, cano: 30}
+
+	tests := []struct {
+		name     string
+		lemmas   []uuid.UUID
+		expected LexicalRichness
+	}{
+		{
+			name:   "empty text",
+			lemmas: []uuid.UUID{},
+			expected: LexicalRichness{
+				TokenCount: 0,
+			},
+		},
+		{
+			name:   "only unique lemmas",
+			lemmas: []uuid.UUID{arma, vir, cano},
+			expected: LexicalRichness{
+				TokenCount:           3,
+				TypeCount:            3,
+				TypeTokenRatio:       1,
+				HapaxLegomena:        3,
+				DisLegomena:          0,
+				MTLD:                 3,
+				YulesK:               0,
+				MeanFrequencyInLASLA: 20,
+			},
+		},
+		{
+			name:   "repeated lemmas",
+			lemmas: []uuid.UUID{et, et, arma, et, vir, vir},
+			expected: LexicalRichness{
+				TokenCount:           6,
+				TypeCount:            3,
+				TypeTokenRatio:       0.5,
+				HapaxLegomena:        1,
+				DisLegomena:          1,
+				MTLD:                 (6/(1+0.25/0.28) + 6.0/2) / 2,
+				YulesK:               10_000 * (9 + 1 + 4 - 6) / 36.0,
+				MeanFrequencyInLASLA: 15,
+			},
+		},
+		{
+			name:   "no lemmas in LASLA",
+			lemmas: []uuid.UUID{et, et},
+			expected: LexicalRichness{
+				TokenCount:           2,
+				TypeCount:            1,
+				TypeTokenRatio:       0.5,
+				HapaxLegomena:        0,
+				DisLegomena:          1,
+				MTLD:                 2,
+				YulesK:               10_000 * (4 - 2) / 4.0,
+				MeanFrequencyInLASLA: 0,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := NewLexicalRichness(test.lemmas, frequencies)
+
+			assert.Equal(t, test.expected.TokenCount, output.TokenCount)
+			assert.Equal(t, test.expected.TypeCount, output.TypeCount)
+			assert.InDelta(t, test.expected.TypeTokenRatio, output.TypeTokenRatio, 0.0001)
+			assert.Equal(t, test.expected.HapaxLegomena, output.HapaxLegomena)
+			assert.Equal(t, test.expected.DisLegomena, output.DisLegomena)
+			assert.InDelta(t, test.expected.MTLD, output.MTLD, 0.0001)
+			assert.InDelta(t, test.expected.YulesK, output.YulesK, 0.0001)
+			assert.InDelta(t, test.expected.MeanFrequencyInLASLA, output.MeanFrequencyInLASLA, 0.0001)
+		})
+	}
+}
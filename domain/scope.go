@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type ScopeKind string
+
+const (
+	ScopeKindWork       ScopeKind = "work"
+	ScopeKindAuthor     ScopeKind = "author"
+	ScopeKindCollection ScopeKind = "collection"
+	ScopeKindSection    ScopeKind = "section"
+	ScopeKindCorpus     ScopeKind = "corpus"
+)
+
+// Scope selects the part of the corpus a word list is compiled from. The ID
+// is that of the work, author, collection or section and is empty for the
+// corpus as a whole. Works further restricts the scope to the works matching the filter.
+type Scope struct {
+	Kind  ScopeKind
+	ID    uuid.UUID
+	Works WorkFilter
+}
+
+// ParseScope parses scopes written as "work:<id>", "author:<id>",
+// "collection:<id>", "section:<id>" or "corpus".
+func ParseScope(s string) (Scope, error) {
+	kind, id, _ := strings.Cut(s, ":")
+
+	switch ScopeKind(kind) {
+	case ScopeKindCorpus:
+		if id != "" {
+			return Scope{}, fmt.Errorf("invalid scope %q", s)
+		}
+
+		return Scope{Kind: ScopeKindCorpus}, nil
+	case ScopeKindWork, ScopeKindAuthor, ScopeKindCollection, ScopeKindSection:
+		parsedID, err := uuid.Parse(id)
+		if err != nil {
+			return Scope{}, fmt.Errorf("invalid scope %q: %w", s, err)
+		}
+
+		return Scope{Kind: ScopeKind(kind), ID: parsedID}, nil
+	default:
+		return Scope{}, fmt.Errorf("invalid scope %q", s)
+	}
+}
+
+func (s Scope) String() string {
+	if s.Kind == ScopeKindCorpus {
+		return string(s.Kind)
+	}
+
+	return fmt.Sprintf("%s:%s", s.Kind, s.ID)
+}
+
+// SpansWorks reports whether the scope can contain more than one work.
+func (s Scope) SpansWorks() bool {
+	return s.Kind != ScopeKindWork && s.Kind != ScopeKindSection
+}
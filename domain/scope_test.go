@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScope(t *testing.T) {
+	id := uuid.MustParse("7f8c2a36-5d1e-4c1b-9a3e-2b6f0d4e8a10")
+
+	tests := []struct {
+		name    string
+		input   string
+		want    Scope
+		wantErr bool
+	}{
+		{name: "work", input: "work:" + id.String(), want: Scope{Kind: ScopeKindWork, ID: id}},
+		{name: "author", input: "author:" + id.String(), want: Scope{Kind: ScopeKindAuthor, ID: id}},
+		{name: "collection", input: "collection:" + id.String(), want: Scope{Kind: ScopeKindCollection, ID: id}},
+		{name: "section", input: "section:" + id.String(), want: Scope{Kind: ScopeKindSection, ID: id}},
+		{name: "corpus", input: "corpus", want: Scope{Kind: ScopeKindCorpus}},
+		{name: "corpus with id", input: "corpus:" + id.String(), wantErr: true},
+		{name: "invalid id", input: "work:caesar", wantErr: true},
+		{name: "unknown kind", input: "shelf:" + id.String(), wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseScope(tt.input)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.input, got.String())
+		})
+	}
+}
+
+func TestScopeSpansWorks(t *testing.T) {
+	tests := []struct {
+		kind ScopeKind
+		want bool
+	}{
+		{kind: ScopeKindWork, want: false},
+		{kind: ScopeKindSection, want: false},
+		{kind: ScopeKindAuthor, want: true},
+		{kind: ScopeKindCollection, want: true},
+		{kind: ScopeKindCorpus, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			assert.Equal(t, tt.want, Scope{Kind: tt.kind}.SpansWorks())
+		})
+	}
+}
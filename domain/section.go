@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Section is a node in the section tree of a work, such as a book, chapter,
+// poem or line. Sections are ordered by Position in document order, parents
+// before their children, and cover the words from FirstWordIndex up to and
+// including LastWordIndex. The Citation joins the labels of the section and
+// its ancestors, e.g. "4.2" for chapter 2 of book 4.
+type Section struct {
+	ID             uuid.UUID
+	WorkID         uuid.UUID
+	ParentID       uuid.UUID
+	Position       int
+	Depth          int
+	Kind           string
+	Label          string
+	Citation       string
+	FirstWordIndex int
+	LastWordIndex  int
+	Created        time.Time
+	Modified       time.Time
+}
+
+// Extend widens the word range of the section so that it includes the given
+// range.
+func (s *Section) Extend(first, last int) {
+	if s.FirstWordIndex == 0 || first < s.FirstWordIndex {
+		s.FirstWordIndex = first
+	}
+
+	s.LastWordIndex = max(s.LastWordIndex, last)
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	MinPasswordLength = 8
+	MaxUserNameLength = 64
+
+	// SessionDuration is how long a user stays logged in.
+	SessionDuration = 30 * 24 * time.Hour
+)
+
+// User is an account with its own learning state. Works and the corpus are
+// shared by all users. A user without a password hash cannot log in; see
+// UserRepository.Register.
+type User struct {
+	ID           uuid.UUID
+	Name         string
+	PasswordHash string
+	Created      time.Time
+}
+
+// Session is a login of a user. Only the hash of its token is stored, so that
+// the sessions cannot be taken over by whoever can read the database.
+type Session struct {
+	TokenHash string
+	UserID    uuid.UUID
+	Created   time.Time
+	Expires   time.Time
+}
+
+// NewUser checks the name and password of a new account and hashes the
+// password. Names are trimmed and may not contain spaces.
+func NewUser(name, password string, now time.Time) (User, error) {
+	name = strings.TrimSpace(name)
+
+	if name == "" || len(name) > MaxUserNameLength || strings.ContainsFunc(name, unicode.IsSpace) {
+		return User{}, fmt.Errorf("invalid user name %q", name)
+	}
+
+	if len(password) < MinPasswordLength {
+		return User{}, fmt.Errorf("password must be at least %d characters long", MinPasswordLength)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return User{}, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	return User{
+		ID:           uuid.New(),
+		Name:         name,
+		PasswordHash: string(hash),
+		Created:      now,
+	}, nil
+}
+
+// CheckPassword reports whether the password is that of the user.
+func (u User) CheckPassword(password string) bool {
+	if u.PasswordHash == "" {
+		return false
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil
+}
+
+// NewSession starts a session for the user. The token is to be given to the
+// user, and is not kept.
+func NewSession(userID uuid.UUID, now time.Time) (Session, string, error) {
+	b := make([]byte, 32)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return Session{}, "", fmt.Errorf("failed to generate session token: %w", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	return Session{
+		TokenHash: HashSessionToken(token),
+		UserID:    userID,
+		Created:   now,
+		Expires:   now.Add(SessionDuration),
+	}, token, nil
+}
+
+func HashSessionToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(hash[:])
+}
+
+type userKey struct{}
+
+// ContextWithUser returns a context for requests made by the user. The
+// repositories read and write the learning state of the user in the context.
+func ContextWithUser(ctx context.Context, user User) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFromContext returns the user the request is made by, if any.
+func UserFromContext(ctx context.Context) (User, bool) {
+	user, ok := ctx.Value(userKey{}).(User)
+
+	return user, ok
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUser(t *testing.T) {
+	now := time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		userName string
+		password string
+		wantName string
+		wantErr  bool
+	}{
+		{name: "valid", userName: " catullus ", password: "passer deliciae", wantName: "catullus"},
+		{name: "empty name", userName: "  ", password: "passer deliciae", wantErr: true},
+		{name: "name with spaces", userName: "gaius valerius", password: "passer deliciae", wantErr: true},
+		{name: "name too long", userName: strings.Repeat("a", MaxUserNameLength+1), password: "passer deliciae", wantErr: true},
+		{name: "password too short", userName: "catullus", password: "passer", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := NewUser(test.userName, test.password, now)
+
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantName, got.Name)
+			assert.Equal(t, now, got.Created)
+			assert.NotEqual(t, test.password, got.PasswordHash)
+			assert.True(t, got.CheckPassword(test.password))
+			assert.False(t, got.CheckPassword("odi et amo"))
+		})
+	}
+}
+
+func TestUserWithoutPasswordCannotLogIn(t *testing.T) {
+	assert.False(t, User{Name: "owner"}.CheckPassword(""))
+}
+
+func TestNewSession(t *testing.T) {
+	now := time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC)
+	user, err := NewUser("catullus", "passer deliciae", now)
+	assert.NoError(t, err)
+
+	session, token, err := NewSession(user.ID, now)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, HashSessionToken(token), session.TokenHash)
+	assert.NotEqual(t, token, session.TokenHash)
+	assert.Equal(t, user.ID, session.UserID)
+	assert.Equal(t, now.Add(SessionDuration), session.Expires)
+
+	_, other, err := NewSession(user.ID, now)
+	assert.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestUserFromContext(t *testing.T) {
+	_, ok := UserFromContext(context.Background())
+	assert.False(t, ok)
+
+	user := User{Name: "catullus"}
+	got, ok := UserFromContext(ContextWithUser(context.Background(), user))
+
+	assert.True(t, ok)
+	assert.Equal(t, user, got)
+}
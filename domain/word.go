@@ -20,5 +20,18 @@ type Word struct {
 
 type WordInWork struct {
 	Word
-	Count int
+	Count      int
+	Dispersion Dispersion
+}
+
+// Dispersion describes how evenly the occurrences of a lemma are spread over
+// the works in a list. Range is the number of works the lemma appears in. DP
+// (Gries' deviation of proportions) ranges from 0 (perfectly even) to almost 1
+// (all occurrences in a single small work); Juilland's D ranges from 0 to 1
+// (perfectly even). AdjustedFrequency is the count multiplied by Juilland's D.
+type Dispersion struct {
+	Range             int
+	DP                float64
+	JuillandD         float64
+	AdjustedFrequency float64
 }
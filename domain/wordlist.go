@@ -0,0 +1,70 @@
+package domain
+
+const DefaultPageSize = 100
+
+type WordSort string
+
+const (
+	WordSortCount             WordSort = "count"
+	WordSortAlphabetical      WordSort = "alphabetical"
+	WordSortLASLA             WordSort = "lasla"
+	WordSortFirstOccurrence   WordSort = "first"
+	WordSortRange             WordSort = "range"
+	WordSortDP                WordSort = "dp"
+	WordSortJuillandD         WordSort = "d"
+	WordSortAdjustedFrequency WordSort = "adjusted"
+)
+
+type SortOrder string
+
+const (
+	SortOrderAscending  SortOrder = "asc"
+	SortOrderDescending SortOrder = "desc"
+)
+
+type PartOfSpeech struct {
+	Tag  string
+	Name string
+}
+
+// PartsOfSpeech lists the word classes that Collatinus encodes in the first
+// character of its tags.
+var PartsOfSpeech = []PartOfSpeech{
+	{Tag: "n", Name: "Noun"},
+	{Tag: "v", Name: "Verb"},
+	{Tag: "a", Name: "Adjective"},
+	{Tag: "d", Name: "Adverb"},
+	{Tag: "p", Name: "Pronoun"},
+	{Tag: "r", Name: "Preposition"},
+	{Tag: "c", Name: "Conjunction"},
+	{Tag: "i", Name: "Interjection"},
+	{Tag: "m", Name: "Numeral"},
+}
+
+// WordListFilter describes which page of a word list to retrieve and how to
+// sort and filter it. Zero values mean the corresponding filter is not
+// applied; a PageSize of zero returns all words at once. The LASLA frequency
+// bounds are nil when not set, so that zero can be used as a bound; the
+// maximum is exclusive, keeping only lemmas below that frequency.
+type WordListFilter struct {
+	Sort                WordSort
+	Order               SortOrder
+	Page                int
+	PageSize            int
+	MinCount            int
+	MaxCount            int
+	MinFrequencyInLASLA *int
+	MaxFrequencyInLASLA *int
+	PartOfSpeech        string
+	Known               *bool
+	Search              string
+}
+
+// PageCount returns the number of pages needed to show total words.
+func (f WordListFilter) PageCount(total int) int {
+	if f.PageSize == 0 || total == 0 {
+		return 1
+	}
+
+	return (total + f.PageSize - 1) / f.PageSize
+}
@@ -6,11 +6,32 @@ import (
 	"github.com/google/uuid"
 )
 
+type WorkSort string
+
+const (
+	WorkSortAuthor   WorkSort = "author"
+	WorkSortTitle    WorkSort = "title"
+	WorkSortCoverage WorkSort = "coverage"
+)
+
+// Work is an uploaded text. ReadingPosition is the index of the first word on
+// the page that was last opened in the reader.
 type Work struct {
-	ID       uuid.UUID
-	Title    string
-	Author   Author
-	Created  time.Time
-	Modified time.Time
-	Deleted  time.Time
+	ID     uuid.UUID
+	Title  string
+	Author Author
+	WorkMetadata
+	Read            bool
+	ReadingPosition int
+	TokenCount      int
+	KnownTokenCount int
+	Created         time.Time
+	Modified        time.Time
+	Deleted         time.Time
+}
+
+// Coverage returns the percentage of running tokens in the work whose lemma
+// is marked as known.
+func (w Work) Coverage() float64 {
+	return percentage(w.KnownTokenCount, w.TokenCount)
 }
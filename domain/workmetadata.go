@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+)
+
+type WorkForm string
+
+const (
+	WorkFormProse WorkForm = "prose"
+	WorkFormVerse WorkForm = "verse"
+)
+
+// WorkMetadata holds the optional descriptive information about a work. Year
+// is the (approximate) year of composition, negative for BC.
+type WorkMetadata struct {
+	Period  string
+	Year    *int
+	Genre   string
+	Form    WorkForm
+	Edition string
+	Notes   string
+	Tags    []string
+}
+
+// WorkFilter selects works by their metadata. Empty fields match every work;
+// Period, Genre and Tag are compared case-insensitively, and Edition and Notes
+// match if they contain the given text.
+type WorkFilter struct {
+	Period   string
+	Genre    string
+	Form     WorkForm
+	Tag      string
+	Edition  string
+	Notes    string
+	FromYear *int
+	ToYear   *int
+}
+
+// IsEmpty reports whether the filter matches every work.
+func (f WorkFilter) IsEmpty() bool {
+	return f == WorkFilter{}
+}
+
+// ParseTags splits a comma-separated list of tags, dropping empty and
+// duplicate tags.
+func ParseTags(s string) []string {
+	tags := []string{}
+
+	for _, tag := range strings.Split(s, ",") {
+		tag = strings.TrimSpace(tag)
+
+		if tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
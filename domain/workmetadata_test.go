@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTags(t *testing.T) {
+	assert.Equal(t, []string{"AP syllabus", "military"}, ParseTags(" AP syllabus, military,,AP syllabus "))
+	assert.Equal(t, []string{}, ParseTags(""))
+}
@@ -0,0 +1,468 @@
+package anki
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"html"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nienkeboomsma/vocabularium/domain"
+	_ "modernc.org/sqlite"
+)
+
+// modelID identifies the note type. It must never change, or Anki will treat
+// notes from later exports as a different type.
+const modelID = 1735689600000
+
+const (
+	defaultDeckID  = 1
+	fieldSeparator = "\x1f"
+)
+
+// htmlTags matches HTML tags, which Anki leaves out of the sort field.
+var htmlTags = regexp.MustCompile(`<[^>]*>`)
+
+var fields = []string{"Lemma", "LemmaRich", "Translation", "LASLA", "Example", "Source"}
+
+const front = `<div class="lemma">{{Lemma}}</div>`
+
+const back = `{{FrontSide}}
+<hr id="answer">
+<div class="lemma">{{LemmaRich}}</div>
+<div>{{Translation}}</div>
+{{#Example}}<div class="example">{{Example}}</div>{{/Example}}
+{{#Source}}<div class="source">{{Source}}</div>{{/Source}}
+{{#LASLA}}<div class="source">LASLA: {{LASLA}}</div>{{/LASLA}}`
+
+const css = `.card {
+	font-family: serif;
+	font-size: 20px;
+	text-align: center;
+}
+
+.lemma {
+	font-size: 28px;
+}
+
+.example {
+	font-style: italic;
+	margin-top: 1em;
+}
+
+.source {
+	color: #888;
+	font-size: 14px;
+	margin-top: 0.5em;
+}`
+
+// AnkiExporter writes decks as Anki packages (.apkg): a zip archive holding a
+// SQLite collection. Notes get the ID of their word as GUID, so that importing
+// a later export of the same words updates the existing notes instead of
+// adding duplicates.
+type AnkiExporter struct{}
+
+func NewAnkiExporter() *AnkiExporter {
+	return &AnkiExporter{}
+}
+
+func (ae *AnkiExporter) Export(w io.Writer, deck domain.Deck) error {
+	file, err := os.CreateTemp("", "vocabularium-*.anki2")
+	if err != nil {
+		return fmt.Errorf("failed to create collection file: %w", err)
+	}
+
+	path := file.Name()
+	defer os.Remove(path)
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close collection file: %w", err)
+	}
+
+	err = writeCollection(path, deck, time.Now())
+	if err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(w)
+
+	collection, err := archive.Create("collection.anki2")
+	if err != nil {
+		return fmt.Errorf("failed to add collection to package: %w", err)
+	}
+
+	file, err = os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open collection file: %w", err)
+	}
+	defer file.Close()
+
+	_, err = io.Copy(collection, file)
+	if err != nil {
+		return fmt.Errorf("failed to add collection to package: %w", err)
+	}
+
+	media, err := archive.Create("media")
+	if err != nil {
+		return fmt.Errorf("failed to add media to package: %w", err)
+	}
+
+	_, err = media.Write([]byte("{}"))
+	if err != nil {
+		return fmt.Errorf("failed to add media to package: %w", err)
+	}
+
+	err = archive.Close()
+	if err != nil {
+		return fmt.Errorf("failed to write package: %w", err)
+	}
+
+	return nil
+}
+
+func writeCollection(path string, deck domain.Deck, now time.Time) error {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return fmt.Errorf("failed to open collection: %w", err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(schema)
+	if err != nil {
+		return fmt.Errorf("failed to create collection: %w", err)
+	}
+
+	deckID := deriveDeckID(deck.Name)
+
+	conf, models, decks, dconf, err := collectionConfig(deck.Name, deckID, now)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(
+		`INSERT INTO col VALUES (1, ?, ?, ?, 11, 0, 0, 0, ?, ?, ?, ?, '{}')`,
+		now.Truncate(24*time.Hour).Unix(),
+		now.UnixMilli(),
+		now.UnixMilli(),
+		conf,
+		models,
+		decks,
+		dconf,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save collection: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i, card := range deck.Cards {
+		noteID, cardID := deriveIDs(card.ID)
+		sortField := htmlTags.ReplaceAllString(card.LemmaRaw, "")
+
+		_, err = tx.Exec(
+			`INSERT INTO notes VALUES (?, ?, ?, ?, -1, ?, ?, ?, ?, 0, '')`,
+			noteID,
+			card.ID.String(),
+			modelID,
+			now.Unix(),
+			formatTags(card.Example.Works),
+			strings.Join(noteFields(card), fieldSeparator),
+			sortField,
+			checksum(sortField),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save note: %w", err)
+		}
+
+		// New cards are due in the order of the list.
+		_, err = tx.Exec(
+			`INSERT INTO cards VALUES (?, ?, ?, 0, ?, -1, 0, 0, ?, 0, 0, 0, 0, 0, 0, 0, 0, '')`,
+			cardID,
+			noteID,
+			deckID,
+			now.Unix(),
+			i+1,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save card: %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// noteFields returns the contents of the fields of the note, in the order of
+// fields. Fields contain HTML: the lemmas and translation come with their own
+// markup, such as homonym numbers in <sup>, and all other text is escaped.
+func noteFields(card domain.DeckCard) []string {
+	lasla := ""
+
+	if card.FrequencyInLASLA > 0 {
+		lasla = strconv.Itoa(card.FrequencyInLASLA)
+	}
+
+	source := ""
+
+	if card.Example.Title != "" {
+		source = html.EscapeString(card.Example.Title + ", " + card.Example.Author)
+	}
+
+	return []string{
+		card.LemmaRaw,
+		card.LemmaRich,
+		card.Translation,
+		lasla,
+		formatExample(card.Example),
+		source,
+	}
+}
+
+// formatExample joins the words of the sentence, with the word itself in bold.
+func formatExample(example domain.Example) string {
+	words := make([]string, len(example.Sentence))
+
+	for i, word := range example.Sentence {
+		words[i] = html.EscapeString(word)
+
+		if i == example.Position {
+			words[i] = "<b>" + words[i] + "</b>"
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+// formatTags turns the titles of works into Anki tags, which cannot contain
+// spaces.
+func formatTags(titles []string) string {
+	if len(titles) == 0 {
+		return ""
+	}
+
+	tags := make([]string, len(titles))
+
+	for i, title := range titles {
+		tags[i] = strings.Join(strings.Fields(title), "_")
+	}
+
+	return " " + strings.Join(tags, " ") + " "
+}
+
+// deriveIDs derives the note and card IDs from the word ID, so that they are
+// the same in every export. Anki stores IDs as integers that must fit in a
+// JavaScript number.
+func deriveIDs(id uuid.UUID) (int64, int64) {
+	noteID := int64(binary.BigEndian.Uint64(id[:8]) >> 11)
+	cardID := int64(binary.BigEndian.Uint64(id[8:]) >> 11)
+
+	return noteID, cardID
+}
+
+func deriveDeckID(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+
+	return max(int64(h.Sum64()>>11), defaultDeckID+1)
+}
+
+// checksum is used by Anki to find duplicates: the first 8 hexadecimal digits
+// of the SHA-1 hash of the sort field, as an integer.
+func checksum(field string) int64 {
+	sum := sha1.Sum([]byte(field))
+
+	value, _ := strconv.ParseInt(hex.EncodeToString(sum[:4]), 16, 64)
+
+	return value
+}
+
+type model struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Type      int        `json:"type"`
+	Mod       int64      `json:"mod"`
+	USN       int        `json:"usn"`
+	SortField int        `json:"sortf"`
+	DeckID    int64      `json:"did"`
+	Templates []template `json:"tmpls"`
+	Fields    []field    `json:"flds"`
+	CSS       string     `json:"css"`
+	LatexPre  string     `json:"latexPre"`
+	LatexPost string     `json:"latexPost"`
+	Required  []any      `json:"req"`
+	Tags      []string   `json:"tags"`
+	Vers      []any      `json:"vers"`
+}
+
+type template struct {
+	Name           string `json:"name"`
+	Ord            int    `json:"ord"`
+	QuestionFormat string `json:"qfmt"`
+	AnswerFormat   string `json:"afmt"`
+	BrowserQFormat string `json:"bqfmt"`
+	BrowserAFormat string `json:"bafmt"`
+	DeckID         *int64 `json:"did"`
+}
+
+type field struct {
+	Name   string `json:"name"`
+	Ord    int    `json:"ord"`
+	Sticky bool   `json:"sticky"`
+	RTL    bool   `json:"rtl"`
+	Font   string `json:"font"`
+	Size   int    `json:"size"`
+	Media  []any  `json:"media"`
+}
+
+type deckConfig struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Desc      string `json:"desc"`
+	Mod       int64  `json:"mod"`
+	USN       int    `json:"usn"`
+	Conf      int    `json:"conf"`
+	Dyn       int    `json:"dyn"`
+	Collapsed bool   `json:"collapsed"`
+	ExtendNew int    `json:"extendNew"`
+	ExtendRev int    `json:"extendRev"`
+	NewToday  []int  `json:"newToday"`
+	RevToday  []int  `json:"revToday"`
+	LrnToday  []int  `json:"lrnToday"`
+	TimeToday []int  `json:"timeToday"`
+}
+
+func newDeckConfig(id int64, name string, now time.Time) deckConfig {
+	return deckConfig{
+		ID:        id,
+		Name:      name,
+		Mod:       now.Unix(),
+		USN:       -1,
+		Conf:      1,
+		ExtendNew: 10,
+		ExtendRev: 50,
+		NewToday:  []int{0, 0},
+		RevToday:  []int{0, 0},
+		LrnToday:  []int{0, 0},
+		TimeToday: []int{0, 0},
+	}
+}
+
+// collectionConfig returns the JSON configuration of the collection: its
+// settings, the note type, the decks and the deck options.
+func collectionConfig(name string, deckID int64, now time.Time) (string, string, string, string, error) {
+	modelFields := make([]field, len(fields))
+
+	for i, name := range fields {
+		modelFields[i] = field{Name: name, Ord: i, Font: "Arial", Size: 20, Media: []any{}}
+	}
+
+	models := map[string]model{
+		strconv.Itoa(modelID): {
+			ID:        modelID,
+			Name:      "Vocabularium",
+			Mod:       now.Unix(),
+			USN:       -1,
+			DeckID:    deckID,
+			Templates: []template{{Name: "Card 1", QuestionFormat: front, AnswerFormat: back}},
+			Fields:    modelFields,
+			CSS:       css,
+			LatexPre:  "\\documentclass[12pt]{article}\n\\special{papersize=3in,5in}\n\\usepackage{amssymb,amsmath}\n\\pagestyle{empty}\n\\setlength{\\parindent}{0in}\n\\begin{document}\n",
+			LatexPost: "\\end{document}",
+			Required:  []any{[]any{0, "any", []int{0}}},
+			Tags:      []string{},
+			Vers:      []any{},
+		},
+	}
+
+	decks := map[string]deckConfig{
+		strconv.Itoa(defaultDeckID):   newDeckConfig(defaultDeckID, "Default", now),
+		strconv.FormatInt(deckID, 10): newDeckConfig(deckID, name, now),
+	}
+
+	conf := map[string]any{
+		"activeDecks":   []int64{deckID},
+		"curDeck":       deckID,
+		"curModel":      strconv.Itoa(modelID),
+		"nextPos":       1,
+		"sortType":      "noteFld",
+		"sortBackwards": false,
+		"addToCur":      true,
+		"newSpread":     0,
+		"collapseTime":  1200,
+		"timeLim":       0,
+		"estTimes":      true,
+		"dueCounts":     true,
+	}
+
+	dconf := map[string]any{
+		"1": map[string]any{
+			"id":       1,
+			"name":     "Default",
+			"mod":      0,
+			"usn":      0,
+			"maxTaken": 60,
+			"autoplay": true,
+			"timer":    0,
+			"replayq":  true,
+			"new": map[string]any{
+				"bury":          true,
+				"delays":        []int{1, 10},
+				"initialFactor": 2500,
+				"ints":          []int{1, 4, 7},
+				"order":         1,
+				"perDay":        20,
+				"separate":      true,
+			},
+			"lapse": map[string]any{
+				"delays":      []int{10},
+				"leechAction": 0,
+				"leechFails":  8,
+				"minInt":      1,
+				"mult":        0,
+			},
+			"rev": map[string]any{
+				"bury":     true,
+				"ease4":    1.3,
+				"fuzz":     0.05,
+				"ivlFct":   1,
+				"maxIvl":   36500,
+				"minSpace": 1,
+				"perDay":   100,
+			},
+		},
+	}
+
+	values := []any{conf, models, decks, dconf}
+	encoded := make([]string, len(values))
+
+	for i, value := range values {
+		b, err := json.Marshal(value)
+		if err != nil {
+			return "", "", "", "", fmt.Errorf("failed to encode collection configuration: %w", err)
+		}
+
+		encoded[i] = string(b)
+	}
+
+	return encoded[0], encoded[1], encoded[2], encoded[3], nil
+}
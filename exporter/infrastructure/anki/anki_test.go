@@ -0,0 +1,142 @@
+package anki
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nienkeboomsma/vocabularium/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatExample(t *testing.T) {
+	tests := []struct {
+		name    string
+		example domain.Example
+		want    string
+	}{
+		{
+			name:    "highlights the word",
+			example: domain.Example{Sentence: []string{"Gallia", "est", "omnis"}, Position: 1},
+			want:    "Gallia <b>est</b> omnis",
+		},
+		{
+			name:    "escapes HTML",
+			example: domain.Example{Sentence: []string{"<arma>", "uirumque"}, Position: 1},
+			want:    "&lt;arma&gt; <b>uirumque</b>",
+		},
+		{
+			name:    "no example",
+			example: domain.Example{},
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatExample(tt.example))
+		})
+	}
+}
+
+func TestFormatTags(t *testing.T) {
+	tests := []struct {
+		name   string
+		titles []string
+		want   string
+	}{
+		{name: "no works", titles: nil, want: ""},
+		{name: "single work", titles: []string{"Aeneis"}, want: " Aeneis "},
+		{name: "spaces", titles: []string{"De bello Gallico", "Metamorphoses"}, want: " De_bello_Gallico Metamorphoses "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatTags(tt.titles))
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	word := domain.Word{
+		ID:               uuid.MustParse("6f1c1f3e-8a4b-4c7d-9e2f-0a1b2c3d4e5f"),
+		LemmaRaw:         "sum<sup>1</sup>",
+		LemmaRich:        "sŭm<sup>1</sup>, es, esse, fui",
+		Translation:      "to be",
+		FrequencyInLASLA: 24106,
+	}
+
+	deck := domain.NewDeck("De bello Gallico", []domain.WordInWork{{Word: word, Count: 3}}, map[uuid.UUID]domain.Example{
+		word.ID: {
+			WordID:   word.ID,
+			Works:    []string{"De bello Gallico"},
+			Title:    "De bello Gallico",
+			Author:   "Caesar",
+			Sentence: []string{"Gallia", "est", "omnis", "diuisa"},
+			Position: 1,
+		},
+	})
+
+	var buf bytes.Buffer
+
+	err := NewAnkiExporter().Export(&buf, deck)
+	require.NoError(t, err)
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	names := []string{}
+	path := filepath.Join(t.TempDir(), "collection.anki2")
+
+	for _, file := range archive.File {
+		names = append(names, file.Name)
+
+		if file.Name != "collection.anki2" {
+			continue
+		}
+
+		r, err := file.Open()
+		require.NoError(t, err)
+
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, b, 0o600))
+	}
+
+	assert.ElementsMatch(t, []string{"collection.anki2", "media"}, names)
+
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	defer db.Close()
+
+	var guid, tags, flds, sfld string
+
+	err = db.QueryRow("SELECT guid, tags, flds, sfld FROM notes").Scan(&guid, &tags, &flds, &sfld)
+	require.NoError(t, err)
+
+	assert.Equal(t, word.ID.String(), guid)
+	assert.Equal(t, " De_bello_Gallico ", tags)
+	assert.Equal(t, "sum1", sfld)
+	assert.Equal(t, []string{
+		"sum<sup>1</sup>",
+		"sŭm<sup>1</sup>, es, esse, fui",
+		"to be",
+		"24106",
+		"Gallia <b>est</b> omnis diuisa",
+		"De bello Gallico, Caesar",
+	}, strings.Split(flds, fieldSeparator))
+
+	var cards int
+
+	err = db.QueryRow("SELECT COUNT(*) FROM cards c JOIN notes n ON n.id = c.nid").Scan(&cards)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, cards)
+}
@@ -0,0 +1,360 @@
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"html"
+	"html/template"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nienkeboomsma/vocabularium/domain"
+	"github.com/nienkeboomsma/vocabularium/exporter/infrastructure/markup"
+)
+
+const container = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+	<rootfiles>
+		<rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+	</rootfiles>
+</container>
+`
+
+const style = `body {
+	font-family: serif;
+	line-height: 1.5;
+}
+
+h1, h2 {
+	text-align: center;
+}
+
+.sentence {
+	margin-right: 1em;
+}
+
+a[epub|type~="noteref"] {
+	color: inherit;
+	text-decoration: underline dotted;
+}
+
+aside {
+	font-size: 0.9em;
+}
+
+dt {
+	font-weight: bold;
+	margin-top: 0.5em;
+}
+
+dd {
+	margin-left: 1em;
+}
+`
+
+const xmlDeclaration = `<?xml version="1.0" encoding="UTF-8"?>
+`
+
+var pkg = template.Must(template.New("package").Parse(`<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="id" xml:lang="la">
+	<metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+		<dc:identifier id="id">urn:uuid:{{.ID}}</dc:identifier>
+		<dc:title>{{.Title}}</dc:title>
+		{{if .Author}}<dc:creator>{{.Author}}</dc:creator>{{end}}
+		<dc:language>la</dc:language>
+		<meta property="dcterms:modified">{{.Modified}}</meta>
+	</metadata>
+	<manifest>
+		<item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+		<item id="style" href="style.css" media-type="text/css"/>
+		{{range .Chapters}}
+		<item id="{{.ID}}" href="{{.Href}}" media-type="application/xhtml+xml"/>
+		{{end}}
+	</manifest>
+	<spine>
+		{{range .Chapters}}
+		<itemref idref="{{.ID}}"/>
+		{{end}}
+	</spine>
+</package>
+`))
+
+var nav = template.Must(template.New("nav").Parse(`<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" lang="la" xml:lang="la">
+	<head>
+		<meta charset="UTF-8"/>
+		<title>{{.Title}}</title>
+	</head>
+	<body>
+		<nav epub:type="toc" id="toc">
+			<h1>{{.Title}}</h1>
+			<ol>
+				{{range .Chapters}}
+				<li><a href="{{.Href}}">{{.Heading}}</a></li>
+				{{end}}
+			</ol>
+		</nav>
+	</body>
+</html>
+`))
+
+var chapter = template.Must(template.New("chapter").Parse(`<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" lang="la" xml:lang="la">
+	<head>
+		<meta charset="UTF-8"/>
+		<title>{{.Heading}}</title>
+		<link rel="stylesheet" type="text/css" href="style.css"/>
+	</head>
+	<body>
+		<section epub:type="chapter">
+			{{if .Title}}<h1>{{.Title}}</h1>{{end}}
+			<h2>{{.Heading}}</h2>
+			<p>
+				{{range .Sentences}}
+				<span class="sentence">{{range $i, $token := .}}{{if $i}} {{end}}{{if $token.Note}}<a epub:type="noteref" href="#{{$token.Note}}">{{$token.Form}}</a>{{else}}{{$token.Form}}{{end}}{{end}}</span>
+				{{end}}
+			</p>
+			{{range .Notes}}
+			<aside epub:type="footnote" id="{{.ID}}">
+				<p><b>{{.Lemma}}</b> {{.Translation}}</p>
+			</aside>
+			{{end}}
+		</section>
+	</body>
+</html>
+`))
+
+var wordList = template.Must(template.New("wordlist").Parse(`<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" lang="la" xml:lang="la">
+	<head>
+		<meta charset="UTF-8"/>
+		<title>{{.Heading}}</title>
+		<link rel="stylesheet" type="text/css" href="style.css"/>
+	</head>
+	<body>
+		<section epub:type="glossary">
+			<h2>{{.Heading}}</h2>
+			<dl>
+				{{range .Notes}}
+				<dt>{{.Lemma}}</dt>
+				<dd>{{.Translation}}</dd>
+				{{end}}
+			</dl>
+		</section>
+	</body>
+</html>
+`))
+
+var tags = map[string]markup.Tag{
+	"sup":    {Open: "<sup>", Close: "</sup>"},
+	"sub":    {Open: "<sub>", Close: "</sub>"},
+	"i":      {Open: "<i>", Close: "</i>"},
+	"em":     {Open: "<em>", Close: "</em>"},
+	"b":      {Open: "<b>", Close: "</b>"},
+	"strong": {Open: "<strong>", Close: "</strong>"},
+}
+
+// file is a file in the book, either with fixed content or rendered from a
+// template, in which case it gets an XML declaration.
+type file struct {
+	name     string
+	template *template.Template
+	data     any
+	content  string
+}
+
+type chapterData struct {
+	ID        string
+	Href      string
+	Title     string
+	Heading   string
+	Sentences [][]tokenData
+	Notes     []noteData
+}
+
+type tokenData struct {
+	Form string
+	Note string
+}
+
+type noteData struct {
+	ID          string
+	Lemma       template.HTML
+	Translation template.HTML
+}
+
+// EPUBExporter writes a reader's edition as an EPUB 3 book, with a chapter for
+// every page of the edition and a word list at the end. Every glossed word
+// links to a footnote with its dictionary entry and translation, which most
+// e-readers show as a pop-up.
+type EPUBExporter struct{}
+
+func NewEPUBExporter() *EPUBExporter {
+	return &EPUBExporter{}
+}
+
+func (ee *EPUBExporter) Export(w io.Writer, edition domain.Edition) error {
+	return export(w, edition, time.Now())
+}
+
+func export(w io.Writer, edition domain.Edition, now time.Time) error {
+	chapters := make([]chapterData, 0, len(edition.Pages)+1)
+
+	for i, page := range edition.Pages {
+		chapters = append(chapters, newChapter(i, page))
+	}
+
+	if len(chapters) > 0 {
+		chapters[0].Title = edition.Title
+	}
+
+	words := chapterData{
+		ID:      "wordlist",
+		Href:    "wordlist.xhtml",
+		Heading: "Word list",
+		Notes:   newNotes(edition.WordList, "word-"),
+	}
+
+	contents := append(chapters, words)
+
+	archive := zip.NewWriter(w)
+
+	// The mimetype must be the first file in the archive, and uncompressed.
+	mimetype, err := archive.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return fmt.Errorf("failed to add mimetype to archive: %w", err)
+	}
+
+	_, err = io.WriteString(mimetype, "application/epub+zip")
+	if err != nil {
+		return fmt.Errorf("failed to write mimetype: %w", err)
+	}
+
+	files := []file{
+		{name: "META-INF/container.xml", content: container},
+		{name: "OEBPS/style.css", content: style},
+		{
+			name:     "OEBPS/content.opf",
+			template: pkg,
+			data: map[string]any{
+				"ID":       bookID(edition.ID),
+				"Title":    edition.Title,
+				"Author":   edition.Author,
+				"Modified": now.UTC().Format("2006-01-02T15:04:05Z"),
+				"Chapters": contents,
+			},
+		},
+		{
+			name:     "OEBPS/nav.xhtml",
+			template: nav,
+			data:     map[string]any{"Title": edition.Title, "Chapters": contents},
+		},
+	}
+
+	for _, c := range chapters {
+		files = append(files, file{name: "OEBPS/" + c.Href, template: chapter, data: c})
+	}
+
+	files = append(files, file{name: "OEBPS/" + words.Href, template: wordList, data: words})
+
+	for _, f := range files {
+		content := f.content
+
+		if f.template != nil {
+			var buf bytes.Buffer
+
+			err = f.template.Execute(&buf, f.data)
+			if err != nil {
+				return fmt.Errorf("failed to render %s: %w", f.name, err)
+			}
+
+			content = xmlDeclaration + buf.String()
+		}
+
+		fw, err := archive.Create(f.name)
+		if err != nil {
+			return fmt.Errorf("failed to add %s to archive: %w", f.name, err)
+		}
+
+		_, err = io.WriteString(fw, content)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+	}
+
+	err = archive.Close()
+	if err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	return nil
+}
+
+// newChapter turns a page of the edition into a chapter, in which every glossed
+// word links to the footnote of its lemma; pages without a heading are
+// numbered.
+func newChapter(index int, page domain.EditionPage) chapterData {
+	c := chapterData{
+		ID:      fmt.Sprintf("page-%03d", index+1),
+		Href:    fmt.Sprintf("page-%03d.xhtml", index+1),
+		Heading: page.Heading,
+		Notes:   newNotes(page.Glossary, "gloss-"),
+	}
+
+	if c.Heading == "" {
+		c.Heading = strconv.Itoa(index + 1)
+	}
+
+	notes := make(map[uuid.UUID]string, len(page.Glossary))
+
+	for i, word := range page.Glossary {
+		notes[word.ID] = c.Notes[i].ID
+	}
+
+	for _, sentence := range page.Sentences {
+		tokens := make([]tokenData, len(sentence))
+
+		for i, token := range sentence {
+			tokens[i] = tokenData{Form: token.Form}
+
+			if token.Glossed {
+				tokens[i].Note = notes[token.WordID]
+			}
+		}
+
+		c.Sentences = append(c.Sentences, tokens)
+	}
+
+	return c
+}
+
+func newNotes(words []domain.WordInWork, prefix string) []noteData {
+	notes := make([]noteData, len(words))
+
+	for i, word := range words {
+		notes[i] = noteData{
+			ID:          fmt.Sprintf("%s%d", prefix, i+1),
+			Lemma:       convert(word.LemmaRich),
+			Translation: convert(word.Translation),
+		}
+	}
+
+	return notes
+}
+
+// convert keeps the simple markup of lemmas and translations, and escapes
+// everything else so that the result is valid XHTML.
+func convert(s string) template.HTML {
+	return template.HTML(markup.Convert(s, tags, html.EscapeString))
+}
+
+// bookID returns the ID of the book, or a random one if the edition has none.
+func bookID(id uuid.UUID) uuid.UUID {
+	if id == uuid.Nil {
+		return uuid.New()
+	}
+
+	return id
+}
@@ -0,0 +1,107 @@
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nienkeboomsma/vocabularium/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExport(t *testing.T) {
+	gallia := domain.WordInWork{Word: domain.Word{ID: uuid.New(), LemmaRaw: "Gallia", LemmaRich: "Gallĭa<sup>1</sup>, ae, f.", Translation: "Gaul & its people"}}
+
+	edition := domain.Edition{
+		ID:     uuid.New(),
+		Title:  "De bello Gallico",
+		Author: "Caesar",
+		Pages: []domain.EditionPage{
+			{
+				Heading: "1.1",
+				Sentences: [][]domain.EditionToken{
+					{{Form: "Gallia", WordID: gallia.ID, Glossed: true}, {Form: "est"}},
+					{{Form: "Gallia", WordID: gallia.ID, Glossed: true}},
+				},
+				Glossary: []domain.WordInWork{gallia},
+			},
+			{
+				Sentences: [][]domain.EditionToken{{{Form: "<et>"}}},
+			},
+		},
+		WordList: []domain.WordInWork{gallia},
+	}
+
+	var buf bytes.Buffer
+
+	err := export(&buf, edition, time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	require.NotEmpty(t, archive.File)
+	assert.Equal(t, "mimetype", archive.File[0].Name)
+	assert.Equal(t, zip.Store, archive.File[0].Method)
+
+	contents := map[string]string{}
+
+	for _, file := range archive.File {
+		r, err := file.Open()
+		require.NoError(t, err)
+
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+
+		contents[file.Name] = string(b)
+
+		if strings.HasSuffix(file.Name, ".xhtml") || strings.HasSuffix(file.Name, ".opf") || strings.HasSuffix(file.Name, ".xml") {
+			decoder := xml.NewDecoder(bytes.NewReader(b))
+
+			for err == nil {
+				_, err = decoder.Token()
+			}
+
+			assert.ErrorIs(t, err, io.EOF, "%s is not well-formed", file.Name)
+		}
+	}
+
+	assert.ElementsMatch(t, []string{
+		"mimetype",
+		"META-INF/container.xml",
+		"OEBPS/style.css",
+		"OEBPS/content.opf",
+		"OEBPS/nav.xhtml",
+		"OEBPS/page-001.xhtml",
+		"OEBPS/page-002.xhtml",
+		"OEBPS/wordlist.xhtml",
+	}, keys(contents))
+
+	assert.Equal(t, "application/epub+zip", contents["mimetype"])
+	assert.Contains(t, contents["OEBPS/content.opf"], "urn:uuid:"+edition.ID.String())
+	assert.Contains(t, contents["OEBPS/content.opf"], "2025-09-01T09:00:00Z")
+
+	page := contents["OEBPS/page-001.xhtml"]
+	assert.Equal(t, 2, strings.Count(page, `<a epub:type="noteref" href="#gloss-1">Gallia</a>`))
+	assert.Contains(t, page, `<aside epub:type="footnote" id="gloss-1">`)
+	assert.Contains(t, page, "<b>Gallĭa<sup>1</sup>, ae, f.</b> Gaul &amp; its people")
+
+	assert.Contains(t, contents["OEBPS/page-002.xhtml"], "&lt;et&gt;")
+	assert.Contains(t, contents["OEBPS/wordlist.xhtml"], "<dt>Gallĭa<sup>1</sup>, ae, f.</dt>")
+}
+
+func keys(m map[string]string) []string {
+	keys := []string{}
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
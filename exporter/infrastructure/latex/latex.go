@@ -0,0 +1,172 @@
+package latex
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nienkeboomsma/vocabularium/domain"
+	"github.com/nienkeboomsma/vocabularium/exporter/infrastructure/markup"
+)
+
+// style is the package with the layout of the edition, kept separate so that it
+// can be adapted without touching the text. It works with pdfLaTeX as well as
+// XeLaTeX and LuaLaTeX, and only uses packages included in every TeX
+// distribution.
+const style = `\NeedsTeXFormat{LaTeX2e}
+\ProvidesPackage{vocabularium}
+
+\RequirePackage{iftex}
+\ifPDFTeX
+	\RequirePackage[utf8]{inputenc}
+	\RequirePackage[T1]{fontenc}
+	\RequirePackage{lmodern}
+\else
+	\RequirePackage{fontspec}
+\fi
+\RequirePackage[a5paper,margin=15mm]{geometry}
+\RequirePackage{multicol}
+
+\setlength{\parindent}{0pt}
+\setlength{\parskip}{0.5em}
+
+% Redefine \glossed to mark the words that are glossed beneath the page.
+\newcommand{\glossed}[1]{#1}
+\newcommand{\sentencebreak}{\quad}
+
+\newenvironment{passage}[1]
+	{\if\relax\detokenize{#1}\relax\else\section*{#1}\fi}
+	{\par}
+
+\newenvironment{glosses}
+	{\vfill\noindent\rule{\linewidth}{0.4pt}\par\footnotesize\begin{multicols}{2}\raggedright\setlength{\parskip}{0.2em}}
+	{\end{multicols}}
+
+\newcommand{\gloss}[2]{\textbf{#1}\enspace #2\par}
+`
+
+var tags = map[string]markup.Tag{
+	"sup":    {Open: `\textsuperscript{`, Close: `}`},
+	"i":      {Open: `\textit{`, Close: `}`},
+	"em":     {Open: `\emph{`, Close: `}`},
+	"b":      {Open: `\textbf{`, Close: `}`},
+	"strong": {Open: `\textbf{`, Close: `}`},
+}
+
+var escaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`$`, `\$`,
+	`&`, `\&`,
+	`#`, `\#`,
+	`%`, `\%`,
+	`_`, `\_`,
+	`^`, `\textasciicircum{}`,
+	`~`, `\textasciitilde{}`,
+)
+
+// LaTeXExporter writes a reader's edition as a zip archive with the LaTeX
+// source of the edition and the package with its layout.
+type LaTeXExporter struct{}
+
+func NewLaTeXExporter() *LaTeXExporter {
+	return &LaTeXExporter{}
+}
+
+func (le *LaTeXExporter) Export(w io.Writer, edition domain.Edition) error {
+	archive := zip.NewWriter(w)
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{name: "edition.tex", content: render(edition)},
+		{name: "vocabularium.sty", content: style},
+	}
+
+	for _, file := range files {
+		f, err := archive.Create(file.name)
+		if err != nil {
+			return fmt.Errorf("failed to add %s to archive: %w", file.name, err)
+		}
+
+		_, err = io.WriteString(f, file.content)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+	}
+
+	err := archive.Close()
+	if err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	return nil
+}
+
+func render(edition domain.Edition) string {
+	var b strings.Builder
+
+	b.WriteString("% Compile with pdflatex, xelatex or lualatex, e.g. `pdflatex edition.tex`.\n")
+	b.WriteString("\\documentclass[11pt]{article}\n")
+	b.WriteString("\\usepackage{vocabularium}\n\n")
+	fmt.Fprintf(&b, "\\title{%s}\n", escape(edition.Title))
+	fmt.Fprintf(&b, "\\author{%s}\n", escape(edition.Author))
+	b.WriteString("\\date{}\n\n")
+	b.WriteString("\\begin{document}\n\n")
+	b.WriteString("\\maketitle\n\n")
+
+	for i, page := range edition.Pages {
+		if i > 0 {
+			b.WriteString("\\clearpage\n\n")
+		}
+
+		fmt.Fprintf(&b, "\\begin{passage}{%s}\n", escape(page.Heading))
+
+		for j, sentence := range page.Sentences {
+			if j > 0 {
+				b.WriteString("\\sentencebreak\n")
+			}
+
+			forms := make([]string, len(sentence))
+
+			for k, token := range sentence {
+				forms[k] = escape(token.Form)
+
+				if token.Glossed {
+					forms[k] = "\\glossed{" + forms[k] + "}"
+				}
+			}
+
+			b.WriteString(strings.Join(forms, " ") + "\n")
+		}
+
+		b.WriteString("\\end{passage}\n")
+
+		if len(page.Glossary) > 0 {
+			b.WriteString("\n\\begin{glosses}\n")
+
+			for _, word := range page.Glossary {
+				fmt.Fprintf(&b, "\\gloss{%s}{%s}\n", convert(word.LemmaRich), convert(word.Translation))
+			}
+
+			b.WriteString("\\end{glosses}\n")
+		}
+
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\\end{document}\n")
+
+	return b.String()
+}
+
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+func convert(s string) string {
+	return markup.Convert(s, tags, escape)
+}
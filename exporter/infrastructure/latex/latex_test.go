@@ -0,0 +1,64 @@
+package latex
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nienkeboomsma/vocabularium/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	edition := domain.Edition{
+		Title:  "De bello Gallico",
+		Author: "Caesar",
+		Pages: []domain.EditionPage{
+			{
+				Heading: "1.1",
+				Sentences: [][]domain.EditionToken{
+					{{Form: "Gallia", WordID: uuid.New(), Glossed: true}, {Form: "est"}},
+					{{Form: "50%"}},
+				},
+				Glossary: []domain.WordInWork{
+					{Word: domain.Word{LemmaRich: "Gallĭa<sup>1</sup>, ae, f.", Translation: "Gaul & its people"}},
+				},
+			},
+			{
+				Sentences: [][]domain.EditionToken{{{Form: "et"}}},
+			},
+		},
+	}
+
+	want := `% Compile with pdflatex, xelatex or lualatex, e.g. ` + "`pdflatex edition.tex`" + `.
+\documentclass[11pt]{article}
+\usepackage{vocabularium}
+
+\title{De bello Gallico}
+\author{Caesar}
+\date{}
+
+\begin{document}
+
+\maketitle
+
+\begin{passage}{1.1}
+\glossed{Gallia} est
+\sentencebreak
+50\%
+\end{passage}
+
+\begin{glosses}
+\gloss{Gallĭa\textsuperscript{1}, ae, f.}{Gaul \& its people}
+\end{glosses}
+
+\clearpage
+
+\begin{passage}{}
+et
+\end{passage}
+
+\end{document}
+`
+
+	assert.Equal(t, want, render(edition))
+}
@@ -0,0 +1,45 @@
+package markup
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Tag is the markup that replaces an opening and a closing HTML tag.
+type Tag struct {
+	Open  string
+	Close string
+}
+
+var htmlTag = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9]*)[^>]*>`)
+
+// Convert rewrites the simple HTML found in lemmas and translations, such as
+// homonym numbers in <sup>, into another markup language. Tags that are not in
+// tags are dropped, and the text in between is unescaped and escaped again
+// with escape.
+func Convert(s string, tags map[string]Tag, escape func(string) string) string {
+	var b strings.Builder
+
+	last := 0
+
+	for _, match := range htmlTag.FindAllStringSubmatchIndex(s, -1) {
+		b.WriteString(escape(html.UnescapeString(s[last:match[0]])))
+		last = match[1]
+
+		tag, ok := tags[strings.ToLower(s[match[4]:match[5]])]
+		if !ok {
+			continue
+		}
+
+		if match[3] > match[2] {
+			b.WriteString(tag.Close)
+		} else {
+			b.WriteString(tag.Open)
+		}
+	}
+
+	b.WriteString(escape(html.UnescapeString(s[last:])))
+
+	return b.String()
+}
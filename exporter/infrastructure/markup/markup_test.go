@@ -0,0 +1,35 @@
+package markup
+
+import (
+	"html"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvert(t *testing.T) {
+	tags := map[string]Tag{
+		"sup": {Open: "^{", Close: "}"},
+		"i":   {Open: "/", Close: "/"},
+	}
+	upper := strings.ToUpper
+
+	tests := []struct {
+		name   string
+		input  string
+		escape func(string) string
+		want   string
+	}{
+		{name: "plain text", input: "sum, es, esse", escape: upper, want: "SUM, ES, ESSE"},
+		{name: "homonym number", input: "adeo<sup>2</sup>", escape: upper, want: "ADEO^{2}"},
+		{name: "unknown tags are dropped", input: "<span class=\"x\">a</span> <I>b</I>", escape: upper, want: "A /B/"},
+		{name: "entities are unescaped before escaping", input: "a &amp; b &lt; c", escape: html.EscapeString, want: "a &amp; b &lt; c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Convert(tt.input, tags, tt.escape))
+		})
+	}
+}
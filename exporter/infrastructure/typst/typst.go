@@ -0,0 +1,183 @@
+package typst
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nienkeboomsma/vocabularium/domain"
+	"github.com/nienkeboomsma/vocabularium/exporter/infrastructure/markup"
+)
+
+// style is the module with the layout of the edition, kept separate so that it
+// can be adapted without touching the text.
+const style = `#let edition(title: "", author: "", body) = {
+  set document(title: title)
+  set page(paper: "a5", margin: 15mm)
+  set text(size: 11pt, lang: "la")
+  set par(justify: true)
+
+  align(center, text(size: 17pt, title))
+  if author != "" {
+    align(center, emph(author))
+  }
+
+  body
+}
+
+// Redefine glossed to mark the words that are glossed beneath the page.
+#let glossed(form) = form
+#let sentencebreak = h(1em)
+
+#let passage(heading: "", body) = {
+  if heading != "" {
+    block(strong(heading))
+  }
+
+  body
+}
+
+#let glossary(entries) = {
+  v(1fr)
+  line(length: 100%, stroke: 0.4pt)
+  set text(size: 8.5pt)
+  set par(justify: false)
+  columns(2, for (lemma, translation) in entries {
+    [*#lemma* #h(0.5em) #translation \ ]
+  })
+}
+`
+
+// tags end every function call with a semicolon, so that text directly after
+// it, such as a full stop, is not read as part of the call.
+var tags = map[string]markup.Tag{
+	"sup":    {Open: "#super[", Close: "];"},
+	"i":      {Open: "#emph[", Close: "];"},
+	"em":     {Open: "#emph[", Close: "];"},
+	"b":      {Open: "#strong[", Close: "];"},
+	"strong": {Open: "#strong[", Close: "];"},
+}
+
+// escaper escapes the characters that have a meaning in Typst markup.
+var escaper = strings.NewReplacer(
+	`\`, `\\`,
+	`#`, `\#`,
+	`*`, `\*`,
+	`_`, `\_`,
+	"`", "\\`",
+	`$`, `\$`,
+	`<`, `\<`,
+	`>`, `\>`,
+	`@`, `\@`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`~`, `\~`,
+	`/`, `\/`,
+	`=`, `\=`,
+	`-`, `\-`,
+	`+`, `\+`,
+)
+
+var stringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// TypstExporter writes a reader's edition as a zip archive with the Typst
+// source of the edition and the module with its layout.
+type TypstExporter struct{}
+
+func NewTypstExporter() *TypstExporter {
+	return &TypstExporter{}
+}
+
+func (te *TypstExporter) Export(w io.Writer, edition domain.Edition) error {
+	archive := zip.NewWriter(w)
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{name: "edition.typ", content: render(edition)},
+		{name: "vocabularium.typ", content: style},
+	}
+
+	for _, file := range files {
+		f, err := archive.Create(file.name)
+		if err != nil {
+			return fmt.Errorf("failed to add %s to archive: %w", file.name, err)
+		}
+
+		_, err = io.WriteString(f, file.content)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+	}
+
+	err := archive.Close()
+	if err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	return nil
+}
+
+func render(edition domain.Edition) string {
+	var b strings.Builder
+
+	b.WriteString("// Compile with `typst compile edition.typ`.\n")
+	b.WriteString("#import \"vocabularium.typ\": *\n\n")
+	fmt.Fprintf(&b, "#show: edition.with(title: %s, author: %s)\n\n", quote(edition.Title), quote(edition.Author))
+
+	for i, page := range edition.Pages {
+		if i > 0 {
+			b.WriteString("#pagebreak()\n\n")
+		}
+
+		fmt.Fprintf(&b, "#passage(heading: %s)[\n", quote(page.Heading))
+
+		for j, sentence := range page.Sentences {
+			if j > 0 {
+				b.WriteString("#sentencebreak\n")
+			}
+
+			forms := make([]string, len(sentence))
+
+			for k, token := range sentence {
+				forms[k] = escape(token.Form)
+
+				if token.Glossed {
+					forms[k] = "#glossed[" + forms[k] + "]"
+				}
+			}
+
+			b.WriteString(strings.Join(forms, " ") + "\n")
+		}
+
+		b.WriteString("]\n")
+
+		if len(page.Glossary) > 0 {
+			b.WriteString("\n#glossary((\n")
+
+			for _, word := range page.Glossary {
+				fmt.Fprintf(&b, "  ([%s], [%s]),\n", convert(word.LemmaRich), convert(word.Translation))
+			}
+
+			b.WriteString("))\n")
+		}
+
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+func quote(s string) string {
+	return `"` + stringEscaper.Replace(s) + `"`
+}
+
+func convert(s string) string {
+	return markup.Convert(s, tags, escape)
+}
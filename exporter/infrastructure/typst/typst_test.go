@@ -0,0 +1,56 @@
+package typst
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nienkeboomsma/vocabularium/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	edition := domain.Edition{
+		Title:  `De bello "Gallico"`,
+		Author: "Caesar",
+		Pages: []domain.EditionPage{
+			{
+				Heading: "1.1",
+				Sentences: [][]domain.EditionToken{
+					{{Form: "Gallia", WordID: uuid.New(), Glossed: true}, {Form: "est"}},
+					{{Form: "#1"}},
+				},
+				Glossary: []domain.WordInWork{
+					{Word: domain.Word{LemmaRich: "Gallĭa<sup>1</sup>, ae, f.", Translation: "Gaul [the country]"}},
+				},
+			},
+			{
+				Sentences: [][]domain.EditionToken{{{Form: "et"}}},
+			},
+		},
+	}
+
+	want := "// Compile with `typst compile edition.typ`." + `
+#import "vocabularium.typ": *
+
+#show: edition.with(title: "De bello \"Gallico\"", author: "Caesar")
+
+#passage(heading: "1.1")[
+#glossed[Gallia] est
+#sentencebreak
+\#1
+]
+
+#glossary((
+  ([Gallĭa#super[1];, ae, f.], [Gaul \[the country\]]),
+))
+
+#pagebreak()
+
+#passage(heading: "")[
+et
+]
+
+`
+
+	assert.Equal(t, want, render(edition))
+}
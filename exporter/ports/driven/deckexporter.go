@@ -0,0 +1,11 @@
+package driven
+
+import (
+	"io"
+
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type DeckExporter interface {
+	Export(w io.Writer, deck domain.Deck) error
+}
@@ -0,0 +1,11 @@
+package driven
+
+import (
+	"io"
+
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type EditionExporter interface {
+	Export(w io.Writer, edition domain.Edition) error
+}
@@ -0,0 +1,66 @@
+package knownwords
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nienkeboomsma/vocabularium/domain"
+	"github.com/nienkeboomsma/vocabularium/repositories/ports/driving"
+)
+
+type KnownWordImporter struct {
+	learningStateRepository driving.LearningStateRepository
+	wordRepository          driving.WordRepository
+}
+
+func NewKnownWordImporter(learningStateRepository driving.LearningStateRepository, wordRepository driving.WordRepository) *KnownWordImporter {
+	return &KnownWordImporter{
+		learningStateRepository: learningStateRepository,
+		wordRepository:          wordRepository,
+	}
+}
+
+// Import matches the entries on a list of known words with the words in the
+// corpus and, unless dryRun is set, marks the words matched as known. It backs
+// both the import page and the import-known command.
+func (ki *KnownWordImporter) Import(ctx context.Context, list io.Reader, format domain.KnownWordFormat, dryRun bool, now time.Time) (domain.KnownWordReport, error) {
+	entries, err := parseKnownWords(list, format)
+	if err != nil {
+		return domain.KnownWordReport{}, err
+	}
+
+	words, _, err := ki.wordRepository.GetFrequencyList(ctx, domain.Scope{Kind: domain.ScopeKindCorpus}, domain.WordListFilter{Page: 1})
+	if err != nil {
+		return domain.KnownWordReport{}, err
+	}
+
+	report := domain.MatchKnownWords(entries, *words)
+
+	if dryRun {
+		for _, word := range report.Matched {
+			if !word.Known {
+				report.Marked++
+			}
+		}
+
+		return report, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(report.Matched))
+
+	for _, word := range report.Matched {
+		ids = append(ids, word.ID)
+	}
+
+	action, err := ki.learningStateRepository.MarkKnown(ctx, ids, domain.StatusSourceImport, fmt.Sprintf("Imported %s list of %d entries", format, report.Entries), now)
+	if err != nil {
+		return domain.KnownWordReport{}, err
+	}
+
+	report.Marked = action.WordCount
+
+	return report, nil
+}
@@ -0,0 +1,193 @@
+package knownwords
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+// anki export headers such as "#separator:tab" and "#guid column:1"
+var ankiHeader = regexp.MustCompile(`^#([a-z ]+):(.*)$`)
+
+var ankiSeparators = map[string]rune{
+	"tab":       '\t',
+	"comma":     ',',
+	"semicolon": ';',
+	"space":     ' ',
+	"pipe":      '|',
+	"colon":     ':',
+}
+
+var lemmaColumns = []string{"lemma", "headword", "word"}
+
+// parseKnownWords reads the lemmas from a list of known words. Blank lines and
+// lines starting with # are skipped.
+func parseKnownWords(r io.Reader, format domain.KnownWordFormat) ([]domain.KnownWordEntry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return []domain.KnownWordEntry{}, fmt.Errorf("failed to read list: %w", err)
+	}
+
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+
+	switch format {
+	case domain.KnownWordFormatText:
+		return parseTextList(data)
+	case domain.KnownWordFormatCSV:
+		return parseTable(data, separatorOf(data), lemmaColumns, nil, nil)
+	case domain.KnownWordFormatAnki:
+		return parseAnkiList(data)
+	case domain.KnownWordFormatDCC:
+		return parseTable(data, separatorOf(data), []string{"headword"}, nil, nil)
+	default:
+		return []domain.KnownWordEntry{}, fmt.Errorf("invalid known word format %q", format)
+	}
+}
+
+func parseTextList(data []byte) ([]domain.KnownWordEntry, error) {
+	entries := []domain.KnownWordEntry{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		lemma := strings.TrimSpace(scanner.Text())
+
+		if lemma == "" || strings.HasPrefix(lemma, "#") {
+			continue
+		}
+
+		entries = append(entries, domain.KnownWordEntry{Line: line, Lemma: lemma})
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return []domain.KnownWordEntry{}, fmt.Errorf("failed to read list: %w", err)
+	}
+
+	return entries, nil
+}
+
+// parseAnkiList reads a "Notes in Plain Text" export, whose headers give the
+// separator, the column names and the columns that hold the GUID, note type
+// and deck rather than fields. The lemma is taken from the first field.
+func parseAnkiList(data []byte) ([]domain.KnownWordEntry, error) {
+	separator := '\t'
+	skipped := map[int]bool{}
+	var header []string
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		match := ankiHeader.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if match == nil {
+			continue
+		}
+
+		name, value := match[1], match[2]
+
+		switch {
+		case name == "separator":
+			if s, ok := ankiSeparators[strings.ToLower(value)]; ok {
+				separator = s
+			}
+		case name == "columns":
+			header = strings.Split(value, string(separator))
+		case strings.HasSuffix(name, " column"):
+			column, err := strconv.Atoi(value)
+			if err == nil {
+				skipped[column-1] = true
+			}
+		}
+	}
+
+	return parseTable(data, separator, lemmaColumns, header, skipped)
+}
+
+// parseTable reads the lemmas from the column with one of the given names,
+// or else from the first column that is not skipped. The names are looked for
+// in the given header or, without one, in the first row, which is then
+// skipped if it contains one of them.
+func parseTable(data []byte, separator rune, names []string, header []string, skipped map[int]bool) ([]domain.KnownWordEntry, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = separator
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	entries := []domain.KnownWordEntry{}
+	column := -1
+
+	if header != nil {
+		column = lemmaColumn(header, names)
+	}
+
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return []domain.KnownWordEntry{}, fmt.Errorf("failed to read list: %w", err)
+		}
+
+		if first && header == nil {
+			column = lemmaColumn(record, names)
+
+			if column >= 0 {
+				continue
+			}
+		}
+
+		if column < 0 {
+			for column = 0; skipped[column]; column++ {
+			}
+		}
+
+		if column >= len(record) {
+			continue
+		}
+
+		lemma := strings.TrimSpace(domain.StripTags(record[column]))
+		if lemma == "" {
+			continue
+		}
+
+		line, _ := reader.FieldPos(column)
+		entries = append(entries, domain.KnownWordEntry{Line: line, Lemma: lemma})
+	}
+
+	return entries, nil
+}
+
+func lemmaColumn(header []string, names []string) int {
+	for _, name := range names {
+		for i, field := range header {
+			if strings.EqualFold(strings.TrimSpace(field), name) {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// separatorOf guesses whether a table is separated by tabs or commas from its
+// first line.
+func separatorOf(data []byte) rune {
+	first, _, _ := bytes.Cut(data, []byte("\n"))
+
+	if bytes.ContainsRune(first, '\t') {
+		return '\t'
+	}
+
+	return ','
+}
@@ -0,0 +1,70 @@
+package knownwords
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nienkeboomsma/vocabularium/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKnownWords(t *testing.T) {
+	tests := []struct {
+		name   string
+		format domain.KnownWordFormat
+		input  string
+		want   []domain.KnownWordEntry
+	}{
+		{
+			name:   "plain text skips blank lines and comments",
+			format: domain.KnownWordFormatText,
+			input:  "\ufeffamo\n\n# verbs\n  moneo, monere \n",
+			want:   []domain.KnownWordEntry{{Line: 1, Lemma: "amo"}, {Line: 4, Lemma: "moneo, monere"}},
+		},
+		{
+			name:   "CSV with a lemma column",
+			format: domain.KnownWordFormatCSV,
+			input:  "rank,lemma,translation\n1,sum,\"to be, exist\"\n2,\"qui, quae, quod\",who\n",
+			want:   []domain.KnownWordEntry{{Line: 2, Lemma: "sum"}, {Line: 3, Lemma: "qui, quae, quod"}},
+		},
+		{
+			name:   "CSV without a header uses the first column",
+			format: domain.KnownWordFormatCSV,
+			input:  "sum,to be\net,and\n",
+			want:   []domain.KnownWordEntry{{Line: 1, Lemma: "sum"}, {Line: 2, Lemma: "et"}},
+		},
+		{
+			name:   "TSV",
+			format: domain.KnownWordFormatCSV,
+			input:  "Word\tMeaning\nrex\tking\n",
+			want:   []domain.KnownWordEntry{{Line: 2, Lemma: "rex"}},
+		},
+		{
+			name:   "Anki export skips GUID and note type columns and strips HTML",
+			format: domain.KnownWordFormatAnki,
+			input:  "#separator:tab\n#html:true\n#guid column:1\n#notetype column:2\nabc\tBasic\t<b>am&amp;o</b>\tto love\ndef\tBasic\tmoneo\tto warn\n",
+			want:   []domain.KnownWordEntry{{Line: 5, Lemma: "am&o"}, {Line: 6, Lemma: "moneo"}},
+		},
+		{
+			name:   "Anki export with named columns",
+			format: domain.KnownWordFormatAnki,
+			input:  "#separator:Semicolon\n#columns:Translation;Lemma\nto love;amo\n",
+			want:   []domain.KnownWordEntry{{Line: 3, Lemma: "amo"}},
+		},
+		{
+			name:   "DCC core vocabulary",
+			format: domain.KnownWordFormatDCC,
+			input:  "Definition,Headword,Part of Speech\n\"be, exist\",\"sum, esse, fuī, futūrus\",Verb\n",
+			want:   []domain.KnownWordEntry{{Line: 2, Lemma: "sum, esse, fuī, futūrus"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseKnownWords(strings.NewReader(test.input), test.format)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
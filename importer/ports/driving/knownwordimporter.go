@@ -0,0 +1,13 @@
+package driving
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type KnownWordImporter interface {
+	Import(ctx context.Context, list io.Reader, format domain.KnownWordFormat, dryRun bool, now time.Time) (domain.KnownWordReport, error)
+}
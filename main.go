@@ -2,13 +2,26 @@ package main
 
 import (
 	"cmp"
+	"context"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	api "github.com/nienkeboomsma/vocabularium/api/infrastructure"
 	"github.com/nienkeboomsma/vocabularium/database"
+	"github.com/nienkeboomsma/vocabularium/domain"
+	"github.com/nienkeboomsma/vocabularium/exporter/infrastructure/anki"
+	"github.com/nienkeboomsma/vocabularium/exporter/infrastructure/epub"
+	"github.com/nienkeboomsma/vocabularium/exporter/infrastructure/latex"
+	"github.com/nienkeboomsma/vocabularium/exporter/infrastructure/typst"
+	exporter "github.com/nienkeboomsma/vocabularium/exporter/ports/driven"
+	"github.com/nienkeboomsma/vocabularium/importer/infrastructure/knownwords"
+	importer "github.com/nienkeboomsma/vocabularium/importer/ports/driving"
 	repositories "github.com/nienkeboomsma/vocabularium/repositories/infrastructure/postgres"
 	"github.com/nienkeboomsma/vocabularium/textprocessor/infrastructure/collatinus"
 	"github.com/nienkeboomsma/vocabularium/workpersister/infrastructure/postgres"
@@ -36,33 +49,200 @@ func main() {
 	}
 
 	authorRepository := repositories.NewAuthorRepository(db)
+	classRepository := repositories.NewClassRepository(db)
+	collectionRepository := repositories.NewCollectionRepository(db)
+	learningStateRepository := repositories.NewLearningStateRepository(db)
+	sectionRepository := repositories.NewSectionRepository(db)
+	userRepository := repositories.NewUserRepository(db)
 	workRepository := repositories.NewWorkRepository(db)
 	wordRepository := repositories.NewWordRepository(db)
-	workWordRepository := repositories.NewWorkWordRepository()
+	workWordRepository := repositories.NewWorkWordRepository(db)
 
-	wp := postgres.NewWorkPersister(db, authorRepository, workRepository, wordRepository, workWordRepository)
+	wp := postgres.NewWorkPersister(db, authorRepository, sectionRepository, workRepository, wordRepository, workWordRepository)
 
-	api := api.NewAPI(tp, wp, authorRepository, wordRepository, workRepository)
+	editionExporters := map[domain.EditionFormat]exporter.EditionExporter{
+		domain.EditionFormatLaTeX: latex.NewLaTeXExporter(),
+		domain.EditionFormatTypst: typst.NewTypstExporter(),
+		domain.EditionFormatEPUB:  epub.NewEPUBExporter(),
+	}
+
+	ki := knownwords.NewKnownWordImporter(learningStateRepository, wordRepository)
+
+	api := api.NewAPI(tp, wp, anki.NewAnkiExporter(), editionExporters, ki, authorRepository, classRepository, collectionRepository, learningStateRepository, sectionRepository, userRepository, wordRepository, workRepository, workWordRepository)
+
+	// The import-known command marks the words on a list as known, without
+	// starting the server.
+	if len(os.Args) > 1 && os.Args[1] == "import-known" {
+		err = importKnownWords(ki, userRepository, os.Args[2:])
+		if err != nil {
+			log.Fatal("Failed to import known words: " + err.Error())
+		}
+
+		return
+	}
 
 	mux := http.NewServeMux()
 
-	mux.HandleFunc("GET /frequency-list/{id}/{skipKnown}", api.GetFrequencyListByWork())
-	mux.HandleFunc("GET /frequency-list-author/{id}/{skipKnown}", api.GetFrequencyListByAuthor())
-	mux.HandleFunc("GET /frequency-list-corpus/{skipKnown}", api.GetFrequencyList())
-	mux.HandleFunc("GET /glossary/{id}/{skipKnown}", api.GetGlossaryByWork())
+	mux.HandleFunc("GET /actions", api.GetActions())
+	mux.HandleFunc("GET /class-glossary/{id}/{assignmentID}", api.GetClassGlossary())
+	mux.HandleFunc("GET /classes", api.GetClasses())
+	mux.HandleFunc("GET /classes/{id}", api.GetClass())
+	mux.HandleFunc("GET /collections", api.GetCollections())
+	mux.HandleFunc("GET /collections/{id}", api.GetCollection())
+	mux.HandleFunc("GET /compare", api.GetComparison())
+	mux.HandleFunc("GET /coverage/{id}", api.GetCoverageCurveByWork())
+	mux.HandleFunc("GET /coverage-author/{id}", api.GetCoverageCurveByAuthor())
+	mux.HandleFunc("GET /coverage-collection/{id}", api.GetCoverageCurveByCollection())
+	mux.HandleFunc("GET /coverage-corpus", api.GetCoverageCurve())
+	mux.HandleFunc("GET /coverage-section/{id}", api.GetCoverageCurveBySection())
+	mux.HandleFunc("GET /edit/{id}", api.EditWork())
+	mux.HandleFunc("GET /edition/{id}", api.GetEdition())
+	mux.HandleFunc("GET /frequency-list/{id}", api.GetFrequencyListByWork())
+	mux.HandleFunc("GET /frequency-list-author/{id}", api.GetFrequencyListByAuthor())
+	mux.HandleFunc("GET /frequency-list-collection/{id}", api.GetFrequencyListByCollection())
+	mux.HandleFunc("GET /frequency-list-corpus", api.GetFrequencyList())
+	mux.HandleFunc("GET /frequency-list-section/{id}", api.GetFrequencyListBySection())
+	mux.HandleFunc("GET /glossary/{id}", api.GetGlossaryByWork())
+	mux.HandleFunc("GET /glossary-collection/{id}", api.GetGlossaryByCollection())
+	mux.HandleFunc("GET /glossary-section/{id}", api.GetGlossaryBySection())
+	mux.HandleFunc("GET /history/{id}", api.GetWordHistory())
+	mux.HandleFunc("GET /import-known", api.UploadKnownWords())
+	mux.HandleFunc("GET /keyness/{id}", api.GetKeynessByWork())
+	mux.HandleFunc("GET /keyness-author/{id}", api.GetKeynessByAuthor())
+	mux.HandleFunc("GET /keyness-collection/{id}", api.GetKeynessByCollection())
+	mux.HandleFunc("GET /keyness-section/{id}", api.GetKeynessBySection())
+	mux.HandleFunc("GET /login", api.GetLogin())
+	mux.HandleFunc("GET /progress", api.GetProgress())
+	mux.HandleFunc("GET /read/{id}", api.GetReader())
+	mux.HandleFunc("GET /recommendations", api.GetRecommendations())
+	mux.HandleFunc("GET /register", api.GetRegistration())
+	mux.HandleFunc("GET /review", api.GetReview())
+	mux.HandleFunc("GET /sections/{id}", api.GetSections())
+	mux.HandleFunc("GET /statistics", api.GetStatistics())
 	mux.HandleFunc("GET /upload", api.Upload())
 	mux.HandleFunc("GET /", api.GetWorks())
 
-	mux.HandleFunc("POST /lemmatise", api.Lemmatise())
+	mux.HandleFunc("GET /api/classes/{id}", api.GetClassAsJSON())
+	mux.HandleFunc("GET /api/compare", api.GetComparisonAsJSON())
+	mux.HandleFunc("GET /api/keyness/{id}", api.GetKeynessByWorkAsJSON())
+	mux.HandleFunc("GET /api/keyness-author/{id}", api.GetKeynessByAuthorAsJSON())
+	mux.HandleFunc("GET /api/keyness-collection/{id}", api.GetKeynessByCollectionAsJSON())
+	mux.HandleFunc("GET /api/keyness-section/{id}", api.GetKeynessBySectionAsJSON())
+	mux.HandleFunc("GET /api/learning-state/{id}", api.GetLearningStateAsJSON())
+	mux.HandleFunc("GET /api/progress", api.GetProgressAsJSON())
+	mux.HandleFunc("GET /api/sections/{id}", api.GetSectionsAsJSON())
+	mux.HandleFunc("GET /api/statistics", api.GetStatisticsAsJSON())
+
+	mux.HandleFunc("POST /class-assignments/{id}", api.AssignToClass())
+	mux.HandleFunc("POST /classes", api.SaveClass())
+	mux.HandleFunc("POST /classes/{id}", api.SaveClass())
+	mux.HandleFunc("POST /collections", api.SaveCollection())
+	mux.HandleFunc("POST /collections/{id}", api.SaveCollection())
+	mux.HandleFunc("POST /delete-class-assignment/{id}/{assignmentID}", api.DeleteClassAssignment())
+	mux.HandleFunc("POST /delete-class-student/{id}/{studentID}", api.RemoveClassStudent())
+	mux.HandleFunc("POST /delete-class/{id}", api.DeleteClass())
+	mux.HandleFunc("POST /delete-collection/{id}", api.DeleteCollection())
 	mux.HandleFunc("POST /delete/{id}", api.DeleteWork())
+	mux.HandleFunc("POST /edit/{id}", api.UpdateWorkMetadata())
+	mux.HandleFunc("POST /import-known", api.ImportKnownWords())
+	mux.HandleFunc("POST /join-class", api.JoinClass())
+	mux.HandleFunc("POST /learning-status/{id}", api.SetLearningStatus())
+	mux.HandleFunc("POST /leave-class/{id}", api.LeaveClass())
+	mux.HandleFunc("POST /lemmatise", api.Lemmatise())
+	mux.HandleFunc("POST /login", api.LogIn())
+	mux.HandleFunc("POST /logout", api.LogOut())
+	mux.HandleFunc("POST /mark-known", api.MarkKnown())
+	mux.HandleFunc("POST /reading-position/{id}", api.SaveReadingPosition())
+	mux.HandleFunc("POST /register", api.Register())
+	mux.HandleFunc("POST /review-seed", api.SeedReviewQueue())
+	mux.HandleFunc("POST /review/{id}", api.GradeFlashcard())
 	mux.HandleFunc("POST /toggle-known-status/{id}", api.ToggleKnownStatus())
+	mux.HandleFunc("POST /toggle-read-status/{id}", api.ToggleReadStatus())
+	mux.HandleFunc("POST /undo", api.UndoLatestActions())
+	mux.HandleFunc("POST /undo/{id}", api.UndoAction())
 
 	// TODO: set ports via .env
 	fmt.Println("Listening at :4321")
 
-	err = http.ListenAndServe(":4321", mux)
+	err = http.ListenAndServe(":4321", api.Authenticate(mux))
 	if err != nil {
 		log.Fatal("Failed to start server: " + err.Error())
 	}
 
 }
+
+// importKnownWords imports the lists of known words in the given files, or on
+// standard input if there are none, for the given user, and prints the entries
+// that need resolving by hand.
+func importKnownWords(ki importer.KnownWordImporter, users *repositories.UserRepository, args []string) error {
+	flags := flag.NewFlagSet("import-known", flag.ExitOnError)
+	userName := flags.String("user", "", "name of the user whose words are marked as known")
+	format := flags.String("format", string(domain.KnownWordFormatText), "format of the lists: text, csv, anki or dcc")
+	dryRun := flags.Bool("dry-run", false, "only report what would be marked as known")
+	flags.Parse(args)
+
+	user, err := users.GetByName(context.Background(), *userName)
+	if err != nil {
+		return fmt.Errorf("failed to find user %q: %w", *userName, err)
+	}
+
+	ctx := domain.ContextWithUser(context.Background(), user)
+
+	listFormat, err := domain.ParseKnownWordFormat(*format)
+	if err != nil {
+		return err
+	}
+
+	lists := map[string]io.Reader{}
+	names := flags.Args()
+
+	if len(names) == 0 {
+		names = []string{"-"}
+		lists["-"] = os.Stdin
+	}
+
+	for _, name := range names {
+		if name == "-" {
+			continue
+		}
+
+		file, err := os.Open(name)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", name, err)
+		}
+		defer file.Close()
+
+		lists[name] = file
+	}
+
+	for _, name := range names {
+		report, err := ki.Import(ctx, lists[name], listFormat, *dryRun, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to import %s: %w", name, err)
+		}
+
+		action := "marked"
+
+		if *dryRun {
+			action = "would be marked"
+		}
+
+		fmt.Printf("%s: %d entries, %d words matched, %d %s as known\n", name, report.Entries, len(report.Matched), report.Marked, action)
+
+		for _, entry := range report.Ambiguous {
+			candidates := make([]string, 0, len(entry.Candidates))
+
+			for _, word := range entry.Candidates {
+				candidates = append(candidates, word.LemmaRaw+" ("+word.LemmaRich+")")
+			}
+
+			fmt.Printf("  line %d: %s is ambiguous: %s\n", entry.Line, entry.Lemma, strings.Join(candidates, "; "))
+		}
+
+		for _, entry := range report.Unmatched {
+			fmt.Printf("  line %d: %s is not in the corpus\n", entry.Line, entry.Lemma)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,429 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/nienkeboomsma/vocabularium/database"
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type ClassRepository struct {
+	db *database.Client
+}
+
+func NewClassRepository(db *database.Client) *ClassRepository {
+	return &ClassRepository{db: db}
+}
+
+// Delete removes the class, provided the user is its instructor.
+func (cr *ClassRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	q := `
+	UPDATE class
+	SET deleted_at = NOW()
+	WHERE id = $1
+	AND instructor_id = $2
+	AND deleted_at IS NULL;
+	`
+
+	_, err := cr.db.Pool.Exec(ctx, q, id, currentUserID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+func (cr *ClassRepository) DeleteAssignment(ctx context.Context, classID, id uuid.UUID) error {
+	q := `
+	DELETE FROM class_assignment
+	WHERE id = $2
+	AND class_id = $1;
+	`
+
+	_, err := cr.db.Pool.Exec(ctx, q, classID, id)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the classes the user teaches or is a student in, without their
+// students and assignments.
+func (cr *ClassRepository) Get(ctx context.Context) ([]domain.Class, error) {
+	return cr.get(ctx, "")
+}
+
+// GetByID returns the class with its students, ordered by name, and its
+// assignments, oldest first, provided the user teaches it or is a student in
+// it.
+func (cr *ClassRepository) GetByID(ctx context.Context, id uuid.UUID) (domain.Class, error) {
+	classes, err := cr.get(ctx, "AND c.id = $2", id)
+	if err != nil {
+		return domain.Class{}, err
+	}
+
+	if len(classes) == 0 {
+		return domain.Class{}, fmt.Errorf("class %s not found", id)
+	}
+
+	class := classes[0]
+
+	class.Students, err = cr.getStudents(ctx, id)
+	if err != nil {
+		return domain.Class{}, err
+	}
+
+	class.Assignments, err = cr.getAssignments(ctx, id)
+	if err != nil {
+		return domain.Class{}, err
+	}
+
+	return class, nil
+}
+
+// GetCoverage returns, for every assignment of the class and every student,
+// the number of running words in the assignment and the number of those the
+// student knows.
+func (cr *ClassRepository) GetCoverage(ctx context.Context, classID uuid.UUID) ([]domain.StudentCoverage, error) {
+	q := fmt.Sprintf(`
+	SELECT ca.id, cs.user_id, COUNT(ww.id), COUNT(ww.id) FILTER (WHERE %s)
+	FROM class_assignment ca
+	JOIN class_student cs
+	ON cs.class_id = ca.class_id
+	LEFT JOIN section s
+	ON s.id = ca.section_id
+	JOIN work_word ww
+	ON ww.work_id = ca.work_id
+	AND ww.deleted_at IS NULL
+	AND (s.id IS NULL OR ww.word_index BETWEEN s.first_word_index AND s.last_word_index)
+	WHERE ca.class_id = $1
+	GROUP BY ca.id, cs.user_id;
+	`, knownByCondition("ww.word_id", "cs.user_id"))
+
+	coverage := []domain.StudentCoverage{}
+
+	rows, err := cr.db.Pool.Query(ctx, q, classID)
+	if err != nil {
+		return []domain.StudentCoverage{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c domain.StudentCoverage
+
+		err = rows.Scan(&c.AssignmentID, &c.StudentID, &c.TokenCount, &c.KnownTokenCount)
+		if err != nil {
+			return []domain.StudentCoverage{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		coverage = append(coverage, c)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []domain.StudentCoverage{}, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return coverage, nil
+}
+
+// GetKnownBy returns, for every lemma in the scope, the number of students of
+// the class who know it.
+func (cr *ClassRepository) GetKnownBy(ctx context.Context, classID uuid.UUID, scope domain.Scope) (map[uuid.UUID]int, error) {
+	qb := &queryBuilder{}
+	class := qb.arg(classID)
+	qb.filterScope(scope)
+
+	q := fmt.Sprintf(`
+	SELECT sw.word_id, COUNT(ls.user_id)
+	FROM (
+		SELECT DISTINCT ww.word_id
+		FROM work_word ww
+		JOIN work
+		ON work.id = ww.work_id
+		JOIN author a
+		ON a.id = work.author_id
+		WHERE ww.deleted_at IS NULL
+		AND work.deleted_at IS NULL
+		%s
+	) sw
+	LEFT JOIN learning_state ls
+	ON ls.word_id = sw.word_id
+	AND ls.status IN (%s)
+	AND ls.user_id IN (SELECT cs.user_id FROM class_student cs WHERE cs.class_id = %s)
+	GROUP BY sw.word_id;
+	`, qb.conditions(), knownStatuses(), class)
+
+	knownBy := map[uuid.UUID]int{}
+
+	rows, err := cr.db.Pool.Query(ctx, q, qb.args...)
+	if err != nil {
+		return map[uuid.UUID]int{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var wordID uuid.UUID
+		var count int
+
+		err = rows.Scan(&wordID, &count)
+		if err != nil {
+			return map[uuid.UUID]int{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		knownBy[wordID] = count
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return map[uuid.UUID]int{}, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return knownBy, nil
+}
+
+// Join makes the user a student of the class with the given join code and
+// returns its ID. Instructors cannot join their own classes.
+func (cr *ClassRepository) Join(ctx context.Context, code string) (uuid.UUID, error) {
+	q := `
+	SELECT id, instructor_id
+	FROM class
+	WHERE join_code = $1
+	AND deleted_at IS NULL;
+	`
+
+	var id, instructorID uuid.UUID
+
+	err := cr.db.Pool.QueryRow(ctx, q, code).Scan(&id, &instructorID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, fmt.Errorf("no class has join code %q", code)
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	if instructorID == currentUserID(ctx) {
+		return uuid.Nil, fmt.Errorf("class %s is taught by the user", id)
+	}
+
+	q = `
+	INSERT INTO class_student (class_id, user_id)
+	VALUES ($1, $2)
+	ON CONFLICT DO NOTHING;
+	`
+
+	_, err = cr.db.Pool.Exec(ctx, q, id, currentUserID(ctx))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return id, nil
+}
+
+// Leave removes the user from the students of the class.
+func (cr *ClassRepository) Leave(ctx context.Context, classID uuid.UUID) error {
+	return cr.RemoveStudent(ctx, classID, currentUserID(ctx))
+}
+
+func (cr *ClassRepository) RemoveStudent(ctx context.Context, classID, userID uuid.UUID) error {
+	q := `
+	DELETE FROM class_student
+	WHERE class_id = $1
+	AND user_id = $2;
+	`
+
+	_, err := cr.db.Pool.Exec(ctx, q, classID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+// Save creates a class taught by the user, or renames one they teach. The join
+// code is only set when the class is created.
+func (cr *ClassRepository) Save(ctx context.Context, c domain.Class) (domain.Class, error) {
+	q := `
+	INSERT INTO class (id, name, instructor_id, join_code, modified_at)
+	VALUES ($1, $2, $3, $4, DEFAULT)
+	ON CONFLICT (id) DO UPDATE
+	SET name = $2, modified_at = DEFAULT
+	WHERE class.instructor_id = $3
+	AND class.deleted_at IS NULL
+	RETURNING id, name, instructor_id, join_code, created_at, modified_at;
+	`
+
+	var class domain.Class
+
+	err := cr.db.Pool.QueryRow(ctx, q, c.ID, c.Name, currentUserID(ctx), c.JoinCode).Scan(
+		&class.ID,
+		&class.Name,
+		&class.InstructorID,
+		&class.JoinCode,
+		&class.Created,
+		&class.Modified,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Class{}, fmt.Errorf("class %s is not taught by the user", c.ID)
+	}
+	if err != nil {
+		return domain.Class{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return class, nil
+}
+
+func (cr *ClassRepository) SaveAssignment(ctx context.Context, a domain.Assignment) (domain.Assignment, error) {
+	q := `
+	INSERT INTO class_assignment (id, class_id, work_id, section_id)
+	VALUES ($1, $2, $3, $4)
+	RETURNING created_at;
+	`
+
+	sectionID := uuid.NullUUID{UUID: a.SectionID, Valid: a.SectionID != uuid.Nil}
+
+	err := cr.db.Pool.QueryRow(ctx, q, a.ID, a.ClassID, a.WorkID, sectionID).Scan(&a.Created)
+	if err != nil {
+		return domain.Assignment{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return a, nil
+}
+
+// get returns the classes matching the condition that the user teaches or is
+// a student in. The join code is left empty for students.
+func (cr *ClassRepository) get(ctx context.Context, condition string, args ...any) ([]domain.Class, error) {
+	q := fmt.Sprintf(`
+	SELECT c.id, c.name, c.instructor_id, CASE WHEN c.instructor_id = $1 THEN c.join_code ELSE '' END, c.created_at, c.modified_at
+	FROM class c
+	WHERE c.deleted_at IS NULL
+	AND (c.instructor_id = $1 OR EXISTS (SELECT 1 FROM class_student cs WHERE cs.class_id = c.id AND cs.user_id = $1))
+	%s
+	ORDER BY c.name ASC, c.id;
+	`, condition)
+
+	classes := []domain.Class{}
+
+	rows, err := cr.db.Pool.Query(ctx, q, append([]any{currentUserID(ctx)}, args...)...)
+	if err != nil {
+		return []domain.Class{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var class domain.Class
+
+		err = rows.Scan(&class.ID, &class.Name, &class.InstructorID, &class.JoinCode, &class.Created, &class.Modified)
+		if err != nil {
+			return []domain.Class{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		class.Students = []domain.User{}
+		class.Assignments = []domain.Assignment{}
+		classes = append(classes, class)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []domain.Class{}, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return classes, nil
+}
+
+// getStudents returns the students of the class without their password
+// hashes.
+func (cr *ClassRepository) getStudents(ctx context.Context, classID uuid.UUID) ([]domain.User, error) {
+	q := `
+	SELECT u.id, u.name, u.created_at
+	FROM class_student cs
+	JOIN app_user u
+	ON u.id = cs.user_id
+	WHERE cs.class_id = $1
+	ORDER BY u.name ASC;
+	`
+
+	students := []domain.User{}
+
+	rows, err := cr.db.Pool.Query(ctx, q, classID)
+	if err != nil {
+		return []domain.User{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var student domain.User
+
+		err = rows.Scan(&student.ID, &student.Name, &student.Created)
+		if err != nil {
+			return []domain.User{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		students = append(students, student)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []domain.User{}, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return students, nil
+}
+
+func (cr *ClassRepository) getAssignments(ctx context.Context, classID uuid.UUID) ([]domain.Assignment, error) {
+	q := `
+	SELECT ca.id, ca.class_id, ca.work_id, ca.section_id, work.title, a.name, COALESCE(s.citation, ''), ca.created_at
+	FROM class_assignment ca
+	JOIN work
+	ON work.id = ca.work_id
+	JOIN author a
+	ON a.id = work.author_id
+	LEFT JOIN section s
+	ON s.id = ca.section_id
+	WHERE ca.class_id = $1
+	AND work.deleted_at IS NULL
+	ORDER BY ca.created_at ASC;
+	`
+
+	assignments := []domain.Assignment{}
+
+	rows, err := cr.db.Pool.Query(ctx, q, classID)
+	if err != nil {
+		return []domain.Assignment{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var assignment domain.Assignment
+		var sectionID uuid.NullUUID
+
+		err = rows.Scan(
+			&assignment.ID,
+			&assignment.ClassID,
+			&assignment.WorkID,
+			&sectionID,
+			&assignment.Title,
+			&assignment.Author,
+			&assignment.Citation,
+			&assignment.Created,
+		)
+		if err != nil {
+			return []domain.Assignment{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		assignment.SectionID = sectionID.UUID
+		assignments = append(assignments, assignment)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []domain.Assignment{}, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return assignments, nil
+}
@@ -0,0 +1,207 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/nienkeboomsma/vocabularium/database"
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type CollectionRepository struct {
+	db *database.Client
+}
+
+func NewCollectionRepository(db *database.Client) *CollectionRepository {
+	return &CollectionRepository{db: db}
+}
+
+// Delete removes the collection, provided it belongs to the user.
+func (cr *CollectionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	q := `
+	UPDATE collection
+	SET deleted_at = NOW()
+	WHERE id = $1
+	AND user_id = $2
+	AND deleted_at IS NULL;
+	`
+
+	_, err := cr.db.Pool.Exec(ctx, q, id, currentUserID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+func (cr *CollectionRepository) Get(ctx context.Context) ([]domain.Collection, error) {
+	return cr.get(ctx, "")
+}
+
+func (cr *CollectionRepository) GetByID(ctx context.Context, id uuid.UUID) (domain.Collection, error) {
+	collections, err := cr.get(ctx, "AND c.id = $2", id)
+	if err != nil {
+		return domain.Collection{}, err
+	}
+
+	if len(collections) == 0 {
+		return domain.Collection{}, fmt.Errorf("collection %s not found", id)
+	}
+
+	return collections[0], nil
+}
+
+// Save creates a collection owned by the user, or renames one they own, and
+// replaces its works, which must all exist and not be deleted.
+func (cr *CollectionRepository) Save(ctx context.Context, c domain.Collection) (domain.Collection, error) {
+	tx, err := cr.db.Pool.Begin(ctx)
+	if err != nil {
+		return domain.Collection{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	workIDs := slices.Clone(c.WorkIDs)
+	slices.SortFunc(workIDs, func(a, b uuid.UUID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	workIDs = slices.Compact(workIDs)
+
+	q := `
+	SELECT COUNT(*)
+	FROM work
+	WHERE id = ANY($1)
+	AND deleted_at IS NULL;
+	`
+
+	var liveWorks int
+
+	err = tx.QueryRow(ctx, q, workIDs).Scan(&liveWorks)
+	if err != nil {
+		return domain.Collection{}, fmt.Errorf("failed to execute work query: %w", err)
+	}
+
+	if liveWorks != len(workIDs) {
+		return domain.Collection{}, fmt.Errorf("collection %s contains %d unknown or deleted works", c.ID, len(workIDs)-liveWorks)
+	}
+
+	q = `
+	INSERT INTO collection (id, name, user_id, modified_at)
+	VALUES ($1, $2, $3, DEFAULT)
+	ON CONFLICT (id) DO UPDATE
+	SET name = $2, modified_at = DEFAULT
+	WHERE collection.user_id = $3
+	AND collection.deleted_at IS NULL
+	RETURNING id, name, created_at, modified_at, deleted_at;
+	`
+
+	var collection domain.Collection
+	var deleted sql.NullTime
+
+	err = tx.QueryRow(ctx, q, c.ID, c.Name, currentUserID(ctx)).Scan(
+		&collection.ID,
+		&collection.Name,
+		&collection.Created,
+		&collection.Modified,
+		&deleted,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Collection{}, fmt.Errorf("collection %s does not belong to the user", c.ID)
+	}
+	if err != nil {
+		return domain.Collection{}, fmt.Errorf("failed to execute collection query: %w", err)
+	}
+
+	collection.Deleted = deleted.Time
+
+	q = `
+	DELETE FROM collection_work
+	WHERE collection_id = $1;
+	`
+
+	_, err = tx.Exec(ctx, q, c.ID)
+	if err != nil {
+		return domain.Collection{}, fmt.Errorf("failed to execute collection_work query: %w", err)
+	}
+
+	q = `
+	INSERT INTO collection_work (collection_id, work_id)
+	VALUES ($1, $2)
+	ON CONFLICT DO NOTHING;
+	`
+
+	for _, workID := range workIDs {
+		_, err = tx.Exec(ctx, q, c.ID, workID)
+		if err != nil {
+			return domain.Collection{}, fmt.Errorf("failed to execute collection_work query: %w", err)
+		}
+
+		collection.WorkIDs = append(collection.WorkIDs, workID)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.Collection{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return collection, nil
+}
+
+// get returns the user's collections matching the condition with one row per
+// work, which are combined into a single domain.Collection each. The user is
+// bound to $1, so the condition's placeholders start at $2.
+func (cr *CollectionRepository) get(ctx context.Context, condition string, args ...any) ([]domain.Collection, error) {
+	q := fmt.Sprintf(`
+	SELECT c.id, c.name, c.created_at, c.modified_at, work.id
+	FROM collection c
+	LEFT JOIN collection_work cw
+	ON cw.collection_id = c.id
+	LEFT JOIN work
+	ON work.id = cw.work_id
+	AND work.deleted_at IS NULL
+	WHERE c.deleted_at IS NULL
+	AND c.user_id = $1
+	%s
+	ORDER BY c.name ASC, c.id;
+	`, condition)
+
+	collections := []domain.Collection{}
+
+	rows, err := cr.db.Pool.Query(ctx, q, append([]any{currentUserID(ctx)}, args...)...)
+	if err != nil {
+		return []domain.Collection{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var collection domain.Collection
+		var workID uuid.NullUUID
+
+		err = rows.Scan(&collection.ID, &collection.Name, &collection.Created, &collection.Modified, &workID)
+		if err != nil {
+			return []domain.Collection{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		if len(collections) == 0 || collections[len(collections)-1].ID != collection.ID {
+			collection.WorkIDs = []uuid.UUID{}
+			collections = append(collections, collection)
+		}
+
+		if workID.Valid {
+			last := &collections[len(collections)-1]
+			last.WorkIDs = append(last.WorkIDs, workID.UUID)
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []domain.Collection{}, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return collections, nil
+}
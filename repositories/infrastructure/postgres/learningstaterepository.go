@@ -0,0 +1,925 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/nienkeboomsma/vocabularium/database"
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type LearningStateRepository struct {
+	db *database.Client
+}
+
+func NewLearningStateRepository(db *database.Client) *LearningStateRepository {
+	return &LearningStateRepository{db: db}
+}
+
+// CountKnown returns the number of words whose learning status counts as
+// known to the user.
+func (lr *LearningStateRepository) CountKnown(ctx context.Context) (int, error) {
+	q := fmt.Sprintf(`
+	SELECT COUNT(*)
+	FROM learning_state
+	WHERE user_id = $1
+	AND status IN (%s);
+	`, knownStatuses())
+
+	var count int
+
+	err := lr.db.Pool.QueryRow(ctx, q, currentUserID(ctx)).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return count, nil
+}
+
+// Enqueue adds the new words among the given ones to the review queue, in
+// the given order, until limit words have been added. Their due times are a
+// millisecond apart, so that the queue keeps that order. It returns the
+// number of words added; they are added as a single action.
+func (lr *LearningStateRepository) Enqueue(ctx context.Context, wordIDs []uuid.UUID, limit int, now time.Time) (int, error) {
+	tx, err := lr.db.Pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	action := domain.StatusAction{
+		ID:          uuid.New(),
+		Source:      domain.StatusSourceReview,
+		Description: "Added to the review queue",
+		Status:      domain.LearningStatusLearning,
+		Created:     now,
+	}
+
+	err = lr.saveAction(ctx, tx, action)
+	if err != nil {
+		return 0, err
+	}
+
+	added := 0
+
+	for _, wordID := range wordIDs {
+		if added == limit {
+			break
+		}
+
+		state, err := lr.get(ctx, tx, wordID, "FOR UPDATE")
+		if err != nil {
+			return 0, err
+		}
+
+		next, ok := state.Enqueue(now.Add(time.Duration(added) * time.Millisecond))
+		if !ok {
+			continue
+		}
+
+		err = lr.saveChange(ctx, tx, action.ID, state, next.Status)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = lr.save(ctx, tx, next)
+		if err != nil {
+			return 0, err
+		}
+
+		added++
+	}
+
+	if added == 0 {
+		return 0, nil
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return added, nil
+}
+
+// GetDue returns up to limit flashcards that are due, the earliest first,
+// along with the total number of due cards. The example on each card is the
+// first occurrence of the word in the corpus or, if a work is given, in that
+// work; words that do not occur in the work are then left out.
+func (lr *LearningStateRepository) GetDue(ctx context.Context, workID uuid.UUID, limit int, now time.Time) ([]domain.Flashcard, int, error) {
+	qb := &queryBuilder{}
+	due := qb.arg(now)
+	user := qb.arg(currentUserID(ctx))
+
+	if workID != uuid.Nil {
+		qb.where("ww.work_id = %s", workID)
+	}
+
+	q := fmt.Sprintf(`
+	SELECT w.id, w.lemma_raw, w.lemma_rich, w.translation, COALESCE(w.lasla_frequency, 0),
+		ls.status, ls.due_at, ls.stability, ls.difficulty, ls.repetitions, ls.lapses, ls.last_reviewed_at, ls.created_at, ls.modified_at,
+		ex.id, ex.work_id, ex.word_index, ex.sentence_index, ex.original_form, ex.tag, ex.morph_analysis, ex.title, ex.author_name
+	FROM learning_state ls
+	JOIN word w
+	ON w.id = ls.word_id
+	JOIN LATERAL (
+		SELECT ww.id, ww.work_id, ww.word_index, ww.sentence_index, ww.original_form, ww.tag, ww.morph_analysis,
+			work.title, a.name AS author_name
+		FROM work_word ww
+		JOIN work
+		ON work.id = ww.work_id
+		JOIN author a
+		ON a.id = work.author_id
+		WHERE ww.word_id = w.id
+		AND ww.deleted_at IS NULL
+		AND work.deleted_at IS NULL
+		%s
+		ORDER BY a.name ASC, work.title ASC, ww.word_index ASC
+		LIMIT 1
+	) ex
+	ON TRUE
+	WHERE ls.user_id = %s
+	AND ls.status IN ('learning', 'review')
+	AND ls.due_at <= %s
+	`, qb.conditions(), user, due)
+
+	total := 0
+
+	err := lr.db.Pool.QueryRow(ctx, fmt.Sprintf("SELECT COUNT(*) FROM (%s) d;", q), qb.args...).Scan(&total)
+	if err != nil {
+		return []domain.Flashcard{}, 0, fmt.Errorf("failed to count due cards: %w", err)
+	}
+
+	q = fmt.Sprintf("%s\tORDER BY ls.due_at ASC\n\tLIMIT %s;", q, qb.arg(limit))
+
+	cards := []domain.Flashcard{}
+
+	rows, err := lr.db.Pool.Query(ctx, q, qb.args...)
+	if err != nil {
+		return []domain.Flashcard{}, 0, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var card domain.Flashcard
+		var due, lastReviewed sql.NullTime
+
+		err = rows.Scan(
+			&card.ID,
+			&card.LemmaRaw,
+			&card.LemmaRich,
+			&card.Translation,
+			&card.FrequencyInLASLA,
+			&card.State.Status,
+			&due,
+			&card.State.Stability,
+			&card.State.Difficulty,
+			&card.State.Repetitions,
+			&card.State.Lapses,
+			&lastReviewed,
+			&card.State.Created,
+			&card.State.Modified,
+			&card.Example.ID,
+			&card.Example.WorkID,
+			&card.Example.WordIndex,
+			&card.Example.SentenceIndex,
+			&card.Example.OriginalForm,
+			&card.Example.Tag,
+			&card.Example.MorphoSyntacticalAnalysis,
+			&card.Title,
+			&card.Author,
+		)
+		if err != nil {
+			return []domain.Flashcard{}, 0, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		card.State.WordID = card.ID
+		card.State.Due = due.Time
+		card.State.LastReviewed = lastReviewed.Time
+		card.Known = card.State.Status.IsKnown()
+		card.Example.WordID = card.ID
+
+		cards = append(cards, card)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []domain.Flashcard{}, 0, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return cards, total, nil
+}
+
+// GetByWordID returns the learning state of the word; words that have never
+// been reviewed or marked are new.
+func (lr *LearningStateRepository) GetByWordID(ctx context.Context, wordID uuid.UUID) (domain.LearningState, error) {
+	return lr.get(ctx, lr.db.Pool, wordID, "")
+}
+
+// GetDailyProgress returns, for every action that changed whether words count
+// as known, the number of words that became known and the number that stopped
+// being known, oldest first. Actions that have been undone are left out. The
+// actions keep their own time rather than being grouped by day here, since
+// the day they fall on depends on the time zone of the caller; see
+// domain.NewProgress.
+func (lr *LearningStateRepository) GetDailyProgress(ctx context.Context) ([]domain.DailyProgress, error) {
+	q := fmt.Sprintf(`
+	SELECT a.created_at,
+		COUNT(*) FILTER (WHERE c.status IN (%[1]s) AND c.previous_status NOT IN (%[1]s)) AS learned,
+		COUNT(*) FILTER (WHERE c.status NOT IN (%[1]s) AND c.previous_status IN (%[1]s)) AS forgotten
+	FROM status_change c
+	JOIN status_action a
+	ON a.id = c.action_id
+	WHERE a.user_id = $1
+	AND a.undone_at IS NULL
+	AND (c.status IN (%[1]s)) <> (c.previous_status IN (%[1]s))
+	GROUP BY a.id
+	ORDER BY a.created_at ASC;
+	`, knownStatuses())
+
+	days := []domain.DailyProgress{}
+
+	rows, err := lr.db.Pool.Query(ctx, q, currentUserID(ctx))
+	if err != nil {
+		return []domain.DailyProgress{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var day domain.DailyProgress
+
+		err = rows.Scan(&day.Day, &day.Learned, &day.Forgotten)
+		if err != nil {
+			return []domain.DailyProgress{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		days = append(days, day)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []domain.DailyProgress{}, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return days, nil
+}
+
+// GetHistory returns every change of the learning status of the word, oldest
+// first, including those that have been undone.
+func (lr *LearningStateRepository) GetHistory(ctx context.Context, wordID uuid.UUID) ([]domain.StatusChange, error) {
+	q := `
+	SELECT c.action_id, c.word_id, a.source, a.description, c.previous_status, c.status, a.created_at, a.undone_at
+	FROM status_change c
+	JOIN status_action a
+	ON a.id = c.action_id
+	WHERE c.word_id = $1
+	AND a.user_id = $2
+	ORDER BY a.created_at ASC;
+	`
+
+	changes := []domain.StatusChange{}
+
+	rows, err := lr.db.Pool.Query(ctx, q, wordID, currentUserID(ctx))
+	if err != nil {
+		return []domain.StatusChange{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var change domain.StatusChange
+		var undone sql.NullTime
+
+		err = rows.Scan(
+			&change.ActionID,
+			&change.WordID,
+			&change.Source,
+			&change.Description,
+			&change.PreviousStatus,
+			&change.Status,
+			&change.Changed,
+			&undone,
+		)
+		if err != nil {
+			return []domain.StatusChange{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		change.Undone = undone.Time
+		changes = append(changes, change)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []domain.StatusChange{}, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return changes, nil
+}
+
+// GetReviews returns the review history of the word, oldest first.
+func (lr *LearningStateRepository) GetReviews(ctx context.Context, wordID uuid.UUID) ([]domain.Review, error) {
+	q := `
+	SELECT id, word_id, grade, previous_status, status, stability, difficulty, due_at, reviewed_at
+	FROM review
+	WHERE word_id = $1
+	AND user_id = $2
+	ORDER BY reviewed_at ASC;
+	`
+
+	reviews := []domain.Review{}
+
+	rows, err := lr.db.Pool.Query(ctx, q, wordID, currentUserID(ctx))
+	if err != nil {
+		return []domain.Review{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var review domain.Review
+		var due sql.NullTime
+
+		err = rows.Scan(
+			&review.ID,
+			&review.WordID,
+			&review.Grade,
+			&review.PreviousStatus,
+			&review.Status,
+			&review.Stability,
+			&review.Difficulty,
+			&due,
+			&review.Reviewed,
+		)
+		if err != nil {
+			return []domain.Review{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		review.Due = due.Time
+		reviews = append(reviews, review)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []domain.Review{}, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return reviews, nil
+}
+
+// MarkKnown marks the given words as known within a single transaction, as
+// one action that can be undone as a whole. Words that already count as known
+// are left as they are; if that leaves nothing to change, no action is saved.
+func (lr *LearningStateRepository) MarkKnown(ctx context.Context, wordIDs []uuid.UUID, source domain.StatusSource, description string, now time.Time) (domain.StatusAction, error) {
+	tx, err := lr.db.Pool.Begin(ctx)
+	if err != nil {
+		return domain.StatusAction{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	action := domain.StatusAction{
+		ID:          uuid.New(),
+		Source:      source,
+		Description: description,
+		Status:      domain.LearningStatusKnown,
+		Created:     now,
+	}
+
+	err = lr.saveAction(ctx, tx, action)
+	if err != nil {
+		return domain.StatusAction{}, err
+	}
+
+	for _, wordID := range wordIDs {
+		state, err := lr.get(ctx, tx, wordID, "FOR UPDATE")
+		if err != nil {
+			return domain.StatusAction{}, err
+		}
+
+		if state.Status.IsKnown() {
+			continue
+		}
+
+		err = lr.saveChange(ctx, tx, action.ID, state, domain.LearningStatusKnown)
+		if err != nil {
+			return domain.StatusAction{}, err
+		}
+
+		_, err = lr.save(ctx, tx, state.WithStatus(domain.LearningStatusKnown, now))
+		if err != nil {
+			return domain.StatusAction{}, err
+		}
+
+		action.WordCount++
+	}
+
+	if action.WordCount == 0 {
+		return action, nil
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.StatusAction{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return action, nil
+}
+
+// GetActions returns the most recent actions, the latest first. Actions
+// without a description of their own, such as a word marked by hand, are
+// described by the lemmas they changed.
+func (lr *LearningStateRepository) GetActions(ctx context.Context, limit int) ([]domain.StatusAction, error) {
+	q := `
+	SELECT a.id, a.source, COALESCE(NULLIF(a.description, ''), string_agg(w.lemma_raw, ', ' ORDER BY w.lemma_raw), ''),
+		a.status, COUNT(c.word_id), a.created_at, a.undone_at
+	FROM status_action a
+	LEFT JOIN status_change c
+	ON c.action_id = a.id
+	LEFT JOIN word w
+	ON w.id = c.word_id
+	WHERE a.user_id = $2
+	GROUP BY a.id
+	ORDER BY a.created_at DESC
+	LIMIT $1;
+	`
+
+	actions := []domain.StatusAction{}
+
+	rows, err := lr.db.Pool.Query(ctx, q, limit, currentUserID(ctx))
+	if err != nil {
+		return []domain.StatusAction{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var action domain.StatusAction
+		var undone sql.NullTime
+
+		err = rows.Scan(
+			&action.ID,
+			&action.Source,
+			&action.Description,
+			&action.Status,
+			&action.WordCount,
+			&action.Created,
+			&undone,
+		)
+		if err != nil {
+			return []domain.StatusAction{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		action.Undone = undone.Time
+		actions = append(actions, action)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []domain.StatusAction{}, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return actions, nil
+}
+
+// Undo restores every word the action changed to the state it was in before,
+// within a single transaction. An action can only be undone once, and not
+// after a later action has changed one of its words; see undo.
+func (lr *LearningStateRepository) Undo(ctx context.Context, actionID uuid.UUID, now time.Time) (domain.StatusAction, error) {
+	tx, err := lr.db.Pool.Begin(ctx)
+	if err != nil {
+		return domain.StatusAction{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	action, err := lr.undo(ctx, tx, actionID, now)
+	if err != nil {
+		return domain.StatusAction{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.StatusAction{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return action, nil
+}
+
+// UndoLatest undoes the last count actions that have not been undone yet, the
+// latest first, within a single transaction. If one of them cannot be undone,
+// none are; see undo.
+func (lr *LearningStateRepository) UndoLatest(ctx context.Context, count int, now time.Time) ([]domain.StatusAction, error) {
+	tx, err := lr.db.Pool.Begin(ctx)
+	if err != nil {
+		return []domain.StatusAction{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := `
+	SELECT id
+	FROM status_action
+	WHERE user_id = $2
+	AND undone_at IS NULL
+	ORDER BY created_at DESC
+	LIMIT $1
+	FOR UPDATE;
+	`
+
+	ids := []uuid.UUID{}
+
+	rows, err := tx.Query(ctx, q, count, currentUserID(ctx))
+	if err != nil {
+		return []domain.StatusAction{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	for rows.Next() {
+		var id uuid.UUID
+
+		err = rows.Scan(&id)
+		if err != nil {
+			rows.Close()
+			return []domain.StatusAction{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	rows.Close()
+
+	err = rows.Err()
+	if err != nil {
+		return []domain.StatusAction{}, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	actions := make([]domain.StatusAction, 0, len(ids))
+
+	for _, id := range ids {
+		action, err := lr.undo(ctx, tx, id, now)
+		if err != nil {
+			return []domain.StatusAction{}, err
+		}
+
+		actions = append(actions, action)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return []domain.StatusAction{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return actions, nil
+}
+
+// Review schedules the word according to the grade and adds the review to its
+// history.
+func (lr *LearningStateRepository) Review(ctx context.Context, wordID uuid.UUID, grade domain.Grade, now time.Time) (domain.LearningState, error) {
+	return lr.update(ctx, wordID, domain.StatusSourceReview, now, func(state domain.LearningState) (domain.LearningState, *domain.Review) {
+		next, review := state.Review(grade, now)
+
+		return next, &review
+	})
+}
+
+func (lr *LearningStateRepository) SetStatus(ctx context.Context, wordID uuid.UUID, status domain.LearningStatus, now time.Time) (domain.LearningState, error) {
+	return lr.update(ctx, wordID, domain.StatusSourceManual, now, func(state domain.LearningState) (domain.LearningState, *domain.Review) {
+		return state.WithStatus(status, now), nil
+	})
+}
+
+// ToggleKnown marks a word that counts as known as unknown, and any other word
+// as known, keeping its review schedule; see domain.LearningState.ToggleKnown.
+func (lr *LearningStateRepository) ToggleKnown(ctx context.Context, wordID uuid.UUID, now time.Time) (domain.LearningState, error) {
+	return lr.update(ctx, wordID, domain.StatusSourceManual, now, func(state domain.LearningState) (domain.LearningState, *domain.Review) {
+		return state.ToggleKnown(now), nil
+	})
+}
+
+func (lr *LearningStateRepository) get(ctx context.Context, db database.Executor, wordID uuid.UUID, lock string) (domain.LearningState, error) {
+	q := fmt.Sprintf(`
+	SELECT word_id, status, due_at, stability, difficulty, repetitions, lapses, last_reviewed_at, created_at, modified_at
+	FROM learning_state
+	WHERE user_id = $1
+	AND word_id = $2
+	%s;
+	`, lock)
+
+	var state domain.LearningState
+	var due, lastReviewed sql.NullTime
+
+	err := db.QueryRow(ctx, q, currentUserID(ctx), wordID).Scan(
+		&state.WordID,
+		&state.Status,
+		&due,
+		&state.Stability,
+		&state.Difficulty,
+		&state.Repetitions,
+		&state.Lapses,
+		&lastReviewed,
+		&state.Created,
+		&state.Modified,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.NewLearningState(wordID), nil
+	}
+	if err != nil {
+		return domain.LearningState{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	state.Due = due.Time
+	state.LastReviewed = lastReviewed.Time
+
+	return state, nil
+}
+
+func (lr *LearningStateRepository) save(ctx context.Context, db database.Executor, s domain.LearningState) (domain.LearningState, error) {
+	q := `
+	INSERT INTO learning_state (word_id, status, due_at, stability, difficulty, repetitions, lapses, last_reviewed_at, user_id, modified_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, DEFAULT)
+	ON CONFLICT (user_id, word_id) DO UPDATE
+	SET status = $2, due_at = $3, stability = $4, difficulty = $5, repetitions = $6, lapses = $7, last_reviewed_at = $8, modified_at = DEFAULT
+	RETURNING word_id, status, due_at, stability, difficulty, repetitions, lapses, last_reviewed_at, created_at, modified_at;
+	`
+
+	due := sql.NullTime{Time: s.Due, Valid: !s.Due.IsZero()}
+	lastReviewed := sql.NullTime{Time: s.LastReviewed, Valid: !s.LastReviewed.IsZero()}
+
+	var state domain.LearningState
+
+	err := db.QueryRow(ctx, q, s.WordID, s.Status, due, s.Stability, s.Difficulty, s.Repetitions, s.Lapses, lastReviewed, currentUserID(ctx)).Scan(
+		&state.WordID,
+		&state.Status,
+		&due,
+		&state.Stability,
+		&state.Difficulty,
+		&state.Repetitions,
+		&state.Lapses,
+		&lastReviewed,
+		&state.Created,
+		&state.Modified,
+	)
+	if err != nil {
+		return domain.LearningState{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	state.Due = due.Time
+	state.LastReviewed = lastReviewed.Time
+
+	return state, nil
+}
+
+func (lr *LearningStateRepository) saveAction(ctx context.Context, db database.Executor, a domain.StatusAction) error {
+	q := `
+	INSERT INTO status_action (id, user_id, source, description, status, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6);
+	`
+
+	_, err := db.Exec(ctx, q, a.ID, currentUserID(ctx), a.Source, a.Description, a.Status, a.Created)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+// saveChange records the state of a word before the action changed it, and
+// the status it changed it to.
+func (lr *LearningStateRepository) saveChange(ctx context.Context, db database.Executor, actionID uuid.UUID, previous domain.LearningState, status domain.LearningStatus) error {
+	q := `
+	INSERT INTO status_change (action_id, word_id, previous_status, previous_due_at, previous_stability, previous_difficulty,
+		previous_repetitions, previous_lapses, previous_last_reviewed_at, status)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+	`
+
+	due := sql.NullTime{Time: previous.Due, Valid: !previous.Due.IsZero()}
+	lastReviewed := sql.NullTime{Time: previous.LastReviewed, Valid: !previous.LastReviewed.IsZero()}
+
+	_, err := db.Exec(ctx, q, actionID, previous.WordID, previous.Status, due, previous.Stability, previous.Difficulty,
+		previous.Repetitions, previous.Lapses, lastReviewed, status)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+// getChanges returns the states of the words before the action changed them.
+func (lr *LearningStateRepository) getChanges(ctx context.Context, db database.Executor, actionID uuid.UUID) ([]domain.LearningState, error) {
+	q := `
+	SELECT word_id, previous_status, previous_due_at, previous_stability, previous_difficulty,
+		previous_repetitions, previous_lapses, previous_last_reviewed_at
+	FROM status_change
+	WHERE action_id = $1;
+	`
+
+	states := []domain.LearningState{}
+
+	rows, err := db.Query(ctx, q, actionID)
+	if err != nil {
+		return []domain.LearningState{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var state domain.LearningState
+		var due, lastReviewed sql.NullTime
+
+		err = rows.Scan(
+			&state.WordID,
+			&state.Status,
+			&due,
+			&state.Stability,
+			&state.Difficulty,
+			&state.Repetitions,
+			&state.Lapses,
+			&lastReviewed,
+		)
+		if err != nil {
+			return []domain.LearningState{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		state.Due = due.Time
+		state.LastReviewed = lastReviewed.Time
+		states = append(states, state)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []domain.LearningState{}, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return states, nil
+}
+
+// saveReview records the review along with the action it resulted in, so that
+// undoing the action also removes the review.
+func (lr *LearningStateRepository) saveReview(ctx context.Context, db database.Executor, actionID uuid.UUID, r domain.Review) error {
+	q := `
+	INSERT INTO review (id, user_id, action_id, word_id, grade, previous_status, status, stability, difficulty, due_at, reviewed_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
+	`
+
+	due := sql.NullTime{Time: r.Due, Valid: !r.Due.IsZero()}
+
+	_, err := db.Exec(ctx, q, r.ID, currentUserID(ctx), actionID, r.WordID, r.Grade, r.PreviousStatus, r.Status, r.Stability, r.Difficulty, due, r.Reviewed)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+// update applies the change to the current learning state of the word within
+// a transaction, as an action of its own that can be undone, and saves the
+// review the change resulted in, if any.
+func (lr *LearningStateRepository) update(
+	ctx context.Context,
+	wordID uuid.UUID,
+	source domain.StatusSource,
+	now time.Time,
+	change func(state domain.LearningState) (domain.LearningState, *domain.Review),
+) (domain.LearningState, error) {
+	tx, err := lr.db.Pool.Begin(ctx)
+	if err != nil {
+		return domain.LearningState{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	state, err := lr.get(ctx, tx, wordID, "FOR UPDATE")
+	if err != nil {
+		return domain.LearningState{}, err
+	}
+
+	next, review := change(state)
+
+	action := domain.StatusAction{
+		ID:      uuid.New(),
+		Source:  source,
+		Status:  next.Status,
+		Created: now,
+	}
+
+	err = lr.saveAction(ctx, tx, action)
+	if err != nil {
+		return domain.LearningState{}, err
+	}
+
+	err = lr.saveChange(ctx, tx, action.ID, state, next.Status)
+	if err != nil {
+		return domain.LearningState{}, err
+	}
+
+	updated, err := lr.save(ctx, tx, next)
+	if err != nil {
+		return domain.LearningState{}, err
+	}
+
+	if review != nil {
+		err = lr.saveReview(ctx, tx, action.ID, *review)
+		if err != nil {
+			return domain.LearningState{}, err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.LearningState{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return updated, nil
+}
+
+// undo restores every word the action changed to the state it was in before,
+// removes the reviews it saved and marks the action as undone. Users can only
+// undo their own actions, and only as long as none of its words have been
+// changed by a later action that is still in effect, since restoring them
+// would silently discard that change.
+func (lr *LearningStateRepository) undo(ctx context.Context, db database.Executor, actionID uuid.UUID, now time.Time) (domain.StatusAction, error) {
+	q := `
+	SELECT id, source, description, status, created_at, undone_at
+	FROM status_action
+	WHERE id = $1
+	AND user_id = $2
+	FOR UPDATE;
+	`
+
+	var action domain.StatusAction
+	var undone sql.NullTime
+
+	err := db.QueryRow(ctx, q, actionID, currentUserID(ctx)).Scan(
+		&action.ID,
+		&action.Source,
+		&action.Description,
+		&action.Status,
+		&action.Created,
+		&undone,
+	)
+	if err != nil {
+		return domain.StatusAction{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	if undone.Valid {
+		return domain.StatusAction{}, fmt.Errorf("action %s has already been undone", actionID)
+	}
+
+	q = `
+	SELECT COUNT(DISTINCT c.word_id)
+	FROM status_change c
+	JOIN status_change lc
+	ON lc.word_id = c.word_id
+	JOIN status_action l
+	ON l.id = lc.action_id
+	WHERE c.action_id = $1
+	AND l.user_id = $2
+	AND l.undone_at IS NULL
+	AND l.created_at > $3;
+	`
+
+	var changed int
+
+	err = db.QueryRow(ctx, q, actionID, currentUserID(ctx), action.Created).Scan(&changed)
+	if err != nil {
+		return domain.StatusAction{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	if changed > 0 {
+		return domain.StatusAction{}, fmt.Errorf("action %s cannot be undone, since %d of its words have been changed since", actionID, changed)
+	}
+
+	previous, err := lr.getChanges(ctx, db, actionID)
+	if err != nil {
+		return domain.StatusAction{}, err
+	}
+
+	for _, state := range previous {
+		_, err = lr.save(ctx, db, state)
+		if err != nil {
+			return domain.StatusAction{}, err
+		}
+	}
+
+	q = `
+	DELETE FROM review
+	WHERE action_id = $1;
+	`
+
+	_, err = db.Exec(ctx, q, actionID)
+	if err != nil {
+		return domain.StatusAction{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	q = `
+	UPDATE status_action
+	SET undone_at = $2
+	WHERE id = $1;
+	`
+
+	_, err = db.Exec(ctx, q, actionID, now)
+	if err != nil {
+		return domain.StatusAction{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	action.WordCount = len(previous)
+	action.Undone = now
+
+	return action, nil
+}
@@ -0,0 +1,203 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+// latinCollation sorts lemmas alphabetically, treating u/v and i/j as the same
+// letter. lemma_raw is used because it contains no diacritics.
+const latinCollation = "translate(lower(w.lemma_raw), 'vj', 'ui')"
+
+// knownCondition returns a condition that holds when the learning status of
+// the lemma with the given table alias counts as known to the user whose ID is
+// bound to the placeholder userID; see domain.KnownLearningStatuses.
+func knownCondition(alias, userID string) string {
+	return knownByCondition(alias+".id", userID)
+}
+
+// knownByCondition is like knownCondition, but takes SQL expressions for the
+// IDs of the word and the user, such as the columns of another table.
+func knownByCondition(wordID, userID string) string {
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM learning_state ls WHERE ls.word_id = %s AND ls.user_id = %s AND ls.status IN (%s))", wordID, userID, knownStatuses())
+}
+
+// knownStatuses returns domain.KnownLearningStatuses as a list of SQL string
+// literals, for use in an IN condition.
+func knownStatuses() string {
+	statuses := make([]string, len(domain.KnownLearningStatuses))
+
+	for i, status := range domain.KnownLearningStatuses {
+		statuses[i] = "'" + string(status) + "'"
+	}
+
+	return strings.Join(statuses, ", ")
+}
+
+// queryBuilder collects WHERE or HAVING conditions together with their
+// positional arguments, so that optional filters can be added to a query.
+type queryBuilder struct {
+	clauses []string
+	args    []any
+}
+
+// where adds a condition; every %s in it is replaced by the placeholder of the
+// corresponding value.
+func (qb *queryBuilder) where(condition string, values ...any) {
+	qb.clauses = append(qb.clauses, qb.format(condition, values...))
+}
+
+// format replaces every %s in the condition by the placeholder of the
+// corresponding value, without adding the condition to the builder.
+func (qb *queryBuilder) format(condition string, values ...any) string {
+	placeholders := make([]any, len(values))
+
+	for i, value := range values {
+		placeholders[i] = qb.arg(value)
+	}
+
+	return fmt.Sprintf(condition, placeholders...)
+}
+
+// known is like knownCondition, but binds the user ID as an argument of the
+// builder.
+func (qb *queryBuilder) known(alias string, userID uuid.UUID) string {
+	return knownCondition(alias, qb.arg(userID))
+}
+
+func (qb *queryBuilder) arg(value any) string {
+	qb.args = append(qb.args, value)
+
+	return fmt.Sprintf("$%d", len(qb.args))
+}
+
+func (qb *queryBuilder) conditions() string {
+	if len(qb.clauses) == 0 {
+		return ""
+	}
+
+	return "AND " + strings.Join(qb.clauses, "\n\tAND ")
+}
+
+// inScope returns a condition that matches the work_word rows in the scope,
+// for use in a FILTER clause or combined with other conditions.
+func (qb *queryBuilder) inScope(scope domain.Scope) string {
+	conditions := []string{}
+
+	switch scope.Kind {
+	case domain.ScopeKindWork:
+		conditions = append(conditions, qb.format("ww.work_id = %s", scope.ID))
+	case domain.ScopeKindAuthor:
+		conditions = append(conditions, qb.format("a.id = %s AND a.deleted_at IS NULL", scope.ID))
+	case domain.ScopeKindCollection:
+		conditions = append(conditions, qb.format("ww.work_id IN (SELECT cw.work_id FROM collection_work cw WHERE cw.collection_id = %s)", scope.ID))
+	case domain.ScopeKindSection:
+		conditions = append(conditions, qb.format("EXISTS (SELECT 1 FROM section s WHERE s.id = %s AND s.work_id = ww.work_id AND ww.word_index BETWEEN s.first_word_index AND s.last_word_index)", scope.ID))
+	}
+
+	conditions = append(conditions, qb.matchWorks("work", scope.Works)...)
+
+	if len(conditions) == 0 {
+		return "TRUE"
+	}
+
+	return "(" + strings.Join(conditions, " AND ") + ")"
+}
+
+// matchWorks returns the conditions on the work table with the given alias
+// that select the works matching the filter; see domain.WorkFilter.
+func (qb *queryBuilder) matchWorks(alias string, filter domain.WorkFilter) []string {
+	conditions := []string{}
+
+	if filter.Period != "" {
+		conditions = append(conditions, qb.format("lower("+alias+".period) = lower(%s)", filter.Period))
+	}
+
+	if filter.Genre != "" {
+		conditions = append(conditions, qb.format("lower("+alias+".genre) = lower(%s)", filter.Genre))
+	}
+
+	if filter.Form != "" {
+		conditions = append(conditions, qb.format(alias+".form = %s", string(filter.Form)))
+	}
+
+	if filter.Tag != "" {
+		conditions = append(conditions, qb.format("EXISTS (SELECT 1 FROM unnest("+alias+".tags) tag WHERE lower(tag) = lower(%s))", filter.Tag))
+	}
+
+	if filter.Edition != "" {
+		conditions = append(conditions, qb.format("strpos(lower("+alias+".edition), lower(%s)) > 0", filter.Edition))
+	}
+
+	if filter.Notes != "" {
+		conditions = append(conditions, qb.format("strpos(lower("+alias+".notes), lower(%s)) > 0", filter.Notes))
+	}
+
+	if filter.FromYear != nil {
+		conditions = append(conditions, qb.format(alias+".year >= %s", *filter.FromYear))
+	}
+
+	if filter.ToYear != nil {
+		conditions = append(conditions, qb.format(alias+".year <= %s", *filter.ToYear))
+	}
+
+	return conditions
+}
+
+// filterScope restricts the work_word rows to those in the scope.
+func (qb *queryBuilder) filterScope(scope domain.Scope) {
+	condition := qb.inScope(scope)
+
+	if condition != "TRUE" {
+		qb.clauses = append(qb.clauses, condition)
+	}
+}
+
+// filterWords adds the filters that apply to individual words and occurrences,
+// with known status being that of the given user.
+func (qb *queryBuilder) filterWords(filter domain.WordListFilter, userID uuid.UUID) {
+	if filter.MinFrequencyInLASLA != nil {
+		qb.where("COALESCE(w.lasla_frequency, 0) >= %s", *filter.MinFrequencyInLASLA)
+	}
+
+	if filter.MaxFrequencyInLASLA != nil {
+		qb.where("COALESCE(w.lasla_frequency, 0) < %s", *filter.MaxFrequencyInLASLA)
+	}
+
+	if filter.PartOfSpeech != "" {
+		qb.where("left(ww.tag, 1) = %s", filter.PartOfSpeech)
+	}
+
+	if filter.Known != nil {
+		qb.where(qb.known("w", userID)+" = %s", *filter.Known)
+	}
+
+	if filter.Search != "" {
+		qb.where("(strpos(lower(w.lemma_raw), lower(%[1]s)) > 0 OR strpos(lower(w.lemma_rich), lower(%[1]s)) > 0 OR strpos(lower(w.translation), lower(%[1]s)) > 0)", filter.Search)
+	}
+}
+
+// filterCounts adds the filters that apply to the number of occurrences, which
+// is only known after grouping.
+func (qb *queryBuilder) filterCounts(count string, filter domain.WordListFilter) {
+	if filter.MinCount > 0 {
+		qb.where(count+" >= %s", filter.MinCount)
+	}
+
+	if filter.MaxCount > 0 {
+		qb.where(count+" <= %s", filter.MaxCount)
+	}
+}
+
+func (qb *queryBuilder) paginate(filter domain.WordListFilter) string {
+	if filter.PageSize == 0 {
+		return ""
+	}
+
+	offset := max(filter.Page-1, 0) * filter.PageSize
+
+	return fmt.Sprintf("LIMIT %s OFFSET %s", qb.arg(filter.PageSize), qb.arg(offset))
+}
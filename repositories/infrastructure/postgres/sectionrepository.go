@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/nienkeboomsma/vocabularium/database"
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type SectionRepository struct {
+	db *database.Client
+}
+
+func NewSectionRepository(db *database.Client) *SectionRepository {
+	return &SectionRepository{db: db}
+}
+
+// DeleteByWorkID removes the section tree of the work, so that it can be
+// replaced when the work is uploaded again.
+func (sr *SectionRepository) DeleteByWorkID(ctx context.Context, db database.Executor, workID uuid.UUID) error {
+	q := `
+	DELETE FROM section
+	WHERE work_id = $1;
+	`
+
+	_, err := db.Exec(ctx, q, workID)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+func (sr *SectionRepository) GetByID(ctx context.Context, id uuid.UUID) (domain.Section, error) {
+	sections, err := sr.get(ctx, "s.id = $1", id)
+	if err != nil {
+		return domain.Section{}, err
+	}
+
+	if len(sections) == 0 {
+		return domain.Section{}, fmt.Errorf("section %s not found", id)
+	}
+
+	return sections[0], nil
+}
+
+func (sr *SectionRepository) GetByWorkID(ctx context.Context, workID uuid.UUID) ([]domain.Section, error) {
+	return sr.get(ctx, "s.work_id = $1", workID)
+}
+
+// Save inserts the section; parents must be saved before their children.
+func (sr *SectionRepository) Save(ctx context.Context, db database.Executor, s domain.Section, workID uuid.UUID) (domain.Section, error) {
+	q := `
+	INSERT INTO section (id, work_id, parent_id, position, depth, kind, label, citation, first_word_index, last_word_index, modified_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, DEFAULT)
+	RETURNING id, work_id, parent_id, position, depth, kind, label, citation, first_word_index, last_word_index, created_at, modified_at;
+	`
+
+	parentID := uuid.NullUUID{
+		UUID:  s.ParentID,
+		Valid: s.ParentID != uuid.Nil,
+	}
+
+	var section domain.Section
+
+	err := db.QueryRow(
+		ctx,
+		q,
+		s.ID,
+		workID,
+		parentID,
+		s.Position,
+		s.Depth,
+		s.Kind,
+		s.Label,
+		s.Citation,
+		s.FirstWordIndex,
+		s.LastWordIndex,
+	).Scan(
+		&section.ID,
+		&section.WorkID,
+		&parentID,
+		&section.Position,
+		&section.Depth,
+		&section.Kind,
+		&section.Label,
+		&section.Citation,
+		&section.FirstWordIndex,
+		&section.LastWordIndex,
+		&section.Created,
+		&section.Modified,
+	)
+	if err != nil {
+		return domain.Section{}, err
+	}
+
+	section.ParentID = parentID.UUID
+
+	return section, nil
+}
+
+func (sr *SectionRepository) get(ctx context.Context, condition string, args ...any) ([]domain.Section, error) {
+	q := fmt.Sprintf(`
+	SELECT s.id, s.work_id, s.parent_id, s.position, s.depth, s.kind, s.label, s.citation, s.first_word_index, s.last_word_index, s.created_at, s.modified_at
+	FROM section s
+	WHERE %s
+	ORDER BY s.work_id, s.position ASC;
+	`, condition)
+
+	sections := []domain.Section{}
+
+	rows, err := sr.db.Pool.Query(ctx, q, args...)
+	if err != nil {
+		return []domain.Section{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var section domain.Section
+		var parentID uuid.NullUUID
+
+		err = rows.Scan(
+			&section.ID,
+			&section.WorkID,
+			&parentID,
+			&section.Position,
+			&section.Depth,
+			&section.Kind,
+			&section.Label,
+			&section.Citation,
+			&section.FirstWordIndex,
+			&section.LastWordIndex,
+			&section.Created,
+			&section.Modified,
+		)
+		if err != nil {
+			return []domain.Section{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		section.ParentID = parentID.UUID
+		sections = append(sections, section)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []domain.Section{}, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return sections, nil
+}
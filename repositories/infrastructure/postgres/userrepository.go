@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/nienkeboomsma/vocabularium/database"
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type UserRepository struct {
+	db *database.Client
+}
+
+func NewUserRepository(db *database.Client) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+func (ur *UserRepository) DeleteSession(ctx context.Context, tokenHash string) error {
+	q := `
+	DELETE FROM user_session
+	WHERE token_hash = $1;
+	`
+
+	_, err := ur.db.Pool.Exec(ctx, q, tokenHash)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+func (ur *UserRepository) GetByName(ctx context.Context, name string) (domain.User, error) {
+	q := `
+	SELECT id, name, password_hash, created_at
+	FROM app_user
+	WHERE name = $1;
+	`
+
+	var user domain.User
+
+	err := ur.db.Pool.QueryRow(ctx, q, name).Scan(
+		&user.ID,
+		&user.Name,
+		&user.PasswordHash,
+		&user.Created,
+	)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return user, nil
+}
+
+// GetBySession returns the user who is logged in with the session, provided
+// it has not expired.
+func (ur *UserRepository) GetBySession(ctx context.Context, tokenHash string, now time.Time) (domain.User, error) {
+	q := `
+	SELECT u.id, u.name, u.password_hash, u.created_at
+	FROM user_session s
+	JOIN app_user u
+	ON u.id = s.user_id
+	WHERE s.token_hash = $1
+	AND s.expires_at > $2;
+	`
+
+	var user domain.User
+
+	err := ur.db.Pool.QueryRow(ctx, q, tokenHash, now).Scan(
+		&user.ID,
+		&user.Name,
+		&user.PasswordHash,
+		&user.Created,
+	)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return user, nil
+}
+
+// Register saves a new user. The first user to register takes over the
+// account without a password that holds the learning state recorded before
+// there were accounts, and keeps its ID.
+func (ur *UserRepository) Register(ctx context.Context, u domain.User) (domain.User, error) {
+	tx, err := ur.db.Pool.Begin(ctx)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := `
+	UPDATE app_user
+	SET name = $1, password_hash = $2, created_at = $3
+	WHERE id = (
+		SELECT id
+		FROM app_user
+		WHERE password_hash = ''
+		ORDER BY created_at ASC
+		LIMIT 1
+		FOR UPDATE
+	)
+	RETURNING id, name, password_hash, created_at;
+	`
+
+	var user domain.User
+
+	err = tx.QueryRow(ctx, q, u.Name, u.PasswordHash, u.Created).Scan(
+		&user.ID,
+		&user.Name,
+		&user.PasswordHash,
+		&user.Created,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		q = `
+		INSERT INTO app_user (id, name, password_hash, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, name, password_hash, created_at;
+		`
+
+		err = tx.QueryRow(ctx, q, u.ID, u.Name, u.PasswordHash, u.Created).Scan(
+			&user.ID,
+			&user.Name,
+			&user.PasswordHash,
+			&user.Created,
+		)
+	}
+	if err != nil {
+		return domain.User{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return user, nil
+}
+
+// SaveSession saves a new session, and removes the expired sessions of the
+// user.
+func (ur *UserRepository) SaveSession(ctx context.Context, s domain.Session) error {
+	q := `
+	DELETE FROM user_session
+	WHERE user_id = $1
+	AND expires_at <= $2;
+	`
+
+	_, err := ur.db.Pool.Exec(ctx, q, s.UserID, s.Created)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	q = `
+	INSERT INTO user_session (token_hash, user_id, created_at, expires_at)
+	VALUES ($1, $2, $3, $4);
+	`
+
+	_, err = ur.db.Pool.Exec(ctx, q, s.TokenHash, s.UserID, s.Created, s.Expires)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+// currentUserID returns the ID of the user in the context, whose learning
+// state is read and written; see domain.ContextWithUser. Without a user, it
+// returns the nil UUID, which belongs to no one.
+func currentUserID(ctx context.Context) uuid.UUID {
+	user, _ := domain.UserFromContext(ctx)
+
+	return user.ID
+}
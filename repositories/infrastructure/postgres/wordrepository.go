@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 
@@ -17,23 +18,18 @@ func NewWordRepository(db *database.Client) *WordRepository {
 	return &WordRepository{db: db}
 }
 
-func (wr *WordRepository) GetFrequencyList(ctx context.Context) (*[]domain.WordInWork, error) {
-	q := `
-	SELECT w.id, w.lemma_rich, w.translation, w.known, COUNT(ww.word_id)
-	FROM work_word ww
-	JOIN word w
-	ON w.id = ww.word_id
-	WHERE ww.deleted_at IS NULL
-	GROUP BY w.id, w.lemma_rich, w.known
-	ORDER BY COUNT(ww.word_id) DESC;
-	`
+// GetComparison counts the occurrences of every lemma in both scopes. Lemmas
+// that occur in neither are left out.
+func (wr *WordRepository) GetComparison(ctx context.Context, a, b domain.Scope, filter domain.WordListFilter) (*[]domain.ComparedWord, error) {
+	qb := &queryBuilder{}
+	inA := qb.inScope(a)
+	inB := qb.inScope(b)
 
-	return wr.getWordList(ctx, q)
-}
+	qb.filterWords(filter, currentUserID(ctx))
 
-func (wr *WordRepository) GetFrequencyListByAuthorID(ctx context.Context, authorID uuid.UUID) (*[]domain.WordInWork, error) {
-	q := `
-	SELECT w.id, w.lemma_rich, w.translation, w.known, COUNT(ww.word_id)
+	q := fmt.Sprintf(`
+	SELECT w.id, w.lemma_raw, w.lemma_rich, w.translation, COALESCE(w.lasla_frequency, 0), %[4]s,
+		COUNT(ww.word_id) FILTER (WHERE %[1]s), COUNT(ww.word_id) FILTER (WHERE %[2]s)
 	FROM work_word ww
 	JOIN word w
 	ON w.id = ww.word_id
@@ -41,60 +37,301 @@ func (wr *WordRepository) GetFrequencyListByAuthorID(ctx context.Context, author
 	ON work.id = ww.work_id
 	JOIN author a
 	ON a.id = work.author_id
-	WHERE a.id = $1
-	AND ww.deleted_at IS NULL
+	WHERE ww.deleted_at IS NULL
 	AND work.deleted_at IS NULL
-	AND a.deleted_at IS NULL
-	GROUP BY w.id, w.lemma_rich, w.known
-	ORDER BY COUNT(ww.word_id) DESC;
-	`
+	AND (%[1]s OR %[2]s)
+	%[3]s
+	GROUP BY w.id;
+	`, inA, inB, qb.conditions(), qb.known("w", currentUserID(ctx)))
+
+	words := []domain.ComparedWord{}
+
+	rows, err := wr.db.Pool.Query(ctx, q, qb.args...)
+	if err != nil {
+		return &[]domain.ComparedWord{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		word := domain.ComparedWord{}
+
+		err = rows.Scan(
+			&word.ID,
+			&word.LemmaRaw,
+			&word.LemmaRich,
+			&word.Translation,
+			&word.FrequencyInLASLA,
+			&word.Known,
+			&word.CountA,
+			&word.CountB,
+		)
+		if err != nil {
+			return &[]domain.ComparedWord{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		words = append(words, word)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return &[]domain.ComparedWord{}, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return &words, nil
+}
+
+// GetByID returns the word with the given ID and whether the user knows it.
+func (wr *WordRepository) GetByID(ctx context.Context, id uuid.UUID) (domain.Word, error) {
+	q := fmt.Sprintf(`
+	SELECT w.id, w.lemma_raw, w.lemma_rich, w.translation, COALESCE(w.lasla_frequency, 0), %s, w.created_at, w.modified_at
+	FROM word w
+	WHERE w.id = $1;
+	`, knownCondition("w", "$2"))
+
+	var word domain.Word
+
+	err := wr.db.Pool.QueryRow(ctx, q, id, currentUserID(ctx)).Scan(
+		&word.ID,
+		&word.LemmaRaw,
+		&word.LemmaRich,
+		&word.Translation,
+		&word.FrequencyInLASLA,
+		&word.Known,
+		&word.Created,
+		&word.Modified,
+	)
+	if err != nil {
+		return domain.Word{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return word, nil
+}
+
+// GetExamples returns the first occurrence of every word in the scope, in the
+// order of author and title, together with its sentence and the titles of the
+// works in the scope the word occurs in.
+func (wr *WordRepository) GetExamples(ctx context.Context, scope domain.Scope) (map[uuid.UUID]domain.Example, error) {
+	qb := &queryBuilder{}
+	qb.filterScope(scope)
+
+	q := fmt.Sprintf(`
+	WITH occurrences AS (
+		SELECT ww.word_id, ww.work_id, ww.word_index, ww.sentence_index, work.title, a.name AS author_name,
+			ROW_NUMBER() OVER (PARTITION BY ww.word_id ORDER BY a.name ASC, work.title ASC, ww.word_index ASC) AS occurrence
+		FROM work_word ww
+		JOIN work
+		ON work.id = ww.work_id
+		JOIN author a
+		ON a.id = work.author_id
+		WHERE ww.deleted_at IS NULL
+		AND work.deleted_at IS NULL
+		%s
+	), titles AS (
+		SELECT word_id, array_agg(DISTINCT title ORDER BY title) AS titles
+		FROM occurrences
+		GROUP BY word_id
+	)
+	SELECT o.word_id, t.titles, o.title, o.author_name,
+		array_agg(s.original_form ORDER BY s.word_index), COUNT(s.id) FILTER (WHERE s.word_index < o.word_index)
+	FROM occurrences o
+	JOIN titles t
+	ON t.word_id = o.word_id
+	JOIN work_word s
+	ON s.work_id = o.work_id
+	AND s.sentence_index = o.sentence_index
+	AND s.deleted_at IS NULL
+	WHERE o.occurrence = 1
+	GROUP BY o.word_id, t.titles, o.title, o.author_name;
+	`, qb.conditions())
+
+	examples := map[uuid.UUID]domain.Example{}
+
+	rows, err := wr.db.Pool.Query(ctx, q, qb.args...)
+	if err != nil {
+		return map[uuid.UUID]domain.Example{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var example domain.Example
+
+		err = rows.Scan(
+			&example.WordID,
+			&example.Works,
+			&example.Title,
+			&example.Author,
+			&example.Sentence,
+			&example.Position,
+		)
+		if err != nil {
+			return map[uuid.UUID]domain.Example{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		examples[example.WordID] = example
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return map[uuid.UUID]domain.Example{}, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return examples, nil
+}
+
+// GetFrequencyList lists the lemmas in the scope with their counts. Sorting by
+// first occurrence is only possible within a single work, since word indexes
+// of different works cannot be compared.
+func (wr *WordRepository) GetFrequencyList(ctx context.Context, scope domain.Scope, filter domain.WordListFilter) (*[]domain.WordInWork, int, error) {
+	list, err := wr.getFrequencyList(ctx, scope, filter)
+	if err != nil {
+		return &[]domain.WordInWork{}, 0, err
+	}
 
-	return wr.getWordList(ctx, q, authorID)
+	return wr.getWordListPage(ctx, list, filter)
 }
 
-func (wr *WordRepository) GetFrequencyListByWorkID(ctx context.Context, workID uuid.UUID) (*[]domain.WordInWork, error) {
+// GetGlossary lists the lemmas in the order in which they first occur. When
+// the scope contains several works, these are taken in the order of author and
+// title, and the range applies to each work separately.
+func (wr *WordRepository) GetGlossary(ctx context.Context, scope domain.Scope, options domain.GlossaryOptions, filter domain.WordListFilter) (*[]domain.WordInWork, int, error) {
+	return wr.getWordListPage(ctx, wr.getGlossary(ctx, scope, options, filter), filter)
+}
+
+func (wr *WordRepository) GetKeywords(ctx context.Context, scope domain.Scope, reference domain.KeynessReference) (*[]domain.Keyword, error) {
+	return wr.getKeywords(ctx, scope, reference)
+}
+
+func (wr *WordRepository) GetLemmaCountsByWork(ctx context.Context) (map[uuid.UUID]map[uuid.UUID]int, error) {
 	q := `
-	SELECT w.id, w.lemma_rich, w.translation, w.known, COUNT(ww.word_id)
+	SELECT ww.work_id, ww.word_id, COUNT(ww.word_id)
 	FROM work_word ww
-	JOIN word w
-	ON w.id = ww.word_id
 	JOIN work
 	ON work.id = ww.work_id
-	WHERE ww.work_id = $1
-	AND ww.deleted_at IS NULL
+	WHERE ww.deleted_at IS NULL
 	AND work.deleted_at IS NULL
-	GROUP BY w.id, w.lemma_rich, w.known
-	ORDER BY COUNT(ww.word_id) DESC;
+	GROUP BY ww.work_id, ww.word_id;
 	`
 
-	return wr.getWordList(ctx, q, workID)
+	lemmaCounts := map[uuid.UUID]map[uuid.UUID]int{}
+
+	rows, err := wr.db.Pool.Query(ctx, q)
+	if err != nil {
+		return map[uuid.UUID]map[uuid.UUID]int{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var workID, wordID uuid.UUID
+		var count int
+
+		err = rows.Scan(&workID, &wordID, &count)
+		if err != nil {
+			return map[uuid.UUID]map[uuid.UUID]int{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		if lemmaCounts[workID] == nil {
+			lemmaCounts[workID] = map[uuid.UUID]int{}
+		}
+
+		lemmaCounts[workID][wordID] = count
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return map[uuid.UUID]map[uuid.UUID]int{}, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return lemmaCounts, nil
 }
 
-func (wr *WordRepository) GetGlossaryByWorkID(ctx context.Context, workID uuid.UUID) (*[]domain.WordInWork, error) {
+func (wr *WordRepository) GetLemmaStreamsByWork(ctx context.Context) (map[uuid.UUID][]uuid.UUID, error) {
 	q := `
-	SELECT w.id, w.lemma_rich, w.translation, w.known, COUNT(ww.word_id) OVER (PARTITION BY w.id) AS word_count
+	SELECT ww.work_id, ww.word_id
 	FROM work_word ww
-	JOIN word w
-	ON w.id = ww.word_id
 	JOIN work
 	ON work.id = ww.work_id
-	WHERE ww.work_id = $1
-	AND ww.deleted_at IS NULL
+	WHERE ww.deleted_at IS NULL
 	AND work.deleted_at IS NULL
-	ORDER BY ww.word_index ASC;
+	ORDER BY ww.work_id, ww.word_index ASC;
+	`
+
+	streams := map[uuid.UUID][]uuid.UUID{}
+
+	rows, err := wr.db.Pool.Query(ctx, q)
+	if err != nil {
+		return map[uuid.UUID][]uuid.UUID{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var workID, wordID uuid.UUID
+
+		err = rows.Scan(&workID, &wordID)
+		if err != nil {
+			return map[uuid.UUID][]uuid.UUID{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		streams[workID] = append(streams[workID], wordID)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return map[uuid.UUID][]uuid.UUID{}, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return streams, nil
+}
+
+// GetLemmaStreamsBySection returns the lemmas of every section of the work in
+// the order in which they occur. A word belongs to its section and to all of
+// that section's ancestors.
+func (wr *WordRepository) GetLemmaStreamsBySection(ctx context.Context, workID uuid.UUID) (map[uuid.UUID][]uuid.UUID, error) {
+	q := `
+	SELECT s.id, ww.word_id
+	FROM section s
+	JOIN work_word ww
+	ON ww.work_id = s.work_id
+	AND ww.word_index BETWEEN s.first_word_index AND s.last_word_index
+	WHERE s.work_id = $1
+	AND ww.deleted_at IS NULL
+	ORDER BY s.id, ww.word_index ASC;
 	`
 
-	return wr.getWordList(ctx, q, workID)
+	streams := map[uuid.UUID][]uuid.UUID{}
+
+	rows, err := wr.db.Pool.Query(ctx, q, workID)
+	if err != nil {
+		return map[uuid.UUID][]uuid.UUID{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sectionID, wordID uuid.UUID
+
+		err = rows.Scan(&sectionID, &wordID)
+		if err != nil {
+			return map[uuid.UUID][]uuid.UUID{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		streams[sectionID] = append(streams[sectionID], wordID)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return map[uuid.UUID][]uuid.UUID{}, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return streams, nil
 }
 
 func (wr *WordRepository) Insert(ctx context.Context, db database.Executor, w domain.Word) (domain.Word, error) {
-	q := `
-	INSERT INTO word (id, lemma_raw, lemma_rich, translation, lasla_frequency, known, modified_at, deleted_at)
-	VALUES ($1, $2, $3, $4, $5, $6, DEFAULT, $7)
+	q := fmt.Sprintf(`
+	INSERT INTO word AS w (id, lemma_raw, lemma_rich, translation, lasla_frequency, modified_at, deleted_at)
+	VALUES ($1, $2, $3, $4, $5, DEFAULT, $6)
 	ON CONFLICT (lemma_raw, lemma_rich) DO UPDATE
-	SET translation = $4, modified_at = DEFAULT, deleted_at = $7
-	RETURNING id, lemma_raw, lemma_rich, translation, lasla_frequency, known, created_at, modified_at, deleted_at;
-	`
+	SET translation = $4, modified_at = DEFAULT, deleted_at = $6
+	RETURNING id, lemma_raw, lemma_rich, translation, lasla_frequency, %s, created_at, modified_at, deleted_at;
+	`, knownCondition("w", "$7"))
 
 	var word domain.Word
 
@@ -106,8 +343,8 @@ func (wr *WordRepository) Insert(ctx context.Context, db database.Executor, w do
 		w.LemmaRich,
 		w.Translation,
 		w.FrequencyInLASLA,
-		w.Known,
 		w.Deleted,
+		currentUserID(ctx),
 	).Scan(
 		&word.ID,
 		&word.LemmaRaw,
@@ -126,58 +363,357 @@ func (wr *WordRepository) Insert(ctx context.Context, db database.Executor, w do
 	return word, nil
 }
 
-func (wr *WordRepository) ToggleKnownStatus(ctx context.Context, wordID uuid.UUID) (domain.Word, error) {
-	q := `
-	UPDATE word
-	SET known = NOT known
-	WHERE id = $1
-	RETURNING *;
-	`
+// StreamFrequencyList passes every word in the frequency list to fn, in the
+// order the filter asks for but regardless of its page. The words are read
+// with a single query and never held in memory as a whole.
+func (wr *WordRepository) StreamFrequencyList(ctx context.Context, scope domain.Scope, filter domain.WordListFilter, fn func(domain.WordInWork) error) error {
+	list, err := wr.getFrequencyList(ctx, scope, filter)
+	if err != nil {
+		return err
+	}
 
-	var word domain.Word
+	return wr.streamWordList(ctx, list, fn)
+}
 
-	err := wr.db.Pool.QueryRow(ctx, q, wordID).Scan(
-		&word.ID,
-		&word.LemmaRaw,
-		&word.LemmaRich,
-		&word.Translation,
-		&word.FrequencyInLASLA,
-		&word.Known,
-		&word.Created,
-		&word.Modified,
-		&word.Deleted,
+// StreamGlossary is like StreamFrequencyList, but for the glossary.
+func (wr *WordRepository) StreamGlossary(ctx context.Context, scope domain.Scope, options domain.GlossaryOptions, filter domain.WordListFilter, fn func(domain.WordInWork) error) error {
+	return wr.streamWordList(ctx, wr.getGlossary(ctx, scope, options, filter), fn)
+}
+
+// getFrequencyList counts the occurrences of every lemma per work and derives
+// the dispersion statistics from those counts. Works in which a lemma does not
+// occur are accounted for through the totals of all works in scope:
+//
+//   - DP (Gries 2008) is half the sum of |s - v| over all works, where s is the
+//     share of the work in the corpus and v the share of the lemma's
+//     occurrences in the work; for the works without the lemma |s - v| = s.
+//   - Juilland's D is 1 - V / √(n - 1), where V is the coefficient of variation
+//     of the lemma's relative frequencies in the n works.
+//   - The adjusted frequency is Juilland's usage coefficient U = D × frequency.
+func (wr *WordRepository) getFrequencyList(ctx context.Context, scope domain.Scope, filter domain.WordListFilter) (wordList, error) {
+	if filter.Sort == domain.WordSortFirstOccurrence && scope.SpansWorks() {
+		return wordList{}, fmt.Errorf("cannot sort %s by first occurrence", scope)
+	}
+
+	qb := &queryBuilder{}
+	qb.filterScope(scope)
+	inScope := qb.conditions()
+
+	qb.filterWords(filter, currentUserID(ctx))
+	known := qb.known("w", currentUserID(ctx))
+
+	having := &queryBuilder{args: qb.args}
+	having.filterCounts("SUM(pw.count)", filter)
+
+	direction := map[domain.SortOrder]string{
+		domain.SortOrderAscending:  "ASC",
+		domain.SortOrderDescending: "DESC",
+	}
+
+	var orderBy string
+
+	switch filter.Sort {
+	case domain.WordSortAlphabetical:
+		orderBy = fmt.Sprintf("%s %s", latinCollation, cmp.Or(direction[filter.Order], "ASC"))
+	case domain.WordSortLASLA:
+		orderBy = fmt.Sprintf("COALESCE(w.lasla_frequency, 0) %s", cmp.Or(direction[filter.Order], "DESC"))
+	case domain.WordSortFirstOccurrence:
+		orderBy = fmt.Sprintf("MIN(pw.first_index) %s", cmp.Or(direction[filter.Order], "ASC"))
+	case domain.WordSortRange:
+		orderBy = fmt.Sprintf("COUNT(pw.work_id) %s", cmp.Or(direction[filter.Order], "DESC"))
+	case domain.WordSortDP:
+		orderBy = fmt.Sprintf("d.dp %s", cmp.Or(direction[filter.Order], "ASC"))
+	case domain.WordSortJuillandD:
+		orderBy = fmt.Sprintf("d.juilland_d %s", cmp.Or(direction[filter.Order], "DESC"))
+	case domain.WordSortAdjustedFrequency:
+		orderBy = fmt.Sprintf("d.juilland_d * SUM(pw.count) %s", cmp.Or(direction[filter.Order], "DESC"))
+	default:
+		orderBy = fmt.Sprintf("SUM(pw.count) %s", cmp.Or(direction[filter.Order], "DESC"))
+	}
+
+	q := fmt.Sprintf(`
+	WITH sizes AS (
+		SELECT ww.work_id, COUNT(ww.word_id)::float AS size
+		FROM work_word ww
+		JOIN work
+		ON work.id = ww.work_id
+		JOIN author a
+		ON a.id = work.author_id
+		WHERE ww.deleted_at IS NULL
+		AND work.deleted_at IS NULL
+		%s
+		GROUP BY ww.work_id
+	), totals AS (
+		SELECT COUNT(*)::float AS parts, SUM(size) AS size
+		FROM sizes
+	), per_work AS (
+		SELECT w.id AS word_id, ww.work_id, COUNT(ww.word_id)::float AS count, MIN(ww.word_index) AS first_index,
+			SUM(COUNT(ww.word_id)) OVER (PARTITION BY w.id)::float AS total
+		FROM work_word ww
+		JOIN word w
+		ON w.id = ww.word_id
+		JOIN work
+		ON work.id = ww.work_id
+		JOIN author a
+		ON a.id = work.author_id
+		WHERE ww.deleted_at IS NULL
+		AND work.deleted_at IS NULL
+		%s
+		GROUP BY w.id, ww.work_id
+	), dispersion AS (
+		SELECT pw.word_id,
+			0.5 * (SUM(ABS(s.size / t.size - pw.count / pw.total)) + 1 - SUM(s.size / t.size)) AS dp,
+			CASE WHEN t.parts > 1
+				THEN 1 - SQRT(GREATEST(SUM((pw.count / s.size) ^ 2) / t.parts - (SUM(pw.count / s.size) / t.parts) ^ 2, 0))
+					/ (SUM(pw.count / s.size) / t.parts) / SQRT(t.parts - 1)
+				ELSE 1
+			END AS juilland_d
+		FROM per_work pw
+		JOIN sizes s
+		ON s.work_id = pw.work_id
+		CROSS JOIN totals t
+		GROUP BY pw.word_id, t.parts
 	)
+	SELECT w.id, w.lemma_raw, w.lemma_rich, w.translation, COALESCE(w.lasla_frequency, 0), %s, SUM(pw.count)::int,
+		COUNT(pw.work_id), d.dp, d.juilland_d, d.juilland_d * SUM(pw.count)
+	FROM per_work pw
+	JOIN word w
+	ON w.id = pw.word_id
+	JOIN dispersion d
+	ON d.word_id = pw.word_id
+	GROUP BY w.id, d.dp, d.juilland_d
+	HAVING TRUE
+	%s
+	`, inScope, qb.conditions(), known, having.conditions())
+
+	return wordList{q: q, orderBy: orderBy + ", " + latinCollation + " ASC", qb: having, dispersion: true}, nil
+}
+
+// getGlossary selects the words of GetGlossary.
+func (wr *WordRepository) getGlossary(ctx context.Context, scope domain.Scope, options domain.GlossaryOptions, filter domain.WordListFilter) wordList {
+	rangeColumn := "ww.word_index"
+	if options.Unit == domain.RangeUnitSentence {
+		rangeColumn = "ww.sentence_index"
+	}
+
+	qb := &queryBuilder{}
+	qb.filterScope(scope)
+
+	if options.From != nil {
+		qb.where(rangeColumn+" >= %s", *options.From)
+	}
+
+	if options.To != nil {
+		qb.where(rangeColumn+" <= %s", *options.To)
+	}
+
+	qb.filterWords(filter, currentUserID(ctx))
+	known := qb.known("w", currentUserID(ctx))
+
+	outer := &queryBuilder{args: qb.args}
+
+	if options.FirstOccurrenceOnly {
+		outer.where("g.occurrence = 1")
+	}
+
+	outer.filterCounts("g.word_count", filter)
+
+	q := fmt.Sprintf(`
+	SELECT g.id, g.lemma_raw, g.lemma_rich, g.translation, g.lasla_frequency, g.known, g.word_count
+	FROM (
+		SELECT w.id, w.lemma_raw, w.lemma_rich, w.translation, COALESCE(w.lasla_frequency, 0) AS lasla_frequency, %s AS known,
+			a.name AS author_name, work.title, ww.word_index,
+			COUNT(ww.word_id) OVER (PARTITION BY w.id) AS word_count,
+			ROW_NUMBER() OVER (PARTITION BY w.id ORDER BY a.name ASC, work.title ASC, ww.word_index ASC) AS occurrence
+		FROM work_word ww
+		JOIN word w
+		ON w.id = ww.word_id
+		JOIN work
+		ON work.id = ww.work_id
+		JOIN author a
+		ON a.id = work.author_id
+		WHERE ww.deleted_at IS NULL
+		AND work.deleted_at IS NULL
+		%s
+	) g
+	WHERE TRUE
+	%s
+	`, known, qb.conditions(), outer.conditions())
+
+	return wordList{q: q, orderBy: "g.author_name ASC, g.title ASC, g.word_index ASC", qb: outer}
+}
+
+// getKeywords counts the occurrences of every lemma in the target, i.e. the
+// work_word rows in the scope, and in the reference. The
+// reference is either the rest of the corpus or the LASLA frequencies, in
+// which case lemmas that do not occur in the corpus at all are not taken into
+// account.
+func (wr *WordRepository) getKeywords(ctx context.Context, scope domain.Scope, reference domain.KeynessReference) (*[]domain.Keyword, error) {
+	qb := &queryBuilder{}
+	target := qb.inScope(scope)
+
+	var q string
+
+	switch reference {
+	case domain.KeynessReferenceLASLA:
+		q = fmt.Sprintf(`
+		SELECT w.id, w.lemma_raw, w.lemma_rich, w.translation, COALESCE(w.lasla_frequency, 0), %s,
+			COUNT(ww.word_id) FILTER (WHERE %s), COALESCE(w.lasla_frequency, 0)
+		FROM work_word ww
+		JOIN word w
+		ON w.id = ww.word_id
+		JOIN work
+		ON work.id = ww.work_id
+		JOIN author a
+		ON a.id = work.author_id
+		WHERE ww.deleted_at IS NULL
+		AND work.deleted_at IS NULL
+		GROUP BY w.id;
+		`, qb.known("w", currentUserID(ctx)), target)
+	default:
+		q = fmt.Sprintf(`
+		SELECT w.id, w.lemma_raw, w.lemma_rich, w.translation, COALESCE(w.lasla_frequency, 0), %[2]s,
+			COUNT(ww.word_id) FILTER (WHERE %[1]s), COUNT(ww.word_id) FILTER (WHERE NOT (%[1]s))
+		FROM work_word ww
+		JOIN word w
+		ON w.id = ww.word_id
+		JOIN work
+		ON work.id = ww.work_id
+		JOIN author a
+		ON a.id = work.author_id
+		WHERE ww.deleted_at IS NULL
+		AND work.deleted_at IS NULL
+		GROUP BY w.id;
+		`, target, qb.known("w", currentUserID(ctx)))
+	}
+
+	keywords := []domain.Keyword{}
+
+	rows, err := wr.db.Pool.Query(ctx, q, qb.args...)
 	if err != nil {
-		return domain.Word{}, err
+		return &[]domain.Keyword{}, fmt.Errorf("failed to execute query: %w", err)
 	}
+	defer rows.Close()
 
-	return word, nil
+	for rows.Next() {
+		keyword := domain.Keyword{}
+
+		err = rows.Scan(
+			&keyword.ID,
+			&keyword.LemmaRaw,
+			&keyword.LemmaRich,
+			&keyword.Translation,
+			&keyword.FrequencyInLASLA,
+			&keyword.Known,
+			&keyword.TargetCount,
+			&keyword.ReferenceCount,
+		)
+		if err != nil {
+			return &[]domain.Keyword{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		keywords = append(keywords, keyword)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return &[]domain.Keyword{}, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return &keywords, nil
+}
+
+// wordList is a query that selects a word list, with the order of the words
+// and the builder that holds its arguments. If dispersion is true, the query
+// selects the range, DP, Juilland's D and adjusted frequency of every word
+// after its count; otherwise the dispersion of the words is left empty.
+type wordList struct {
+	q          string
+	orderBy    string
+	qb         *queryBuilder
+	dispersion bool
 }
 
-func (wr *WordRepository) getWordList(ctx context.Context, q string, args ...any) (*[]domain.WordInWork, error) {
+// getWordListPage retrieves the page of the word list that the filter asks
+// for, along with the number of words in the whole list. The total is counted
+// separately, so that it is also known when the page lies past the end of the
+// list.
+func (wr *WordRepository) getWordListPage(ctx context.Context, list wordList, filter domain.WordListFilter) (*[]domain.WordInWork, int, error) {
+	total := 0
+
+	if filter.PageSize > 0 {
+		err := wr.db.Pool.QueryRow(ctx, fmt.Sprintf("SELECT COUNT(*) FROM (%s) l;", list.q), list.qb.args...).Scan(&total)
+		if err != nil {
+			return &[]domain.WordInWork{}, 0, fmt.Errorf("failed to count words: %w", err)
+		}
+	}
+
+	page := list
+	page.orderBy += "\n\t" + list.qb.paginate(filter)
+
 	words := []domain.WordInWork{}
 
-	rows, err := wr.db.Pool.Query(ctx, q, args...)
+	err := wr.streamWordList(ctx, page, func(word domain.WordInWork) error {
+		words = append(words, word)
+		return nil
+	})
 	if err != nil {
-		return &[]domain.WordInWork{}, fmt.Errorf("failed to execute query: %w", err)
+		return &[]domain.WordInWork{}, 0, err
+	}
+
+	if filter.PageSize == 0 {
+		total = len(words)
+	}
+
+	return &words, total, nil
+}
+
+// streamWordList reads the words selected by the list in its order and passes
+// them to fn one at a time, stopping at the first error fn returns.
+func (wr *WordRepository) streamWordList(ctx context.Context, list wordList, fn func(domain.WordInWork) error) error {
+	rows, err := wr.db.Pool.Query(ctx, fmt.Sprintf("%s\tORDER BY %s;", list.q, list.orderBy), list.qb.args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
 		word := domain.WordInWork{}
 
-		err = rows.Scan(&word.ID, &word.LemmaRich, &word.Translation, &word.Known, &word.Count)
+		columns := []any{
+			&word.ID,
+			&word.LemmaRaw,
+			&word.LemmaRich,
+			&word.Translation,
+			&word.FrequencyInLASLA,
+			&word.Known,
+			&word.Count,
+		}
+
+		if list.dispersion {
+			columns = append(columns,
+				&word.Dispersion.Range,
+				&word.Dispersion.DP,
+				&word.Dispersion.JuillandD,
+				&word.Dispersion.AdjustedFrequency,
+			)
+		}
+
+		err = rows.Scan(columns...)
 		if err != nil {
-			return &[]domain.WordInWork{}, fmt.Errorf("failed to scan row: %w", err)
+			return fmt.Errorf("failed to scan row: %w", err)
 		}
 
-		words = append(words, word)
+		err = fn(word)
+		if err != nil {
+			return err
+		}
 	}
 
 	err = rows.Err()
 	if err != nil {
-		return &[]domain.WordInWork{}, fmt.Errorf("failed to read rows: %w", err)
+		return fmt.Errorf("failed to read rows: %w", err)
 	}
 
-	return &words, nil
+	return nil
 }
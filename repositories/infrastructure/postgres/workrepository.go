@@ -77,19 +77,60 @@ func (wr *WorkRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (wr *WorkRepository) Get(ctx context.Context) ([]domain.Work, error) {
-	q := `
-	SELECT w.id, a.id, a.name, w.title
+func (wr *WorkRepository) Get(ctx context.Context, sort domain.WorkSort, descending bool) ([]domain.Work, error) {
+	return wr.get(ctx, sort, descending, domain.WorkFilter{})
+}
+
+// GetByFilter returns the works matching the filter, sorted by author.
+func (wr *WorkRepository) GetByFilter(ctx context.Context, filter domain.WorkFilter) ([]domain.Work, error) {
+	return wr.get(ctx, domain.WorkSortAuthor, false, filter)
+}
+
+func (wr *WorkRepository) get(ctx context.Context, sort domain.WorkSort, descending bool, filter domain.WorkFilter) ([]domain.Work, error) {
+	direction := "ASC"
+	if descending {
+		direction = "DESC"
+	}
+
+	qb := &queryBuilder{}
+	user := qb.arg(currentUserID(ctx))
+	known := knownCondition("wo", user)
+	qb.clauses = qb.matchWorks("w", filter)
+
+	var orderBy string
+
+	switch sort {
+	case domain.WorkSortCoverage:
+		orderBy = fmt.Sprintf("COUNT(ww.id) FILTER (WHERE %s)::float / NULLIF(COUNT(ww.id), 0) %s NULLS LAST, a.name ASC, w.title ASC", known, direction)
+	case domain.WorkSortTitle:
+		orderBy = fmt.Sprintf("w.title %s, a.name ASC", direction)
+	default:
+		orderBy = fmt.Sprintf("a.name %s, w.title ASC", direction)
+	}
+
+	q := fmt.Sprintf(`
+	SELECT w.id, a.id, a.name, w.title, w.period, w.year, w.genre, w.form, w.edition, w.notes, w.tags, COALESCE(uw.read, false),
+		COUNT(ww.id), COUNT(ww.id) FILTER (WHERE %s)
 	FROM work w
 	JOIN author a
 	ON a.id = w.author_id
+	LEFT JOIN user_work uw
+	ON uw.work_id = w.id
+	AND uw.user_id = %s
+	LEFT JOIN work_word ww
+	ON ww.work_id = w.id
+	AND ww.deleted_at IS NULL
+	LEFT JOIN word wo
+	ON wo.id = ww.word_id
 	WHERE w.deleted_at IS NULL
-	ORDER BY a.name ASC, w.title ASC;
-	`
+	%s
+	GROUP BY w.id, a.id, a.name, uw.read
+	ORDER BY %s;
+	`, known, user, qb.conditions(), orderBy)
 
 	works := []domain.Work{}
 
-	rows, err := wr.db.Pool.Query(ctx, q)
+	rows, err := wr.db.Pool.Query(ctx, q, qb.args...)
 	if err != nil {
 		return []domain.Work{}, fmt.Errorf("failed to execute query: %w", err)
 	}
@@ -98,7 +139,22 @@ func (wr *WorkRepository) Get(ctx context.Context) ([]domain.Work, error) {
 	for rows.Next() {
 		work := domain.Work{}
 
-		err = rows.Scan(&work.ID, &work.Author.ID, &work.Author.Name, &work.Title)
+		err = rows.Scan(
+			&work.ID,
+			&work.Author.ID,
+			&work.Author.Name,
+			&work.Title,
+			&work.Period,
+			&work.Year,
+			&work.Genre,
+			&work.Form,
+			&work.Edition,
+			&work.Notes,
+			&work.Tags,
+			&work.Read,
+			&work.TokenCount,
+			&work.KnownTokenCount,
+		)
 		if err != nil {
 			return []domain.Work{}, fmt.Errorf("failed to scan row: %w", err)
 		}
@@ -116,10 +172,14 @@ func (wr *WorkRepository) Get(ctx context.Context) ([]domain.Work, error) {
 
 func (wr *WorkRepository) GetByID(ctx context.Context, id uuid.UUID) (domain.Work, error) {
 	q := `
-	SELECT w.id, a.name, w.title, w.created_at, w.modified_at, w.deleted_at
+	SELECT w.id, a.id, a.name, w.title, w.period, w.year, w.genre, w.form, w.edition, w.notes, w.tags,
+		COALESCE(uw.read, false), COALESCE(uw.reading_position, 0), w.created_at, w.modified_at, w.deleted_at
 	FROM work w
 	JOIN author a
 	ON a.id = w.author_id
+	LEFT JOIN user_work uw
+	ON uw.work_id = w.id
+	AND uw.user_id = $2
 	WHERE w.id = $1
 	AND w.deleted_at IS NULL;
 	`
@@ -131,10 +191,21 @@ func (wr *WorkRepository) GetByID(ctx context.Context, id uuid.UUID) (domain.Wor
 		ctx,
 		q,
 		id,
+		currentUserID(ctx),
 	).Scan(
 		&work.ID,
+		&work.Author.ID,
 		&work.Author.Name,
 		&work.Title,
+		&work.Period,
+		&work.Year,
+		&work.Genre,
+		&work.Form,
+		&work.Edition,
+		&work.Notes,
+		&work.Tags,
+		&work.Read,
+		&work.ReadingPosition,
 		&work.Created,
 		&work.Modified,
 		&deleted,
@@ -148,6 +219,102 @@ func (wr *WorkRepository) GetByID(ctx context.Context, id uuid.UUID) (domain.Wor
 	return work, nil
 }
 
+// SaveReadingPosition remembers where the user last read the work. It is not
+// a change to the work itself, so the modification time is left alone.
+func (wr *WorkRepository) SaveReadingPosition(ctx context.Context, id uuid.UUID, wordIndex int) error {
+	q := `
+	INSERT INTO user_work (user_id, work_id, reading_position)
+	SELECT $3, w.id, $2
+	FROM work w
+	WHERE w.id = $1
+	AND w.deleted_at IS NULL
+	ON CONFLICT (user_id, work_id) DO UPDATE
+	SET reading_position = $2;
+	`
+
+	_, err := wr.db.Pool.Exec(ctx, q, id, wordIndex, currentUserID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+func (wr *WorkRepository) UpdateMetadata(ctx context.Context, id uuid.UUID, metadata domain.WorkMetadata) (domain.Work, error) {
+	q := `
+	UPDATE work
+	SET period = $2, year = $3, genre = $4, form = $5, edition = $6, notes = $7, tags = $8, modified_at = NOW()
+	WHERE id = $1
+	AND deleted_at IS NULL
+	RETURNING id, title, period, year, genre, form, edition, notes, tags, created_at, modified_at, deleted_at;
+	`
+
+	var work domain.Work
+	var deleted sql.NullTime
+
+	err := wr.db.Pool.QueryRow(
+		ctx,
+		q,
+		id,
+		metadata.Period,
+		metadata.Year,
+		metadata.Genre,
+		string(metadata.Form),
+		metadata.Edition,
+		metadata.Notes,
+		metadata.Tags,
+	).Scan(
+		&work.ID,
+		&work.Title,
+		&work.Period,
+		&work.Year,
+		&work.Genre,
+		&work.Form,
+		&work.Edition,
+		&work.Notes,
+		&work.Tags,
+		&work.Created,
+		&work.Modified,
+		&deleted,
+	)
+	if err != nil {
+		return domain.Work{}, err
+	}
+
+	work.Deleted = deleted.Time
+
+	return work, nil
+}
+
+// ToggleReadStatus marks the work as read by the user, or as unread if it
+// already was. Like the reading position, it is kept per user and leaves the
+// work itself alone.
+func (wr *WorkRepository) ToggleReadStatus(ctx context.Context, id uuid.UUID) (domain.Work, error) {
+	q := `
+	INSERT INTO user_work (user_id, work_id, read)
+	SELECT $2, w.id, true
+	FROM work w
+	WHERE w.id = $1
+	AND w.deleted_at IS NULL
+	ON CONFLICT (user_id, work_id) DO UPDATE
+	SET read = NOT user_work.read
+	RETURNING work_id, read, reading_position;
+	`
+
+	var work domain.Work
+
+	err := wr.db.Pool.QueryRow(ctx, q, id, currentUserID(ctx)).Scan(
+		&work.ID,
+		&work.Read,
+		&work.ReadingPosition,
+	)
+	if err != nil {
+		return domain.Work{}, err
+	}
+
+	return work, nil
+}
+
 func (wr *WorkRepository) Save(ctx context.Context, db database.Executor, w domain.Work, authorID uuid.UUID) (domain.Work, error) {
 	q := `
 	INSERT INTO work (id, author_id, title, modified_at, deleted_at)
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/nienkeboomsma/vocabularium/database"
@@ -10,10 +11,38 @@ import (
 )
 
 type WorkWordRepository struct {
+	db *database.Client
 }
 
-func NewWorkWordRepository() *WorkWordRepository {
-	return &WorkWordRepository{}
+func NewWorkWordRepository(db *database.Client) *WorkWordRepository {
+	return &WorkWordRepository{db: db}
+}
+
+// GetByWorkID returns the words of the work in order.
+func (wr *WorkWordRepository) GetByWorkID(ctx context.Context, workID uuid.UUID) ([]domain.WorkWord, error) {
+	q := `
+	SELECT id, work_id, word_id, word_index, sentence_index, original_form, tag, morph_analysis
+	FROM work_word
+	WHERE work_id = $1
+	AND deleted_at IS NULL
+	ORDER BY word_index ASC;
+	`
+
+	return wr.getWorkWords(ctx, q, workID)
+}
+
+// GetSentence returns the words of a sentence in the work in order.
+func (wr *WorkWordRepository) GetSentence(ctx context.Context, workID uuid.UUID, sentenceIndex int) ([]domain.WorkWord, error) {
+	q := `
+	SELECT id, work_id, word_id, word_index, sentence_index, original_form, tag, morph_analysis
+	FROM work_word
+	WHERE work_id = $1
+	AND sentence_index = $2
+	AND deleted_at IS NULL
+	ORDER BY word_index ASC;
+	`
+
+	return wr.getWorkWords(ctx, q, workID, sentenceIndex)
 }
 
 func (wr *WorkWordRepository) Save(ctx context.Context, db database.Executor, ww domain.WorkWord, workID uuid.UUID) (domain.WorkWord, error) {
@@ -69,3 +98,31 @@ func (wr *WorkWordRepository) Save(ctx context.Context, db database.Executor, ww
 
 	return updatedWorkWord, nil
 }
+
+func (wr *WorkWordRepository) getWorkWords(ctx context.Context, q string, args ...any) ([]domain.WorkWord, error) {
+	workWords := []domain.WorkWord{}
+
+	rows, err := wr.db.Pool.Query(ctx, q, args...)
+	if err != nil {
+		return []domain.WorkWord{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ww domain.WorkWord
+
+		err = rows.Scan(&ww.ID, &ww.WorkID, &ww.WordID, &ww.WordIndex, &ww.SentenceIndex, &ww.OriginalForm, &ww.Tag, &ww.MorphoSyntacticalAnalysis)
+		if err != nil {
+			return []domain.WorkWord{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		workWords = append(workWords, ww)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []domain.WorkWord{}, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return workWords, nil
+}
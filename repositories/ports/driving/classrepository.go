@@ -0,0 +1,22 @@
+package driving
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type ClassRepository interface {
+	Delete(ctx context.Context, id uuid.UUID) error
+	DeleteAssignment(ctx context.Context, classID, id uuid.UUID) error
+	Get(ctx context.Context) ([]domain.Class, error)
+	GetByID(ctx context.Context, id uuid.UUID) (domain.Class, error)
+	GetCoverage(ctx context.Context, classID uuid.UUID) ([]domain.StudentCoverage, error)
+	GetKnownBy(ctx context.Context, classID uuid.UUID, scope domain.Scope) (map[uuid.UUID]int, error)
+	Join(ctx context.Context, code string) (uuid.UUID, error)
+	Leave(ctx context.Context, classID uuid.UUID) error
+	RemoveStudent(ctx context.Context, classID, userID uuid.UUID) error
+	Save(ctx context.Context, c domain.Class) (domain.Class, error)
+	SaveAssignment(ctx context.Context, a domain.Assignment) (domain.Assignment, error)
+}
@@ -0,0 +1,15 @@
+package driving
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type CollectionRepository interface {
+	Delete(ctx context.Context, id uuid.UUID) error
+	Get(ctx context.Context) ([]domain.Collection, error)
+	GetByID(ctx context.Context, id uuid.UUID) (domain.Collection, error)
+	Save(ctx context.Context, c domain.Collection) (domain.Collection, error)
+}
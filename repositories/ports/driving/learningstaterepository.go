@@ -0,0 +1,26 @@
+package driving
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type LearningStateRepository interface {
+	CountKnown(ctx context.Context) (int, error)
+	Enqueue(ctx context.Context, wordIDs []uuid.UUID, limit int, now time.Time) (int, error)
+	GetActions(ctx context.Context, limit int) ([]domain.StatusAction, error)
+	GetByWordID(ctx context.Context, wordID uuid.UUID) (domain.LearningState, error)
+	GetDailyProgress(ctx context.Context) ([]domain.DailyProgress, error)
+	GetDue(ctx context.Context, workID uuid.UUID, limit int, now time.Time) ([]domain.Flashcard, int, error)
+	GetHistory(ctx context.Context, wordID uuid.UUID) ([]domain.StatusChange, error)
+	GetReviews(ctx context.Context, wordID uuid.UUID) ([]domain.Review, error)
+	MarkKnown(ctx context.Context, wordIDs []uuid.UUID, source domain.StatusSource, description string, now time.Time) (domain.StatusAction, error)
+	Review(ctx context.Context, wordID uuid.UUID, grade domain.Grade, now time.Time) (domain.LearningState, error)
+	SetStatus(ctx context.Context, wordID uuid.UUID, status domain.LearningStatus, now time.Time) (domain.LearningState, error)
+	ToggleKnown(ctx context.Context, wordID uuid.UUID, now time.Time) (domain.LearningState, error)
+	Undo(ctx context.Context, actionID uuid.UUID, now time.Time) (domain.StatusAction, error)
+	UndoLatest(ctx context.Context, count int, now time.Time) ([]domain.StatusAction, error)
+}
@@ -0,0 +1,16 @@
+package driving
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nienkeboomsma/vocabularium/database"
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type SectionRepository interface {
+	DeleteByWorkID(ctx context.Context, db database.Executor, workID uuid.UUID) error
+	GetByID(ctx context.Context, id uuid.UUID) (domain.Section, error)
+	GetByWorkID(ctx context.Context, workID uuid.UUID) ([]domain.Section, error)
+	Save(ctx context.Context, db database.Executor, s domain.Section, workID uuid.UUID) (domain.Section, error)
+}
@@ -0,0 +1,16 @@
+package driving
+
+import (
+	"context"
+	"time"
+
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+type UserRepository interface {
+	DeleteSession(ctx context.Context, tokenHash string) error
+	GetByName(ctx context.Context, name string) (domain.User, error)
+	GetBySession(ctx context.Context, tokenHash string, now time.Time) (domain.User, error)
+	Register(ctx context.Context, u domain.User) (domain.User, error)
+	SaveSession(ctx context.Context, s domain.Session) error
+}
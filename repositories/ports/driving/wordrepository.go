@@ -9,10 +9,16 @@ import (
 )
 
 type WordRepository interface {
-	GetFrequencyList(ctx context.Context) (*[]domain.WordInWork, error)
-	GetFrequencyListByAuthorID(ctx context.Context, authorID uuid.UUID) (*[]domain.WordInWork, error)
-	GetFrequencyListByWorkID(ctx context.Context, workID uuid.UUID) (*[]domain.WordInWork, error)
-	GetGlossaryByWorkID(ctx context.Context, workID uuid.UUID) (*[]domain.WordInWork, error)
+	GetByID(ctx context.Context, id uuid.UUID) (domain.Word, error)
+	GetComparison(ctx context.Context, a, b domain.Scope, filter domain.WordListFilter) (*[]domain.ComparedWord, error)
+	GetExamples(ctx context.Context, scope domain.Scope) (map[uuid.UUID]domain.Example, error)
+	GetFrequencyList(ctx context.Context, scope domain.Scope, filter domain.WordListFilter) (*[]domain.WordInWork, int, error)
+	GetGlossary(ctx context.Context, scope domain.Scope, options domain.GlossaryOptions, filter domain.WordListFilter) (*[]domain.WordInWork, int, error)
+	GetKeywords(ctx context.Context, scope domain.Scope, reference domain.KeynessReference) (*[]domain.Keyword, error)
+	GetLemmaCountsByWork(ctx context.Context) (map[uuid.UUID]map[uuid.UUID]int, error)
+	GetLemmaStreamsBySection(ctx context.Context, workID uuid.UUID) (map[uuid.UUID][]uuid.UUID, error)
+	GetLemmaStreamsByWork(ctx context.Context) (map[uuid.UUID][]uuid.UUID, error)
 	Insert(ctx context.Context, db database.Executor, w domain.Word) (domain.Word, error)
-	ToggleKnownStatus(ctx context.Context, wordID uuid.UUID) (domain.Word, error)
+	StreamFrequencyList(ctx context.Context, scope domain.Scope, filter domain.WordListFilter, fn func(domain.WordInWork) error) error
+	StreamGlossary(ctx context.Context, scope domain.Scope, options domain.GlossaryOptions, filter domain.WordListFilter, fn func(domain.WordInWork) error) error
 }
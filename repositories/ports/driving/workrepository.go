@@ -10,7 +10,11 @@ import (
 
 type WorkRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
-	Get(ctx context.Context) ([]domain.Work, error)
+	Get(ctx context.Context, sort domain.WorkSort, descending bool) ([]domain.Work, error)
+	GetByFilter(ctx context.Context, filter domain.WorkFilter) ([]domain.Work, error)
 	GetByID(ctx context.Context, id uuid.UUID) (domain.Work, error)
 	Save(ctx context.Context, db database.Executor, w domain.Work, authorID uuid.UUID) (domain.Work, error)
+	SaveReadingPosition(ctx context.Context, id uuid.UUID, wordIndex int) error
+	ToggleReadStatus(ctx context.Context, id uuid.UUID) (domain.Work, error)
+	UpdateMetadata(ctx context.Context, id uuid.UUID, metadata domain.WorkMetadata) (domain.Work, error)
 }
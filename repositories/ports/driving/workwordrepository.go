@@ -9,5 +9,7 @@ import (
 )
 
 type WorkWordRepository interface {
+	GetByWorkID(ctx context.Context, workID uuid.UUID) ([]domain.WorkWord, error)
+	GetSentence(ctx context.Context, workID uuid.UUID, sentenceIndex int) ([]domain.WorkWord, error)
 	Save(ctx context.Context, db database.Executor, ww domain.WorkWord, workID uuid.UUID) (domain.WorkWord, error)
 }
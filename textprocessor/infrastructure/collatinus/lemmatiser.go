@@ -7,12 +7,7 @@ import (
 	"os/exec"
 )
 
-func lemmatise(chunks []string, output io.Writer, language Language) error {
-	err := setLanguage(language)
-	if err != nil {
-		return fmt.Errorf("failed to set language to %s: %w", language, err)
-	}
-
+func lemmatise(chunks []string, output io.Writer) error {
 	for _, chunk := range chunks {
 		cmd := exec.Command("/collatinus/bin/Client_C11", "-p2", chunk)
 
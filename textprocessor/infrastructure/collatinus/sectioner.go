@@ -0,0 +1,326 @@
+package collatinus
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/nienkeboomsma/vocabularium/domain"
+)
+
+// segment is a stretch of text that lies within the same sections; sections
+// holds the indices of those sections, outermost first.
+type segment struct {
+	text     string
+	sections []int
+}
+
+// headingPattern matches Markdown-style headings in plain text, where the
+// number of hashes gives the level of the section, e.g. "# Book 1" and
+// "## Chapter 1".
+var headingPattern = regexp.MustCompile(`^(#{1,6})\s+(.+?)[\s#]*$`)
+
+// divideIntoSections splits the input into segments and builds the section
+// tree, either from the structure of a TEI document or from the headings in
+// plain text. Text without any structure results in a single segment and no
+// sections. The word ranges of the sections are not yet known.
+func divideIntoSections(input []byte) ([]segment, []domain.Section, error) {
+	if bytes.Contains(input, []byte("<TEI")) {
+		return divideTEI(input)
+	}
+
+	segments, sections := divideByHeadings(string(input))
+
+	return segments, sections, nil
+}
+
+// sectionTree keeps track of the open sections while a document is read.
+type sectionTree struct {
+	sections []domain.Section
+	open     []int
+	children map[int]int
+	current  strings.Builder
+	segments []segment
+}
+
+func newSectionTree() *sectionTree {
+	return &sectionTree{
+		sections: []domain.Section{},
+		children: map[int]int{},
+		segments: []segment{},
+	}
+}
+
+// flush ends the current segment.
+func (st *sectionTree) flush() {
+	if strings.TrimSpace(st.current.String()) != "" {
+		st.segments = append(st.segments, segment{
+			text:     st.current.String(),
+			sections: append([]int{}, st.open...),
+		})
+	}
+
+	st.current.Reset()
+}
+
+// push opens a section inside the innermost open section. Sections without a
+// label are numbered among their siblings.
+func (st *sectionTree) push(kind, label string) {
+	st.flush()
+
+	parent := -1
+	section := domain.Section{
+		ID:       uuid.New(),
+		Position: len(st.sections) + 1,
+		Depth:    len(st.open),
+		Kind:     kind,
+	}
+
+	if len(st.open) > 0 {
+		parent = st.open[len(st.open)-1]
+		section.ParentID = st.sections[parent].ID
+	}
+
+	st.children[parent]++
+
+	section.Label = strings.TrimSpace(label)
+	if section.Label == "" {
+		section.Label = strconv.Itoa(st.children[parent])
+	}
+
+	section.Citation = section.Label
+	if parent >= 0 {
+		section.Citation = st.sections[parent].Citation + "." + section.Label
+	}
+
+	st.open = append(st.open, len(st.sections))
+	st.sections = append(st.sections, section)
+}
+
+// pop closes the innermost open section.
+func (st *sectionTree) pop() {
+	st.flush()
+
+	if len(st.open) > 0 {
+		st.open = st.open[:len(st.open)-1]
+	}
+}
+
+func divideByHeadings(text string) ([]segment, []domain.Section) {
+	st := newSectionTree()
+	levels := []int{}
+
+	for _, line := range strings.Split(text, "\n") {
+		match := headingPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			st.current.WriteString(line)
+			st.current.WriteString("\n")
+			continue
+		}
+
+		level := len(match[1])
+
+		for len(levels) > 0 && levels[len(levels)-1] >= level {
+			st.pop()
+			levels = levels[:len(levels)-1]
+		}
+
+		st.push("", match[2])
+		levels = append(levels, level)
+	}
+
+	st.flush()
+
+	return st.segments, st.sections
+}
+
+// divideTEI reads the divisions (div, div1 to div7), line groups (lg) and
+// lines (l) of a TEI document as sections. The header, notes, headings and
+// any translation or commentary are left out, as is the original of every
+// correction or expansion; an EpiDoc edition div is not a section itself.
+func divideTEI(input []byte) ([]segment, []domain.Section, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(input))
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+
+	st := newSectionTree()
+
+	// Every start element pushes onto this stack whether it opened a
+	// section, so that the matching end element knows what to close
+	opened := []bool{}
+	skipping := 0
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return []segment{}, []domain.Section{}, fmt.Errorf("failed to parse TEI: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			kind, isSection, skip := classifyTEIElement(t)
+
+			if skipping > 0 || skip {
+				skipping++
+				opened = append(opened, false)
+				continue
+			}
+
+			if isSection {
+				st.push(kind, teiAttr(t, "n"))
+			}
+
+			opened = append(opened, isSection)
+		case xml.EndElement:
+			if len(opened) == 0 {
+				continue
+			}
+
+			isSection := opened[len(opened)-1]
+			opened = opened[:len(opened)-1]
+
+			if skipping > 0 {
+				skipping--
+				continue
+			}
+
+			if isSection {
+				st.pop()
+			}
+
+			// Keep words on either side of a closing element apart
+			st.current.WriteString(" ")
+		case xml.CharData:
+			if skipping == 0 {
+				st.current.Write(t)
+			}
+		}
+	}
+
+	st.flush()
+
+	return st.segments, st.sections, nil
+}
+
+// classifyTEIElement returns the kind of section the element starts, if any,
+// and whether its contents should be skipped.
+func classifyTEIElement(element xml.StartElement) (string, bool, bool) {
+	elementType := teiAttr(element, "type")
+
+	switch element.Name.Local {
+	case "teiHeader", "note", "head", "fw", "front", "back", "figure", "bibl", "sic", "orig", "abbr", "del":
+		return "", false, true
+	case "div", "div1", "div2", "div3", "div4", "div5", "div6", "div7":
+		switch elementType {
+		case "edition":
+			return "", false, false
+		case "translation", "commentary", "apparatus":
+			return "", false, true
+		case "textpart":
+			return teiAttr(element, "subtype"), true, false
+		default:
+			return elementType, true, false
+		}
+	case "lg":
+		return elementType, true, false
+	case "l":
+		return "line", true, false
+	default:
+		return "", false, false
+	}
+}
+
+func teiAttr(element xml.StartElement, name string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+
+	return ""
+}
+
+// countWords counts the words in sanitised text the way Collatinus divides it,
+// i.e. as runs of letters, so that a segment can be given its share of the
+// words Collatinus returns for the whole text.
+func countWords(text string) int {
+	count := 0
+	inWord := false
+
+	for _, r := range text {
+		isLetter := unicode.IsLetter(r) || unicode.Is(unicode.Mn, r)
+
+		if isLetter && !inWord {
+			count++
+		}
+
+		inWord = isLetter
+	}
+
+	return count
+}
+
+// fitWordCounts makes the word counts of the segments add up to the number of
+// words Collatinus returned, should the two disagree: words that were counted
+// in excess are taken from the last segments and words that were missed are
+// added to the last segment with words.
+func fitWordCounts(wordCounts []int, total int) []int {
+	fitted := append([]int{}, wordCounts...)
+	surplus := sum(fitted) - total
+
+	for i := len(fitted) - 1; i >= 0 && surplus > 0; i-- {
+		taken := min(fitted[i], surplus)
+		fitted[i] -= taken
+		surplus -= taken
+	}
+
+	if surplus < 0 {
+		for i := len(fitted) - 1; i >= 0; i-- {
+			if fitted[i] > 0 || i == 0 {
+				fitted[i] -= surplus
+				break
+			}
+		}
+	}
+
+	return fitted
+}
+
+// assignWordRanges sets the word range of every section given the number of
+// words in each segment, and drops the sections that contain no words.
+func assignWordRanges(segments []segment, wordCounts []int, sections []domain.Section) []domain.Section {
+	wordCount := 0
+
+	for i, segment := range segments {
+		if wordCounts[i] == 0 {
+			continue
+		}
+
+		for _, section := range segment.sections {
+			sections[section].Extend(wordCount+1, wordCount+wordCounts[i])
+		}
+
+		wordCount += wordCounts[i]
+	}
+
+	withWords := []domain.Section{}
+
+	for _, section := range sections {
+		if section.FirstWordIndex > 0 {
+			section.Position = len(withWords) + 1
+			withWords = append(withWords, section)
+		}
+	}
+
+	return withWords
+}
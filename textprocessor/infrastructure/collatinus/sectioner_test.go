@@ -0,0 +1,134 @@
+package collatinus
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDivideByHeadings(t *testing.T) {
+	input := "Praefatio prima.\n# Liber I\n## Caput 1\nGallia est omnis divisa in partes tres.\n## Caput 2\nApud Helvetios longe nobilissimus fuit Orgetorix.\n# Liber II\nCum esset Caesar in citeriore Gallia."
+
+	segments, sections := divideByHeadings(input)
+
+	assert.Len(t, segments, 4)
+	assert.Equal(t, "Praefatio prima.\n", segments[0].text)
+	assert.Empty(t, segments[0].sections)
+	assert.Equal(t, []int{0, 1}, segments[1].sections)
+	assert.Equal(t, []int{0, 2}, segments[2].sections)
+	assert.Equal(t, []int{3}, segments[3].sections)
+
+	assert.Len(t, sections, 4)
+	assert.Equal(t, "Liber I", sections[0].Label)
+	assert.Equal(t, 0, sections[0].Depth)
+	assert.Equal(t, "Liber I.Caput 2", sections[2].Citation)
+	assert.Equal(t, sections[0].ID, sections[2].ParentID)
+	assert.Equal(t, 1, sections[2].Depth)
+}
+
+func TestDivideByHeadingsWithoutHeadings(t *testing.T) {
+	segments, sections := divideByHeadings("Arma virumque cano.\nTroiae qui primus ab oris.")
+
+	assert.Len(t, segments, 1)
+	assert.Empty(t, sections)
+}
+
+func TestDivideTEI(t *testing.T) {
+	input := `<?xml version="1.0" encoding="UTF-8"?>
+<TEI xmlns="http://www.tei-c.org/ns/1.0">
+	<teiHeader><fileDesc><titleStmt><title>Carmina</title></titleStmt></fileDesc></teiHeader>
+	<text>
+		<body>
+			<div type="edition">
+				<div type="textpart" subtype="poem" n="5">
+					<head>Ad Lesbiam</head>
+					<l n="1">Vivamus, mea Lesbia, atque amemus,</l>
+					<l n="2">rumoresque senum severiorum<note>scil. senum</note></l>
+				</div>
+				<div type="textpart" subtype="poem" n="85">
+					<l>Odi et amo&mdash;quare id faciam?</l>
+				</div>
+			</div>
+			<div type="translation"><p>Let us live, my Lesbia.</p></div>
+		</body>
+	</text>
+</TEI>`
+
+	segments, sections, err := divideTEI([]byte(input))
+
+	assert.NoError(t, err)
+	assert.Len(t, sections, 5)
+	assert.Equal(t, "poem", sections[0].Kind)
+	assert.Equal(t, "5", sections[0].Citation)
+	assert.Equal(t, "line", sections[2].Kind)
+	assert.Equal(t, "5.2", sections[2].Citation)
+	assert.Equal(t, "85.1", sections[4].Citation)
+
+	texts := []string{}
+	for _, segment := range segments {
+		texts = append(texts, strings.Join(strings.Fields(segment.text), " "))
+	}
+
+	assert.Equal(t, []string{
+		"Vivamus, mea Lesbia, atque amemus,",
+		"rumoresque senum severiorum",
+		"Odi et amo—quare id faciam?",
+	}, texts)
+	assert.Equal(t, []int{0, 2}, segments[1].sections)
+}
+
+func TestAssignWordRanges(t *testing.T) {
+	_, sections := divideByHeadings("# I\n## 1\nx\n## 2\n# II\n## 1\ny")
+	segments := []segment{
+		{text: "x", sections: []int{0, 1}},
+		{text: "y", sections: []int{3, 4}},
+	}
+
+	sections = assignWordRanges(segments, []int{7, 5}, sections)
+
+	assert.Len(t, sections, 4)
+	assert.Equal(t, []int{1, 7}, []int{sections[0].FirstWordIndex, sections[0].LastWordIndex})
+	assert.Equal(t, []int{1, 7}, []int{sections[1].FirstWordIndex, sections[1].LastWordIndex})
+	assert.Equal(t, "II", sections[2].Citation)
+	assert.Equal(t, []int{8, 12}, []int{sections[2].FirstWordIndex, sections[2].LastWordIndex})
+	assert.Equal(t, 4, sections[3].Position)
+}
+
+func TestCountWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+	}{
+		{name: "empty", input: "", expected: 0},
+		{name: "punctuation", input: "Arma virumque cano, Troiae qui primus ab oris", expected: 8},
+		{name: "macrons and combining marks", input: "Mūsa, mihī causās memorā", expected: 4},
+		{name: "only punctuation", input: " ... ; ", expected: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, countWords(test.input))
+		})
+	}
+}
+
+func TestFitWordCounts(t *testing.T) {
+	tests := []struct {
+		name       string
+		wordCounts []int
+		total      int
+		expected   []int
+	}{
+		{name: "matching", wordCounts: []int{3, 4}, total: 7, expected: []int{3, 4}},
+		{name: "too many", wordCounts: []int{3, 4, 1}, total: 5, expected: []int{3, 2, 0}},
+		{name: "too few", wordCounts: []int{3, 4, 0}, total: 9, expected: []int{3, 6, 0}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, fitWordCounts(test.wordCounts, test.total))
+		})
+	}
+}
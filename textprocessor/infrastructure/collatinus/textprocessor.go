@@ -1,6 +1,7 @@
 package collatinus
 
 import (
+	"bufio"
 	"bytes"
 	"errors"
 	"fmt"
@@ -63,22 +64,78 @@ func NewTextProcessor(language string) (*TextProcessor, error) {
 	}, nil
 }
 
-func (tp *TextProcessor) Process(input []byte) (*[]domain.WorkWord, *map[uuid.UUID]domain.Word, []string, error) {
-	sanitised := sanitise(input)
+// Process lemmatises the input and divides it into sections. The text of all
+// sections is lemmatised at once, so that sentences can run across sections,
+// such as the lines of a poem; the word ranges of the sections are derived
+// afterwards from the number of words in each of them.
+func (tp *TextProcessor) Process(input []byte) (*[]domain.WorkWord, *map[uuid.UUID]domain.Word, *[]domain.Section, []string, error) {
+	segments, sections, err := divideIntoSections(input)
+	if err != nil {
+		return &[]domain.WorkWord{}, &map[uuid.UUID]domain.Word{}, &[]domain.Section{}, []string{}, fmt.Errorf("failed to divide into sections: %w", err)
+	}
+
+	texts := make([]string, 0, len(segments))
+	wordCounts := make([]int, len(segments))
+
+	for i, segment := range segments {
+		sanitised := sanitise([]byte(segment.text))
+
+		texts = append(texts, sanitised)
+		wordCounts[i] = countWords(sanitised)
+	}
 
-	chunks, err := chunkBySentence(sanitised)
+	chunks, err := chunkBySentence(strings.Join(texts, " "))
 	if err != nil {
-		return &[]domain.WorkWord{}, &map[uuid.UUID]domain.Word{}, []string{}, fmt.Errorf("failed to divide into sentences: %w", err)
+		return &[]domain.WorkWord{}, &map[uuid.UUID]domain.Word{}, &[]domain.Section{}, []string{}, fmt.Errorf("failed to divide into sentences: %w", err)
+	}
+
+	err = setLanguage(tp.language)
+	if err != nil {
+		return &[]domain.WorkWord{}, &map[uuid.UUID]domain.Word{}, &[]domain.Section{}, []string{}, fmt.Errorf("failed to set language to %s: %w", tp.language, err)
 	}
 
 	var total bytes.Buffer
 
-	err = lemmatise(chunks, &total, tp.language)
+	err = lemmatise(chunks, &total)
 	if err != nil {
-		return &[]domain.WorkWord{}, &map[uuid.UUID]domain.Word{}, []string{}, fmt.Errorf("failed to lemmatise: %w", err)
+		return &[]domain.WorkWord{}, &map[uuid.UUID]domain.Word{}, &[]domain.Section{}, []string{}, fmt.Errorf("failed to lemmatise: %w", err)
 	}
 
+	lemmatisedCount := countLines(total.Bytes())
 	workWords, words, logs := mapToWords(&total)
 
-	return workWords, words, logs, nil
+	if len(sections) > 0 && sum(wordCounts) != lemmatisedCount {
+		logs = append(logs, fmt.Sprintf("⚠️ counted %d words in the sections but Collatinus returned %d; the word ranges of the sections may be off", sum(wordCounts), lemmatisedCount))
+	}
+
+	sections = assignWordRanges(segments, fitWordCounts(wordCounts, lemmatisedCount), sections)
+
+	return workWords, words, &sections, logs, nil
+}
+
+// countLines counts the non-empty lines in the output of Collatinus, which is
+// how mapToWords numbers the words.
+func countLines(data []byte) int {
+	count := 0
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 1024*1024), 10*1024*1024)
+
+	for scanner.Scan() {
+		if scanner.Text() != "" {
+			count++
+		}
+	}
+
+	return count
+}
+
+func sum(values []int) int {
+	total := 0
+
+	for _, value := range values {
+		total += value
+	}
+
+	return total
 }
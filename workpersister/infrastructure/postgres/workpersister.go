@@ -14,6 +14,7 @@ import (
 type WorkPersister struct {
 	db                 *database.Client
 	authorRepository   driving.AuthorRepository
+	sectionRepository  driving.SectionRepository
 	workRepository     driving.WorkRepository
 	wordRepository     driving.WordRepository
 	workWordRepository driving.WorkWordRepository
@@ -22,6 +23,7 @@ type WorkPersister struct {
 func NewWorkPersister(
 	db *database.Client,
 	authorRepository driving.AuthorRepository,
+	sectionRepository driving.SectionRepository,
 	workRepository driving.WorkRepository,
 	wordRepository driving.WordRepository,
 	workWordRepository driving.WorkWordRepository,
@@ -29,13 +31,14 @@ func NewWorkPersister(
 	return &WorkPersister{
 		db:                 db,
 		authorRepository:   authorRepository,
+		sectionRepository:  sectionRepository,
 		workRepository:     workRepository,
 		wordRepository:     wordRepository,
 		workWordRepository: workWordRepository,
 	}
 }
 
-func (wp *WorkPersister) Persist(ctx context.Context, author domain.Author, work domain.Work, words *map[uuid.UUID]domain.Word, workWords *[]domain.WorkWord) error {
+func (wp *WorkPersister) Persist(ctx context.Context, author domain.Author, work domain.Work, words *map[uuid.UUID]domain.Word, workWords *[]domain.WorkWord, sections *[]domain.Section) error {
 	tx, err := wp.db.Pool.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to start a transaction: %w", err)
@@ -63,6 +66,18 @@ func (wp *WorkPersister) Persist(ctx context.Context, author domain.Author, work
 		}
 	}
 
+	err = wp.sectionRepository.DeleteByWorkID(ctx, tx, updatedWork.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete sections: %w", err)
+	}
+
+	for _, section := range *sections {
+		_, err := wp.sectionRepository.Save(ctx, tx, section, updatedWork.ID)
+		if err != nil {
+			return fmt.Errorf("failed to save section: %w", err)
+		}
+	}
+
 	err = tx.Commit(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to commit the transaction: %w", err)
@@ -8,5 +8,5 @@ import (
 )
 
 type WorkPersister interface {
-	Persist(ctx context.Context, author domain.Author, work domain.Work, words *map[uuid.UUID]domain.Word, workWords *[]domain.WorkWord) error
+	Persist(ctx context.Context, author domain.Author, work domain.Work, words *map[uuid.UUID]domain.Word, workWords *[]domain.WorkWord, sections *[]domain.Section) error
 }